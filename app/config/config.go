@@ -3,12 +3,25 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
 )
 
+// DefaultUserAgent is sent with health checks when no user_agent is configured
+const DefaultUserAgent = "SiteWatch/1.0"
+
+// DefaultPublicURL is the dashboard address linked from alerts
+const DefaultPublicURL = "https://sitewatch.ezeebits.in"
+
+// archivePrefixPattern matches the characters allowed in an archive prefix
+var archivePrefixPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
 // LoadConfig loads configuration from a JSON file
 func LoadConfig(filename string) (*structs.Config, error) {
 	data, err := os.ReadFile(filename)
@@ -23,38 +36,485 @@ func LoadConfig(filename string) (*structs.Config, error) {
 
 	// Set defaults
 	if config.CheckInterval.Duration == 0 {
-		config.CheckInterval.Duration = 30 * time.Second
+		config.CheckInterval.Duration = structs.BuiltinEndpointDefaults.CheckInterval
+	}
+
+	// Check settings for endpoints that leave them unset; new endpoints are checked at check_interval
+	if config.DefaultTimeout.Duration == 0 {
+		config.DefaultTimeout.Duration = structs.BuiltinEndpointDefaults.Timeout
+	}
+	if config.DefaultInterval.Duration == 0 {
+		config.DefaultInterval.Duration = config.CheckInterval.Duration
+	}
+	if config.DefaultExpectedStatus == 0 {
+		config.DefaultExpectedStatus = structs.BuiltinEndpointDefaults.ExpectedStatus
+	}
+	if config.DefaultFailureThreshold == 0 {
+		config.DefaultFailureThreshold = structs.BuiltinEndpointDefaults.FailureThreshold
+	}
+	if config.DefaultSuccessThreshold == 0 {
+		config.DefaultSuccessThreshold = structs.BuiltinEndpointDefaults.SuccessThreshold
+	}
+	if config.DefaultTimeout.Duration < 0 || config.DefaultInterval.Duration < 0 {
+		return nil, fmt.Errorf("default_timeout and default_interval must be positive")
+	}
+	if config.DefaultExpectedStatus < 100 || config.DefaultExpectedStatus > 599 {
+		return nil, fmt.Errorf("invalid default_expected_status %d: must be an HTTP status code", config.DefaultExpectedStatus)
+	}
+	if config.DefaultFailureThreshold < 0 || config.DefaultSuccessThreshold < 0 {
+		return nil, fmt.Errorf("default_failure_threshold and default_success_threshold must be positive")
 	}
 	
+	// Extra time a check may run past its timeout before the watchdog cancels it
+	if config.WatchdogGrace.Duration == 0 {
+		config.WatchdogGrace.Duration = 10 * time.Second
+	}
+	if config.NewEndpointGrace.Duration < 0 {
+		return nil, fmt.Errorf("invalid new_endpoint_grace: must not be negative")
+	}
+
+	// Running checks get this long to finish at shutdown before they are cancelled
+	if config.ShutdownTimeout.Duration <= 0 {
+		config.ShutdownTimeout.Duration = 15 * time.Second
+	}
+
+	// Default burn-rate alerting to the common fast-burn threshold over one hour
+	if config.SLABurnRateThreshold == 0 {
+		config.SLABurnRateThreshold = 14.4
+	}
+	if config.SLABurnRateWindow.Duration == 0 {
+		config.SLABurnRateWindow.Duration = 1 * time.Hour
+	}
+
 	if config.Server.Port == 0 {
 		config.Server.Port = 8080
 	}
 
+	// Base URL used for links and action callbacks in alerts
+	if config.PublicURL == "" {
+		config.PublicURL = DefaultPublicURL
+	}
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+
+	// Push services contact this address about the sender
+	if config.WebPushSubject == "" {
+		config.WebPushSubject = config.PublicURL
+	}
+
+	// Webhooks use the native payload unless a preset is chosen
+	switch config.Alerting.WebhookFormat {
+	case "":
+		config.Alerting.WebhookFormat = structs.WebhookFormatDefault
+	case structs.WebhookFormatDefault, structs.WebhookFormatAlertmanager:
+	default:
+		return nil, fmt.Errorf("invalid webhook_format %q: must be default or alertmanager", config.Alerting.WebhookFormat)
+	}
+	if config.Alerting.WebhookVersion == 0 {
+		config.Alerting.WebhookVersion = structs.WebhookVersion1
+	}
+	if !structs.ValidWebhookVersion(config.Alerting.WebhookVersion) {
+		return nil, fmt.Errorf("invalid webhook_version %d: must be 1 or 2", config.Alerting.WebhookVersion)
+	}
+
+	// Operator catalogs add languages or override built-in message templates
+	for lang, path := range config.MessageCatalogs {
+		if err := utils.LoadMessageCatalog(lang, path); err != nil {
+			return nil, err
+		}
+	}
+	if config.Alerting.Language == "" {
+		config.Alerting.Language = utils.DefaultLanguage
+	}
+	if err := utils.ValidateLanguage(config.Alerting.Language); err != nil {
+		return nil, err
+	}
+	for channel, lang := range config.Alerting.ChannelLanguages {
+		switch channel {
+		case structs.ChannelWebhook, structs.ChannelSlack, structs.ChannelEmail, structs.ChannelTeams, structs.ChannelSyslog, structs.ChannelPush:
+		default:
+			return nil, fmt.Errorf("invalid channel_languages channel %q: must be webhook, slack, email, teams, syslog or push", channel)
+		}
+		if err := utils.ValidateLanguage(lang); err != nil {
+			return nil, fmt.Errorf("invalid language for channel %s: %w", channel, err)
+		}
+	}
+
+	if err := config.Alerting.ValidateSeverities(); err != nil {
+		return nil, err
+	}
+
+	if config.Alerting.SyslogEnabled {
+		if config.Alerting.Syslog.Address == "" {
+			return nil, fmt.Errorf("syslog is enabled but no address is set")
+		}
+		switch config.Alerting.Syslog.Network {
+		case "":
+			config.Alerting.Syslog.Network = "udp"
+		case "udp", "tcp", "tls":
+		default:
+			return nil, fmt.Errorf("invalid syslog network %q: must be udp, tcp or tls", config.Alerting.Syslog.Network)
+		}
+		if config.Alerting.Syslog.Facility == "" {
+			config.Alerting.Syslog.Facility = "local0"
+		}
+		if _, err := utils.SyslogFacility(config.Alerting.Syslog.Facility); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.MQTT.Enabled {
+		if config.MQTT.Broker == "" {
+			return nil, fmt.Errorf("mqtt is enabled but no broker is set")
+		}
+		if config.MQTT.ClientID == "" {
+			config.MQTT.ClientID = "sitewatch"
+		}
+		if config.MQTT.TopicPrefix == "" {
+			config.MQTT.TopicPrefix = "sitewatch"
+		}
+		config.MQTT.TopicPrefix = strings.TrimRight(config.MQTT.TopicPrefix, "/")
+		if config.MQTT.KeepAlive.Duration == 0 {
+			config.MQTT.KeepAlive.Duration = 60 * time.Second
+		}
+	}
+
+	if config.EventStream.Enabled {
+		switch config.EventStream.Driver {
+		case structs.EventStreamNATS, structs.EventStreamKafka:
+		default:
+			return nil, fmt.Errorf("invalid event_stream driver %q: must be nats or kafka", config.EventStream.Driver)
+		}
+		if config.EventStream.URL == "" {
+			return nil, fmt.Errorf("event_stream is enabled but no url is set")
+		}
+		if config.EventStream.TopicPrefix == "" {
+			config.EventStream.TopicPrefix = "sitewatch"
+		}
+		config.EventStream.URL = strings.TrimRight(config.EventStream.URL, "/")
+	}
+
+	if config.Firehose.Enabled {
+		if config.Firehose.URL == "" {
+			return nil, fmt.Errorf("firehose is enabled but no url is set")
+		}
+		if config.Firehose.BatchSize <= 0 {
+			config.Firehose.BatchSize = 100
+		}
+		if config.Firehose.FlushInterval.Duration <= 0 {
+			config.Firehose.FlushInterval.Duration = 5 * time.Second
+		}
+	}
+
+	if config.OTLP.Enabled {
+		if config.OTLP.Endpoint == "" {
+			return nil, fmt.Errorf("otlp is enabled but no endpoint is set")
+		}
+		if config.OTLP.ServiceName == "" {
+			config.OTLP.ServiceName = "sitewatch"
+		}
+		if config.OTLP.MetricInterval.Duration <= 0 {
+			config.OTLP.MetricInterval.Duration = time.Minute
+		}
+	}
+
+	if config.Heartbeat.Enabled {
+		if len(config.Heartbeat.URLs) == 0 {
+			return nil, fmt.Errorf("heartbeat is enabled but no urls are set")
+		}
+		for _, heartbeatURL := range config.Heartbeat.URLs {
+			if u, err := url.Parse(heartbeatURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid heartbeat url %q: must be an http or https URL", heartbeatURL)
+			}
+		}
+		if config.Heartbeat.Interval.Duration <= 0 {
+			config.Heartbeat.Interval.Duration = time.Minute
+		}
+		if config.Heartbeat.Timeout.Duration <= 0 {
+			config.Heartbeat.Timeout.Duration = 10 * time.Second
+		}
+	}
+
+	if config.Archive.Enabled {
+		archive := &config.Archive
+		if archive.Provider == "" {
+			archive.Provider = structs.ArchiveS3
+		}
+		switch archive.Provider {
+		case structs.ArchiveS3:
+			if archive.Region == "" {
+				archive.Region = "us-east-1"
+			}
+			if archive.Endpoint == "" {
+				archive.Endpoint = "https://s3." + archive.Region + ".amazonaws.com"
+			}
+		case structs.ArchiveGCS:
+			// GCS accepts S3 requests signed with HMAC keys, for any region
+			if archive.Region == "" {
+				archive.Region = "auto"
+			}
+			if archive.Endpoint == "" {
+				archive.Endpoint = "https://storage.googleapis.com"
+			}
+			archive.PathStyle = true
+		case structs.ArchiveMinIO:
+			if archive.Endpoint == "" {
+				return nil, fmt.Errorf("archive provider minio needs an endpoint")
+			}
+			if archive.Region == "" {
+				archive.Region = "us-east-1"
+			}
+			archive.PathStyle = true
+		default:
+			return nil, fmt.Errorf("invalid archive provider %q: must be s3, gcs or minio", archive.Provider)
+		}
+		if u, err := url.Parse(archive.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid archive endpoint %q: must be an http or https URL", archive.Endpoint)
+		}
+		archive.Endpoint = strings.TrimRight(archive.Endpoint, "/")
+		if archive.Bucket == "" {
+			return nil, fmt.Errorf("archive is enabled but no bucket is set")
+		}
+		// Object keys are used as-is in signed requests, so they stay within URL-safe characters
+		archive.Prefix = strings.Trim(archive.Prefix, "/")
+		if archive.Prefix == "" {
+			archive.Prefix = "sitewatch/history"
+		}
+		if !archivePrefixPattern.MatchString(archive.Prefix) {
+			return nil, fmt.Errorf("invalid archive prefix %q: use letters, digits, '-', '_', '.' and '/'", archive.Prefix)
+		}
+		if archive.AccessKeyID == "" {
+			archive.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		if archive.SecretAccessKey == "" {
+			archive.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+		if archive.AccessKeyID == "" || archive.SecretAccessKey == "" {
+			return nil, fmt.Errorf("archive is enabled but no access_key_id and secret_access_key are set")
+		}
+	}
+
+	// Alert deliveries must never hang, so the alert client always has a timeout
+	if config.AlertHTTP.Timeout.Duration <= 0 {
+		config.AlertHTTP.Timeout.Duration = 15 * time.Second
+	}
+	if config.AlertHTTP.Proxy != "" {
+		proxy, err := url.Parse(config.AlertHTTP.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid alert_http proxy %q", config.AlertHTTP.Proxy)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid alert_http proxy %q: scheme must be http, https or socks5", config.AlertHTTP.Proxy)
+		}
+	}
+	if config.AlertHTTP.CAFile != "" {
+		if _, err := utils.LoadCABundle(config.AlertHTTP.CAFile); err != nil {
+			return nil, fmt.Errorf("invalid alert_http ca_file: %w", err)
+		}
+	}
+
+	if config.HA.Enabled {
+		if config.HA.LockFile == "" {
+			return nil, fmt.Errorf("ha is enabled but no lock_file is set")
+		}
+		if config.HA.NodeID == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, fmt.Errorf("ha is enabled but no node_id is set: %w", err)
+			}
+			config.HA.NodeID = hostname
+		}
+		if config.HA.LeaseDuration.Duration <= 0 {
+			config.HA.LeaseDuration.Duration = 15 * time.Second
+		}
+		if config.HA.SyncFile == "" {
+			config.HA.SyncFile = config.HA.LockFile + ".endpoints.json"
+		}
+	}
+
+	if config.SelfMonitoring.Enabled {
+		if config.SelfMonitoring.Interval.Duration <= 0 {
+			config.SelfMonitoring.Interval.Duration = time.Minute
+		}
+		if config.SelfMonitoring.DBWriteErrors <= 0 {
+			config.SelfMonitoring.DBWriteErrors = 1
+		}
+		if config.SelfMonitoring.DeliveryFailureRate == 0 {
+			config.SelfMonitoring.DeliveryFailureRate = 50
+		}
+		if config.SelfMonitoring.DeliveryFailureRate < 0 || config.SelfMonitoring.DeliveryFailureRate > 100 {
+			return nil, fmt.Errorf("self_monitoring delivery_failure_rate must be between 0 and 100")
+		}
+		if config.SelfMonitoring.MinDeliveries <= 0 {
+			config.SelfMonitoring.MinDeliveries = 5
+		}
+		if config.SelfMonitoring.OverdueChecks <= 0 {
+			config.SelfMonitoring.OverdueChecks = 3
+		}
+	}
+
+	if _, err := utils.ParseCIDRs(config.AdminAllowedCIDRs); err != nil {
+		return nil, fmt.Errorf("invalid admin_allowed_cidrs: %w", err)
+	}
+	if _, err := utils.ParseCIDRs(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted_proxies: %w", err)
+	}
+
+	// Results kept in memory per endpoint for sparklines and dashboard reads
+	if config.RecentResults <= 0 {
+		config.RecentResults = 60
+	}
+
 	// Default SSL expiry warning to 30 days if not set
 	if config.SSLExpiryWarningDays == 0 {
 		config.SSLExpiryWarningDays = 30
 	}
 
+	// Default calendar reminders to 30, 7 and 1 day before expiry
+	if config.SSLCalendarReminders == nil {
+		config.SSLCalendarReminders = []int{30, 7, 1}
+	}
+
+	// Identify the monitor in access logs so WAFs can whitelist it
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+
 	// Default SSL summary time to 09:30 if not set
 	if config.SSLSummaryTime == "" {
 		config.SSLSummaryTime = "09:30"
 	}
 
+	// Alerts, summaries and schedules are rendered in this zone
+	if config.Timezone == "" {
+		config.Timezone = utils.DefaultTimezone
+	}
+	if _, err := utils.LoadTimezone(config.Timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	for i := range config.Digests {
+		if config.Digests[i].Name == "" {
+			config.Digests[i].Name = fmt.Sprintf("digest-%d", i+1)
+		}
+		if config.Digests[i].Timezone == "" {
+			config.Digests[i].Timezone = config.Timezone
+		}
+		if _, err := utils.LoadTimezone(config.Digests[i].Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone for digest %s: %w", config.Digests[i].Name, err)
+		}
+		if err := utils.ValidateLanguage(config.Digests[i].Language); err != nil {
+			return nil, fmt.Errorf("invalid language for digest %s: %w", config.Digests[i].Name, err)
+		}
+		if config.Digests[i].Time == "" {
+			config.Digests[i].Time = "09:30"
+		}
+		if len(config.Digests[i].Recipients) == 0 {
+			return nil, fmt.Errorf("digest %s has no recipients", config.Digests[i].Name)
+		}
+	}
+
+	reportNames := make(map[string]bool)
+	for i := range config.Reports {
+		if config.Reports[i].Name == "" {
+			return nil, fmt.Errorf("report %d has no name", i+1)
+		}
+		if reportNames[config.Reports[i].Name] {
+			return nil, fmt.Errorf("duplicate report name: %s", config.Reports[i].Name)
+		}
+		reportNames[config.Reports[i].Name] = true
+	}
+
 	for i := range config.Endpoints {
 		if config.Endpoints[i].Method == "" {
 			config.Endpoints[i].Method = "GET"
 		}
 		if config.Endpoints[i].Timeout.Duration == 0 {
-			config.Endpoints[i].Timeout.Duration = 10 * time.Second
+			config.Endpoints[i].Timeout.Duration = config.DefaultTimeout.Duration
 		}
-		if config.Endpoints[i].ExpectedStatus == 0 {
-			config.Endpoints[i].ExpectedStatus = 200
+		if config.Endpoints[i].ExpectedStatus == 0 && config.Endpoints[i].CheckType != structs.CheckNegative {
+			config.Endpoints[i].ExpectedStatus = config.DefaultExpectedStatus
 		}
 		if config.Endpoints[i].FailureThreshold == 0 {
-			config.Endpoints[i].FailureThreshold = 3
+			config.Endpoints[i].FailureThreshold = config.DefaultFailureThreshold
 		}
 		if config.Endpoints[i].SuccessThreshold == 0 {
-			config.Endpoints[i].SuccessThreshold = 2
+			config.Endpoints[i].SuccessThreshold = config.DefaultSuccessThreshold
+		}
+		if config.Endpoints[i].Priority == "" {
+			config.Endpoints[i].Priority = structs.PriorityNormal
+		}
+		if config.Endpoints[i].RateLimitMode == "" {
+			config.Endpoints[i].RateLimitMode = structs.RateLimitDegraded
+		}
+		if config.Endpoints[i].Retries < 0 || config.Endpoints[i].Retries > structs.MaxRetries {
+			return nil, fmt.Errorf("invalid retries %d for endpoint %s: must be between 0 and %d", config.Endpoints[i].Retries, config.Endpoints[i].Name, structs.MaxRetries)
+		}
+		if config.Endpoints[i].RetryDelay.Duration < 0 {
+			return nil, fmt.Errorf("invalid retry_delay for endpoint %s: must not be negative", config.Endpoints[i].Name)
+		}
+		if config.Endpoints[i].NetworkThreshold < 0 || config.Endpoints[i].AppThreshold < 0 {
+			return nil, fmt.Errorf("invalid failure threshold for endpoint %s: must not be negative", config.Endpoints[i].Name)
+		}
+		if config.Endpoints[i].FailureDuration.Duration < 0 || config.Endpoints[i].SuccessDuration.Duration < 0 {
+			return nil, fmt.Errorf("invalid failure_duration or success_duration for endpoint %s: must not be negative", config.Endpoints[i].Name)
+		}
+		if config.Endpoints[i].SLATarget < 0 || config.Endpoints[i].SLATarget >= 100 {
+			return nil, fmt.Errorf("invalid sla_target %v for endpoint %s: must be between 0 and 100", config.Endpoints[i].SLATarget, config.Endpoints[i].Name)
+		}
+		if runbook := config.Endpoints[i].RunbookURL; runbook != "" {
+			if u, err := url.Parse(runbook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid runbook_url %q for endpoint %s: must be an http or https URL", runbook, config.Endpoints[i].Name)
+			}
+		}
+		if callback := config.Endpoints[i].CallbackURL; callback != "" {
+			if u, err := url.Parse(callback); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("invalid callback_url %q for endpoint %s: must be an http or https URL", callback, config.Endpoints[i].Name)
+			}
+		}
+		if err := utils.ValidateLabels(config.Endpoints[i].Labels); err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", config.Endpoints[i].Name, err)
+		}
+		if !config.Endpoints[i].Priority.Valid() {
+			return nil, fmt.Errorf("invalid priority %q for endpoint %s", config.Endpoints[i].Priority, config.Endpoints[i].Name)
+		}
+		if schedule := config.Endpoints[i].Schedule; schedule != "" {
+			if _, err := utils.ParseCron(schedule); err != nil {
+				return nil, fmt.Errorf("invalid schedule %q for endpoint %s: %w", schedule, config.Endpoints[i].Name, err)
+			}
+		}
+		if err := loadJourney(&config.Endpoints[i]); err != nil {
+			return nil, fmt.Errorf("endpoint %s: %w", config.Endpoints[i].Name, err)
+		}
+		if !config.Endpoints[i].CheckType.Valid() {
+			return nil, fmt.Errorf("invalid check_type %q for endpoint %s: must be http, browser or negative", config.Endpoints[i].CheckType, config.Endpoints[i].Name)
+		}
+		if contentType := config.Endpoints[i].ContentType; contentType != "" {
+			if err := utils.ValidateContentType(contentType); err != nil {
+				return nil, fmt.Errorf("endpoint %s: %w", config.Endpoints[i].Name, err)
+			}
+		}
+		if expression := config.Endpoints[i].SuccessExpression; expression != "" {
+			if _, err := utils.CompileExpr(expression); err != nil {
+				return nil, fmt.Errorf("invalid success_expression for endpoint %s: %w", config.Endpoints[i].Name, err)
+			}
+		}
+		if pattern := config.Endpoints[i].BodyRegex; pattern != "" {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("invalid body_regex for endpoint %s: %w", config.Endpoints[i].Name, err)
+			}
+		}
+		for _, host := range config.Endpoints[i].AlternateHosts {
+			if err := utils.ValidateHostname(host); err != nil {
+				return nil, fmt.Errorf("invalid alternate_hostnames for endpoint %s: %w", config.Endpoints[i].Name, err)
+			}
+		}
+		if config.Endpoints[i].HistorySample < 0 {
+			return nil, fmt.Errorf("invalid history_sample_every %d for endpoint %s: must not be negative", config.Endpoints[i].HistorySample, config.Endpoints[i].Name)
+		}
+		if !config.Endpoints[i].RateLimitMode.Valid() {
+			return nil, fmt.Errorf("invalid rate_limit_mode %q for endpoint %s: must be degraded or failure", config.Endpoints[i].RateLimitMode, config.Endpoints[i].Name)
 		}
 	}
 
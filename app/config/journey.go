@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"gopkg.in/yaml.v3"
+)
+
+// loadJourney reads an endpoint's journey_file, validates its journey and makes an endpoint
+// with a journey a browser check
+func loadJourney(endpoint *structs.Endpoint) error {
+	if endpoint.JourneyFile != "" {
+		if len(endpoint.Journey) > 0 {
+			return fmt.Errorf("journey and journey_file are mutually exclusive")
+		}
+		steps, err := readJourneyFile(endpoint.JourneyFile)
+		if err != nil {
+			return err
+		}
+		endpoint.Journey = steps
+	}
+
+	if len(endpoint.Journey) == 0 {
+		return nil
+	}
+	if err := structs.ValidateJourney(endpoint.Journey); err != nil {
+		return err
+	}
+	switch endpoint.CheckType {
+	case "":
+		endpoint.CheckType = structs.CheckBrowser
+	case structs.CheckBrowser:
+	default:
+		return fmt.Errorf("a journey needs check_type browser")
+	}
+	return nil
+}
+
+// readJourneyFile parses a list of journey steps from a YAML (.yaml, .yml) or JSON file
+func readJourneyFile(path string) ([]structs.JourneyStep, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journey file: %w", err)
+	}
+
+	var steps []structs.JourneyStep
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &steps)
+	default:
+		err = json.Unmarshal(data, &steps)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse journey file %s: %w", path, err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("journey file %s has no steps", path)
+	}
+	return steps, nil
+}
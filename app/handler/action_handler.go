@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/worker"
+)
+
+// ActionCallback handles ack/suppress buttons from Teams cards. The signed,
+// short-lived token in ?token= authorizes the action instead of a passkey.
+// Only POST is accepted, so link previews and scanners fetching the URL change nothing.
+func (h *HealthHandler) ActionCallback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	action, id, err := worker.ParseActionToken(h.monitor.ActionKey(), r.URL.Query().Get("token"))
+	if err != nil {
+		http.Error(w, "Invalid action link: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var message string
+	switch action {
+	case worker.ActionAck:
+		err = h.monitor.AcknowledgeEndpoint(id)
+		message = "Incident acknowledged"
+	case worker.ActionSuppress:
+		err = h.monitor.SuppressAlerts(id)
+		message = "Alerts suppressed"
+	default:
+		http.Error(w, "Unknown action: "+action, http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		w.Header().Set("CARD-ACTION-STATUS", err.Error())
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	logger.Infof("[%s] %s from alert action", id, message)
+
+	// Teams shows this header to the operator who clicked the button
+	w.Header().Set("CARD-ACTION-STATUS", message)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": message,
+		"id":      id,
+	})
+}
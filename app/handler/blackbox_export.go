@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// blackboxTarget is one entry of a Prometheus file_sd targets file
+type blackboxTarget struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// ExportBlackbox renders the monitored endpoints as a blackbox_exporter config
+// for GET /api/export/blackbox?file=modules|targets
+func (h *HealthHandler) ExportBlackbox(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	allEndpoints, err := h.db.GetAllEndpoints()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var endpoints []*structs.StoredEndpoint
+	for _, ep := range filterArchived(allEndpoints, false) {
+		// A negative endpoint's success is a failed probe, which blackbox_exporter cannot express
+		if ep.Enabled && ep.MonitorHealth && ep.CheckType != structs.CheckNegative && inScope(projectID, ep.ProjectID) {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].ID < endpoints[j].ID
+	})
+
+	// Endpoints with identical probe settings share a module
+	modules := make(map[string]string)
+	targets := make([]blackboxTarget, 0, len(endpoints))
+	for _, ep := range endpoints {
+		body := h.blackboxModule(ep)
+		sum := sha256.Sum256([]byte(body))
+		name := "sitewatch_" + hex.EncodeToString(sum[:4])
+		modules[name] = body
+
+		labels := map[string]string{}
+		for key, value := range ep.Labels {
+			labels[key] = value
+		}
+		labels["module"] = name
+		labels["sitewatch_id"] = ep.ID
+		labels["sitewatch_name"] = ep.Name
+		if ep.ProjectID != "" {
+			labels["project"] = ep.ProjectID
+		}
+		targets = append(targets, blackboxTarget{Targets: []string{ep.URL}, Labels: labels})
+	}
+
+	switch r.URL.Query().Get("file") {
+	case "", "modules":
+		w.Header().Set("Content-Type", "application/yaml")
+		fmt.Fprintln(w, "# Generated by SiteWatch at "+time.Now().Format(time.RFC3339))
+		fmt.Fprintln(w, "modules:")
+		for _, name := range utils.SortedKeys(modules) {
+			fmt.Fprintf(w, "  %s:\n%s", name, modules[name])
+		}
+	case "targets":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(targets)
+	default:
+		http.Error(w, "Invalid file: must be modules or targets", http.StatusBadRequest)
+	}
+}
+
+// blackboxModule renders an endpoint's probe settings as an http prober module body
+func (h *HealthHandler) blackboxModule(ep *structs.StoredEndpoint) string {
+	// Global defaults first, so per-endpoint headers take precedence, as in checks
+	headers := make(map[string]string)
+	if h.config.UserAgent != "" {
+		headers["User-Agent"] = h.config.UserAgent
+	}
+	for key, value := range h.config.DefaultHeaders {
+		headers[key] = value
+	}
+	for key, value := range ep.Headers {
+		headers[key] = value
+	}
+	if ep.HostHeader != "" {
+		headers["Host"] = ep.HostHeader
+	}
+
+	method := ep.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var b strings.Builder
+	b.WriteString("    prober: http\n")
+	fmt.Fprintf(&b, "    timeout: %s\n", promDuration(ep.Timeout))
+	b.WriteString("    http:\n")
+	fmt.Fprintf(&b, "      method: %s\n", method)
+	fmt.Fprintf(&b, "      valid_status_codes: [%d]\n", ep.ExpectedStatus)
+	b.WriteString("      follow_redirects: true\n")
+	if len(headers) > 0 {
+		b.WriteString("      headers:\n")
+		for _, key := range utils.SortedKeys(headers) {
+			fmt.Fprintf(&b, "        %s: %s\n", strconv.Quote(key), strconv.Quote(headers[key]))
+		}
+	}
+	if ep.InsecureSkipVerify {
+		b.WriteString("      tls_config:\n        insecure_skip_verify: true\n")
+	}
+	return b.String()
+}
+
+// promDuration formats a duration the way Prometheus configs accept it
+func promDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d/time.Millisecond)
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+const (
+	// blackoutHorizon limits how far ahead recurring blackout events are expanded
+	blackoutHorizon = 2 * 365 * 24 * time.Hour
+	// maxCalendarSize caps the size of an imported calendar
+	maxCalendarSize = 5 << 20
+)
+
+// GetBlackouts returns every blackout calendar and the period active now, if any
+func (h *HealthHandler) GetBlackouts(w http.ResponseWriter, r *http.Request) {
+	calendars, err := h.db.GetAllBlackoutCalendars()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(calendars))
+	for _, calendar := range calendars {
+		entry := map[string]interface{}{
+			"calendar": calendar,
+		}
+		if period, ok := calendar.ActivePeriod(now); ok {
+			entry["active"] = period
+		}
+		result = append(result, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"calendars": result,
+		"timestamp": now.Format(time.RFC3339),
+	})
+}
+
+// ImportBlackouts imports an ICS calendar of blackout periods, given inline or by URL,
+// replacing a previous import with the same name (requires passkey)
+func (h *HealthHandler) ImportBlackouts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Name    string `json:"name"`
+		Action  string `json:"action"`
+		ICS     string `json:"ics"`
+		URL     string `json:"url"`
+		Passkey string `json:"passkey"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Action == "" {
+		req.Action = structs.BlackoutSuppress
+	}
+	if req.Action != structs.BlackoutSuppress && req.Action != structs.BlackoutDowngrade {
+		http.Error(w, "Invalid action: must be suppress or downgrade", http.StatusBadRequest)
+		return
+	}
+	if (req.ICS == "") == (req.URL == "") {
+		http.Error(w, "Exactly one of ics or url is required", http.StatusBadRequest)
+		return
+	}
+
+	data := req.ICS
+	if req.URL != "" {
+		var err error
+		if data, err = fetchCalendar(req.URL); err != nil {
+			http.Error(w, "Failed to fetch calendar: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+
+	loc, err := utils.LoadTimezone(h.config.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	// Past events are dropped, they can never suppress anything again
+	now := time.Now()
+	events, err := utils.ParseICS(data, loc, now.Add(blackoutHorizon))
+	if err != nil {
+		http.Error(w, "Invalid calendar: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	calendar := &structs.BlackoutCalendar{
+		ID:     utils.GenerateIDWithURL("blackout", req.Name),
+		Name:   req.Name,
+		Action: req.Action,
+	}
+	for _, event := range events {
+		if event.End.After(now) && event.End.After(event.Start) {
+			calendar.Periods = append(calendar.Periods, structs.BlackoutPeriod{
+				UID:     event.UID,
+				Summary: event.Summary,
+				Start:   event.Start,
+				End:     event.End,
+			})
+		}
+	}
+	sort.Slice(calendar.Periods, func(i, j int) bool {
+		return calendar.Periods[i].Start.Before(calendar.Periods[j].Start)
+	})
+
+	// Keep the original creation time when re-importing
+	if existing, err := h.db.GetBlackoutCalendar(calendar.ID); err == nil {
+		calendar.CreatedAt = existing.CreatedAt
+	}
+
+	if err := h.db.SaveBlackoutCalendar(calendar); err != nil {
+		logger.Errorf("Failed to save blackout calendar: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadBlackouts()
+	logger.Infof("Imported blackout calendar %s with %d periods", calendar.Name, len(calendar.Periods))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"calendar": calendar,
+	})
+}
+
+// DeleteBlackouts removes a blackout calendar (requires passkey)
+func (h *HealthHandler) DeleteBlackouts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID      string `json:"id"`
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.ID == "" {
+		http.Error(w, "Calendar ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteBlackoutCalendar(req.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadBlackouts()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Blackout calendar deleted",
+	})
+}
+
+// fetchCalendar downloads an ICS calendar
+func fetchCalendar(url string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCalendarSize))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
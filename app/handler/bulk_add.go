@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/importer"
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// maxBulkAdd caps the number of endpoints one bulk add may create
+const maxBulkAdd = 500
+
+// BulkAddEndpoints creates an endpoint for every URL of a pasted list or CSV, all with the
+// same settings, for POST /api/endpoints/bulk-add[?dry_run=true]
+func (h *HealthHandler) BulkAddEndpoints(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		URLs             string            `json:"urls"`
+		Method           string            `json:"method"`
+		Timeout          string            `json:"timeout"`
+		CheckInterval    string            `json:"check_interval"`
+		ExpectedStatus   int               `json:"expected_status"`
+		Headers          map[string]string `json:"headers"`
+		FailureThreshold int               `json:"failure_threshold"`
+		SuccessThreshold int               `json:"success_threshold"`
+		Priority         structs.Priority  `json:"priority"`
+		Tags             []string          `json:"tags"`
+		Owner            string            `json:"owner"`
+		MonitorHealth    *bool             `json:"monitor_health"`
+	}
+	if !decodeJSONLimit(w, r, &req, maxImportSize) {
+		return
+	}
+
+	if req.FailureThreshold < 0 || req.SuccessThreshold < 0 {
+		http.Error(w, "Invalid threshold: must not be negative", http.StatusBadRequest)
+		return
+	}
+	if req.Priority != "" && !req.Priority.Valid() {
+		http.Error(w, "Invalid priority: must be critical, high, normal or low", http.StatusBadRequest)
+		return
+	}
+
+	// Bulk-added endpoints are health checked unless told otherwise
+	monitorHealth := req.MonitorHealth == nil || *req.MonitorHealth
+
+	var timeout, checkInterval time.Duration
+	if req.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(req.Timeout)
+		if err != nil {
+			http.Error(w, "Invalid timeout format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.CheckInterval != "" {
+		var err error
+		checkInterval, err = time.ParseDuration(req.CheckInterval)
+		if err != nil {
+			http.Error(w, "Invalid check_interval format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := importer.ParseURLList([]byte(req.URLs))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(result.Endpoints) == 0 && len(result.Skipped) == 0 {
+		http.Error(w, "No URLs given", http.StatusBadRequest)
+		return
+	}
+	if len(result.Endpoints) > maxBulkAdd {
+		http.Error(w, "Too many URLs: at most 500 per request", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	allEndpoints, err := h.db.GetAllEndpoints()
+	if err != nil {
+		http.Error(w, "Failed to check existing endpoints: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	added := result.Endpoints[:0]
+	for _, endpoint := range result.Endpoints {
+		// Earlier lines of the same list count as existing
+		if conflict := h.endpointConflict(allEndpoints, projectID, endpoint.Name, endpoint.URL); conflict != "" {
+			result.Skipped = append(result.Skipped, endpoint.Name+": "+conflict)
+			continue
+		}
+
+		endpoint.ID = utils.NewUUID()
+		endpoint.ProjectID = projectID
+		endpoint.Method = req.Method
+		endpoint.Timeout = timeout
+		endpoint.CheckInterval = checkInterval
+		endpoint.ExpectedStatus = req.ExpectedStatus
+		endpoint.Headers = copyStringMap(req.Headers)
+		endpoint.FailureThreshold = req.FailureThreshold
+		endpoint.SuccessThreshold = req.SuccessThreshold
+		endpoint.Priority = req.Priority
+		endpoint.Tags = append(append([]string(nil), req.Tags...), endpoint.Tags...)
+		endpoint.Owner = req.Owner
+		endpoint.MonitorHealth = monitorHealth
+
+		if !dryRun {
+			if err := h.monitor.AddEndpoint(endpoint); err != nil {
+				logger.Errorf("Failed to bulk add endpoint %s: %v", endpoint.Name, err)
+				result.Skipped = append(result.Skipped, endpoint.Name+": "+err.Error())
+				continue
+			}
+		}
+		allEndpoints = append(allEndpoints, endpoint)
+		added = append(added, endpoint)
+	}
+	result.Endpoints = added
+
+	if !dryRun {
+		logger.Infof("Bulk added %d endpoints, skipped %d", len(result.Endpoints), len(result.Skipped))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"dry_run":   dryRun,
+		"endpoints": result.Endpoints,
+		"skipped":   result.Skipped,
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/models"
+)
+
+// maxChartBuckets bounds the series length a client may request
+const maxChartBuckets = 1000
+
+// parseChartRange parses a Go duration or a whole number of days such as "7d"
+func parseChartRange(value string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// GetCharts returns latency and availability series bucketed for a chart.
+// Supports ?range= (default 24h, e.g. 1h, 7d) and ?buckets= (default 60).
+func (h *HealthHandler) GetCharts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	id := query.Get("id")
+	if id == "" {
+		http.Error(w, "Endpoint ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, id) {
+		return
+	}
+
+	window := 24 * time.Hour
+	if v := query.Get("range"); v != "" {
+		d, ok := parseChartRange(v)
+		if !ok {
+			http.Error(w, "Invalid range: use a duration such as 1h or a number of days such as 7d", http.StatusBadRequest)
+			return
+		}
+		window = d
+	}
+	if retention := models.DataRetentionDays * 24 * time.Hour; window > retention {
+		window = retention
+	}
+
+	buckets := 60
+	if v := query.Get("buckets"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxChartBuckets {
+			http.Error(w, "Invalid buckets: must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		buckets = n
+	}
+
+	to := time.Now()
+	from := to.Add(-window)
+	records, err := h.db.GetHealthHistoryRange(id, from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"endpoint_id":    id,
+		"from":           from.Format(time.RFC3339),
+		"to":             to.Format(time.RFC3339),
+		"bucket_seconds": int(window.Seconds()) / buckets,
+		"availability":   models.UptimePercent(records),
+		"latency":        models.ComputeLatencyStats(records),
+		"buckets":        models.BucketRecords(records, from, to, buckets),
+		"deployments":    h.endpointDeployments(id, from),
+		"timestamp":      time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// csrfHeader carries the dashboard's CSRF token on write requests
+const csrfHeader = "X-CSRF-Token"
+
+// CSRFToken returns the token embedded in the dashboard page, derived from the action signing key
+func (h *HealthHandler) CSRFToken() string {
+	mac := hmac.New(sha256.New, h.monitor.ActionKey())
+	mac.Write([]byte("csrf"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// fromBrowser reports whether a request was sent by a browser, which adds Origin or
+// Sec-Fetch-Site to fetches and cross-site form posts
+func fromBrowser(r *http.Request) bool {
+	return r.Header.Get("Origin") != "" || r.Header.Get("Sec-Fetch-Site") != ""
+}
+
+// RequireCSRF rejects browser requests that lack the dashboard's CSRF token. Requests
+// carrying a bearer token or X-Admin-Passkey header are exempt: browsers never add those
+// on their own and cannot send them cross-site without CORS, which is not enabled.
+func (h *HealthHandler) RequireCSRF(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !fromBrowser(r) || bearerToken(r) != "" || r.Header.Get("X-Admin-Passkey") != "" {
+			next(w, r)
+			return
+		}
+
+		sent := r.Header.Get(csrfHeader)
+		if sent == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(h.CSRFToken())) != 1 {
+			logger.Infof("Refused %s %s without a valid CSRF token (origin %q)", r.Method, r.URL.Path, r.Header.Get("Origin"))
+			http.Error(w, "Forbidden: missing or invalid CSRF token, reload the dashboard", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
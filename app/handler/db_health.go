@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetDBHealth reports database file size, integrity, bucket counts, compaction and backups (requires passkey)
+func (h *HealthHandler) GetDBHealth(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	health, err := h.db.Health()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(health)
+}
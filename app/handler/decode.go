@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxBodySize caps JSON request bodies other than imports
+const maxBodySize = 1 << 20
+
+// bodyError describes why a request body was rejected, naming the offending field when known
+type bodyError struct {
+	status  int
+	Error   string `json:"error"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// decodeJSON reads a JSON body of at most maxBodySize bytes into v, writing a 400 and
+// returning false if it is missing, malformed or has fields v does not know
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	return decodeBody(w, r, v, maxBodySize, false)
+}
+
+// decodeOptionalJSON is decodeJSON for bodies that may be left empty, such as an ID
+// that can also come from the query string
+func decodeOptionalJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	return decodeBody(w, r, v, maxBodySize, true)
+}
+
+// decodeJSONLimit is decodeJSON with a custom size limit
+func decodeJSONLimit(w http.ResponseWriter, r *http.Request, v interface{}, limit int64) bool {
+	return decodeBody(w, r, v, limit, false)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}, limit int64, optional bool) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil && decoder.More() {
+		err = errors.New("trailing data")
+	}
+	if err == nil || (optional && errors.Is(err, io.EOF)) {
+		return true
+	}
+
+	writeBodyError(w, describeBodyError(err, limit))
+	return false
+}
+
+// describeBodyError turns a decoding error into a client-facing bodyError
+func describeBodyError(err error, limit int64) bodyError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var sizeErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &sizeErr):
+		return bodyError{status: http.StatusRequestEntityTooLarge, Error: "body_too_large",
+			Message: fmt.Sprintf("request body must not exceed %d bytes", limit)}
+	case errors.Is(err, io.EOF):
+		return bodyError{status: http.StatusBadRequest, Error: "body_missing",
+			Message: "request body is required"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return bodyError{status: http.StatusBadRequest, Error: "malformed_json",
+			Message: "request body ends in the middle of a JSON value"}
+	case errors.As(err, &syntaxErr):
+		return bodyError{status: http.StatusBadRequest, Error: "malformed_json",
+			Message: fmt.Sprintf("malformed JSON at byte %d: %v", syntaxErr.Offset, err)}
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			return bodyError{status: http.StatusBadRequest, Error: "invalid_type",
+				Message: "request body must be " + jsonKind(typeErr.Type.Kind().String())}
+		}
+		return bodyError{status: http.StatusBadRequest, Error: "invalid_type", Field: field,
+			Message: fmt.Sprintf("%s must be %s, not %s", field, jsonKind(typeErr.Type.Kind().String()), typeErr.Value)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return bodyError{status: http.StatusBadRequest, Error: "unknown_field", Field: field,
+			Message: "unknown field " + field}
+	case err.Error() == "trailing data":
+		return bodyError{status: http.StatusBadRequest, Error: "malformed_json",
+			Message: "request body must contain a single JSON object"}
+	}
+	return bodyError{status: http.StatusBadRequest, Error: "invalid_body", Message: err.Error()}
+}
+
+// jsonKind names a Go kind the way a JSON client thinks of it
+func jsonKind(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "a number"
+	case kind == "string":
+		return "a string"
+	case kind == "bool":
+		return "true or false"
+	case kind == "slice", kind == "array":
+		return "an array"
+	case kind == "map", kind == "struct":
+		return "an object"
+	}
+	return "a " + kind
+}
+
+// writeBodyError writes a rejected body's description as JSON
+func writeBodyError(w http.ResponseWriter, e bodyError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(e)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// DeployHook immediately rechecks the endpoints carrying ?tag= and reports pass/fail.
+// With ?marker=true a deployment marker (optionally with ?service= and ?version=) is stored.
+// Responds 200 when every check passed and 503 otherwise, so CI can gate on it.
+func (h *HealthHandler) DeployHook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	tag := query.Get("tag")
+	if tag == "" {
+		http.Error(w, "Tag is required", http.StatusBadRequest)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	var ids []string
+	for id, state := range h.monitor.GetStatus() {
+		if state.Enabled && state.Endpoint.HasTag(tag) && inScope(projectID, state.Endpoint.ProjectID) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		http.Error(w, "No enabled endpoints with tag "+tag, http.StatusNotFound)
+		return
+	}
+	sort.Strings(ids)
+
+	logger.Infof("Deploy hook triggered for tag %s (%d endpoints)", tag, len(ids))
+
+	results := h.monitor.CheckNow(ids)
+
+	passed, failed := 0, 0
+	for _, result := range results {
+		if result.Passed {
+			passed++
+		} else {
+			failed++
+		}
+	}
+
+	response := map[string]interface{}{
+		"success":   failed == 0,
+		"tag":       tag,
+		"passed":    passed,
+		"failed":    failed,
+		"results":   results,
+		"timestamp": time.Now().Format(time.RFC3339),
+	}
+
+	if marker := query.Get("marker"); marker == "true" || marker == "1" {
+		allPassed := failed == 0
+		deployment := &structs.Deployment{
+			Service:     query.Get("service"),
+			Tag:         tag,
+			Version:     query.Get("version"),
+			EndpointIDs: ids,
+			Passed:      &allPassed,
+			ProjectID:   projectID,
+		}
+		if err := h.db.SaveDeployment(deployment); err != nil {
+			logger.Errorf("Failed to save deployment marker: %v", err)
+		} else {
+			response["deployment"] = deployment
+		}
+	}
+
+	status := http.StatusOK
+	if failed > 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// historySince returns the timestamp of the oldest record (records are newest first)
+func historySince(records []*structs.HealthCheckRecord) time.Time {
+	if len(records) == 0 {
+		return time.Now()
+	}
+	return records[len(records)-1].Timestamp
+}
+
+// endpointDeployments returns deployment markers affecting an endpoint since the given time
+func (h *HealthHandler) endpointDeployments(id string, since time.Time) []*structs.Deployment {
+	deployments := []*structs.Deployment{}
+
+	endpoint, err := h.db.GetEndpoint(id)
+	if err != nil {
+		return deployments
+	}
+
+	all, err := h.db.GetDeploymentsSince(since)
+	if err != nil {
+		logger.Errorf("Failed to load deployments: %v", err)
+		return deployments
+	}
+
+	for _, deployment := range all {
+		if deployment.Affects(endpoint.ToEndpoint(), id) {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments
+}
+
+// GetDeployments returns deployment markers, optionally filtered by ?service= and ?since= (RFC3339, default 7 days)
+func (h *HealthHandler) GetDeployments(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	since := time.Now().AddDate(0, 0, -7)
+	if param := query.Get("since"); param != "" {
+		parsed, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			http.Error(w, "Invalid since: must be RFC3339", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+	service := query.Get("service")
+
+	all, err := h.db.GetDeploymentsSince(since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deployments := []*structs.Deployment{}
+	for _, deployment := range all {
+		if !inScope(projectID, deployment.ProjectID) {
+			continue
+		}
+		if service != "" && deployment.Service != service {
+			continue
+		}
+		deployments = append(deployments, deployment)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"deployments": deployments,
+		"count":       len(deployments),
+		"timestamp":   time.Now().Format(time.RFC3339),
+	})
+}
+
+// AddDeployment records a deployment marker. The affected endpoints come from
+// endpoint_ids, else the composite service named by service, else the tag;
+// a marker with none of these applies to every endpoint.
+func (h *HealthHandler) AddDeployment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Service     string    `json:"service"`
+		Version     string    `json:"version"`
+		Tag         string    `json:"tag"`
+		EndpointIDs []string  `json:"endpoint_ids"`
+		Timestamp   time.Time `json:"timestamp"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	if req.Service == "" && req.Version == "" {
+		http.Error(w, "Service or version is required", http.StatusBadRequest)
+		return
+	}
+
+	ids := req.EndpointIDs
+	switch {
+	case len(ids) > 0:
+	case req.Service != "":
+		if service, err := h.db.GetService(req.Service); err == nil && inScope(projectID, service.ProjectID) {
+			ids = service.EndpointIDs
+		}
+	}
+	if len(ids) == 0 && req.Tag != "" {
+		for id, state := range h.monitor.GetStatus() {
+			if state.Endpoint.HasTag(req.Tag) && inScope(projectID, state.Endpoint.ProjectID) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		if !h.endpointInScope(projectID, id) {
+			http.Error(w, "Unknown endpoint: "+id, http.StatusBadRequest)
+			return
+		}
+	}
+
+	deployment := &structs.Deployment{
+		Service:     req.Service,
+		Tag:         req.Tag,
+		Version:     req.Version,
+		EndpointIDs: ids,
+		ProjectID:   projectID,
+		Timestamp:   req.Timestamp,
+	}
+
+	if err := h.db.SaveDeployment(deployment); err != nil {
+		logger.Errorf("Failed to save deployment: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"deployment": deployment,
+	})
+}
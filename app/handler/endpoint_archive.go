@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// filterArchived returns the endpoints whose archived flag equals archived
+func filterArchived(endpoints []*structs.StoredEndpoint, archived bool) []*structs.StoredEndpoint {
+	filtered := make([]*structs.StoredEndpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Archived == archived {
+			filtered = append(filtered, ep)
+		}
+	}
+	return filtered
+}
+
+// archivedEndpointID reads the endpoint ID from ?id= or the body and checks that it
+// names an archived endpoint in the caller's project
+func (h *HealthHandler) archivedEndpointID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		var req struct {
+			ID string `json:"id"`
+		}
+		if !decodeOptionalJSON(w, r, &req) {
+			return "", false
+		}
+		id = req.ID
+	}
+
+	if id == "" {
+		http.Error(w, "Endpoint ID is required", http.StatusBadRequest)
+		return "", false
+	}
+
+	if !h.requireEndpointScope(w, r, id) {
+		return "", false
+	}
+
+	endpoint, err := h.db.GetEndpoint(id)
+	if err != nil {
+		http.Error(w, "Endpoint not found", http.StatusNotFound)
+		return "", false
+	}
+	if !endpoint.Archived {
+		http.Error(w, "Endpoint is not archived", http.StatusConflict)
+		return "", false
+	}
+	return id, true
+}
+
+// GetArchivedEndpoints returns archived endpoints with their preserved configuration
+func (h *HealthHandler) GetArchivedEndpoints(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	var endpoints []*structs.StoredEndpoint
+	var err error
+	if projectID != "" {
+		endpoints, err = h.db.GetProjectEndpoints(projectID)
+	} else {
+		endpoints, err = h.db.GetAllEndpoints()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"endpoints": filterArchived(endpoints, true),
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// RestoreEndpoint resumes monitoring of an archived endpoint
+func (h *HealthHandler) RestoreEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, ok := h.archivedEndpointID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.monitor.RestoreEndpoint(id); err != nil {
+		logger.Errorf("Failed to restore endpoint %s: %v", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Endpoint restored",
+	})
+}
+
+// PurgeEndpoint permanently deletes an archived endpoint with its state and history
+func (h *HealthHandler) PurgeEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.requireTOTP(w, r) {
+		return
+	}
+
+	id, ok := h.archivedEndpointID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.monitor.PurgeEndpoint(id); err != nil {
+		logger.Errorf("Failed to purge endpoint %s: %v", id, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Endpoint purged",
+	})
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// CloneEndpoint duplicates an endpoint's full configuration under a new name and URL
+// for POST /api/endpoints/clone?id=
+func (h *HealthHandler) CloneEndpoint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if req.ID == "" {
+		http.Error(w, "Endpoint ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, req.ID) {
+		return
+	}
+
+	source, err := h.db.GetEndpoint(req.ID)
+	if err != nil {
+		http.Error(w, "Endpoint not found", http.StatusNotFound)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+	if req.URL == "" {
+		req.URL = source.URL
+	}
+	if !strings.Contains(req.URL, "://") {
+		http.Error(w, "Invalid URL format: must include protocol (e.g., https://)", http.StatusBadRequest)
+		return
+	}
+
+	allEndpoints, err := h.db.GetAllEndpoints()
+	if err != nil {
+		http.Error(w, "Failed to check existing endpoints: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The clone lives in the source's project
+	if conflict := h.endpointConflict(allEndpoints, source.ProjectID, req.Name, req.URL); conflict != "" {
+		http.Error(w, conflict, http.StatusConflict)
+		return
+	}
+
+	endpoint := cloneStoredEndpoint(source)
+	endpoint.Name = req.Name
+	endpoint.URL = req.URL
+	endpoint.ID = utils.NewUUID()
+
+	if err := h.monitor.AddEndpoint(endpoint); err != nil {
+		logger.Errorf("Failed to clone endpoint: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infof("Cloned endpoint %s as %s", source.Name, endpoint.Name)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"endpoint": endpoint,
+	})
+}
+
+// cloneStoredEndpoint copies an endpoint's configuration without sharing its maps and slices.
+// Suppression, archival, the legacy ID and timestamps belong to the original and are not copied.
+func cloneStoredEndpoint(source *structs.StoredEndpoint) *structs.StoredEndpoint {
+	endpoint := *source
+	endpoint.LegacyID = ""
+	endpoint.Headers = copyStringMap(source.Headers)
+	endpoint.Labels = copyStringMap(source.Labels)
+	endpoint.Tags = append([]string(nil), source.Tags...)
+	endpoint.AlertsSuppressed = false
+	endpoint.Archived = false
+	endpoint.ArchivedAt = time.Time{}
+	endpoint.CreatedAt = time.Time{}
+	endpoint.UpdatedAt = time.Time{}
+	return &endpoint
+}
+
+// copyStringMap returns a shallow copy of m, keeping nil as nil
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/models"
+)
+
+// maxDetailIncidents caps the number of recent incidents returned in endpoint detail
+const maxDetailIncidents = 10
+
+// GetEndpointDetail returns stored config, current state, recent incidents,
+// 24h uptime and latency stats for GET /api/endpoints/{id}
+func (h *HealthHandler) GetEndpointDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/endpoints/")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, id) {
+		return
+	}
+
+	endpoint, err := h.db.GetEndpoint(id)
+	if err != nil {
+		http.Error(w, "Endpoint not found", http.StatusNotFound)
+		return
+	}
+
+	records, err := h.db.GetHealthHistory(id, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	last24h := models.RecordsSince(records, time.Now().Add(-24*time.Hour))
+
+	incidents := models.DetectIncidents(records)
+	if len(incidents) > maxDetailIncidents {
+		incidents = incidents[:maxDetailIncidents]
+	}
+
+	response := map[string]interface{}{
+		"endpoint":        endpoint,
+		"incidents":       incidents,
+		"uptime_24h":      models.UptimePercent(last24h),
+		"latency_24h":     models.ComputeLatencyStats(last24h),
+		"check_count_24h": models.CountChecks(last24h),
+		"deployments_24h": h.endpointDeployments(id, time.Now().Add(-24*time.Hour)),
+		"timestamp":       time.Now().Format(time.RFC3339),
+	}
+
+	if state, ok := h.monitor.GetEndpointState(id); ok {
+		response["state"] = h.endpointStatusData(state)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
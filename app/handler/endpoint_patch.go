@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// endpointPatch holds the fields of a PATCH request; omitted fields are left unchanged.
+// Maps and lists replace the stored value as a whole, an empty one clears it.
+type endpointPatch struct {
+	Name               *string                `json:"name"`
+	URL                *string                `json:"url"`
+	Method             *string                `json:"method"`
+	Headers            map[string]string      `json:"headers"`
+	ExpectedStatus     *int                   `json:"expected_status"`
+	MonitorHealth      *bool                  `json:"monitor_health"`
+	Timeout            *string                `json:"timeout"`
+	CheckInterval      *string                `json:"check_interval"`
+	FailureThreshold   *int                   `json:"failure_threshold"`
+	NetworkThreshold   *int                   `json:"network_failure_threshold"`
+	AppThreshold       *int                   `json:"application_failure_threshold"`
+	SuccessThreshold   *int                   `json:"success_threshold"`
+	FailureDuration    *string                `json:"failure_duration"`
+	SuccessDuration    *string                `json:"success_duration"`
+	ResolveTo          *string                `json:"resolve_to"`
+	HostHeader         *string                `json:"host_header"`
+	DisableKeepAlive   *bool                  `json:"disable_keep_alive"`
+	MaxIdleConns       *int                   `json:"max_idle_conns"`
+	UseCookies         *bool                  `json:"use_cookies"`
+	BackoffEnabled     *bool                  `json:"backoff_enabled"`
+	BackoffAfter       *string                `json:"backoff_after"`
+	RetryDelay         *string                `json:"retry_delay"`
+	Retries            *int                   `json:"retries"`
+	BackoffMaxInterval *string                `json:"backoff_max_interval"`
+	Priority           *structs.Priority      `json:"priority"`
+	InsecureSkipVerify *bool                  `json:"insecure_skip_verify"`
+	CertFingerprint    *string                `json:"cert_fingerprint"`
+	Tags               []string               `json:"tags"`
+	SLATarget          *float64               `json:"sla_target"`
+	Description        *string                `json:"description"`
+	Owner              *string                `json:"owner"`
+	RunbookURL         *string                `json:"runbook_url"`
+	Labels             map[string]string      `json:"labels"`
+	RateLimitMode      *structs.RateLimitMode `json:"rate_limit_mode"`
+	Schedule           *string                `json:"schedule"`
+	CheckType          *structs.CheckType     `json:"check_type"`
+	WaitSelector       *string                `json:"wait_selector"`
+	ContentType        *string                `json:"expected_content_type"`
+	SuccessExpression  *string                `json:"success_expression"`
+	BodyContains       *string                `json:"body_contains"`
+	BodyNotContains    *string                `json:"body_not_contains"`
+	BodyRegex          *string                `json:"body_regex"`
+	AlternateHosts     []string               `json:"alternate_hostnames"`
+	CallbackURL        *string                `json:"callback_url"`
+	HistorySample      *int                   `json:"history_sample_every"`
+	Journey            []structs.JourneyStep  `json:"journey"`
+	Enabled            *bool                  `json:"enabled"`
+	AlertsSuppressed   *bool                  `json:"alerts_suppressed"`
+	HealthPaused       *bool                  `json:"health_paused"`
+	SSLPaused          *bool                  `json:"ssl_paused"`
+}
+
+// apply validates the patch and applies it to an endpoint; on error the endpoint may be partly changed
+func (p *endpointPatch) apply(endpoint *structs.StoredEndpoint) error {
+	if p.Name != nil {
+		if strings.TrimSpace(*p.Name) == "" {
+			return fmt.Errorf("Invalid name: must not be empty")
+		}
+		endpoint.Name = *p.Name
+	}
+	if p.URL != nil {
+		if !strings.Contains(*p.URL, "://") {
+			return fmt.Errorf("Invalid URL format: must include protocol (e.g., https://)")
+		}
+		endpoint.URL = *p.URL
+	}
+	if p.Method != nil {
+		endpoint.Method = strings.ToUpper(*p.Method)
+	}
+	if p.Headers != nil {
+		endpoint.Headers = p.Headers
+	}
+	if p.MonitorHealth != nil {
+		endpoint.MonitorHealth = *p.MonitorHealth
+	}
+
+	durations := []struct {
+		field    string
+		value    *string
+		target   *time.Duration
+		positive bool
+	}{
+		{"timeout", p.Timeout, &endpoint.Timeout, true},
+		{"check_interval", p.CheckInterval, &endpoint.CheckInterval, true},
+		{"backoff_after", p.BackoffAfter, &endpoint.BackoffAfter, false},
+		{"retry_delay", p.RetryDelay, &endpoint.RetryDelay, false},
+		{"backoff_max_interval", p.BackoffMaxInterval, &endpoint.BackoffMaxInterval, false},
+		{"failure_duration", p.FailureDuration, &endpoint.FailureDuration, false},
+		{"success_duration", p.SuccessDuration, &endpoint.SuccessDuration, false},
+	}
+	for _, d := range durations {
+		if d.value == nil {
+			continue
+		}
+		parsed, err := time.ParseDuration(*d.value)
+		if err != nil {
+			return fmt.Errorf("Invalid %s format: %v", d.field, err)
+		}
+		if parsed < 0 || (d.positive && parsed == 0) {
+			return fmt.Errorf("Invalid %s: must be positive", d.field)
+		}
+		*d.target = parsed
+	}
+
+	if p.FailureThreshold != nil {
+		if *p.FailureThreshold < 1 {
+			return fmt.Errorf("Invalid failure_threshold: must be at least 1")
+		}
+		endpoint.FailureThreshold = *p.FailureThreshold
+	}
+	if p.Retries != nil {
+		if err := validRetries(*p.Retries); err != nil {
+			return err
+		}
+		endpoint.Retries = *p.Retries
+	}
+	// Zero clears a per-kind threshold back to failure_threshold
+	if p.NetworkThreshold != nil {
+		if *p.NetworkThreshold < 0 {
+			return fmt.Errorf("Invalid network_failure_threshold: must not be negative")
+		}
+		endpoint.NetworkThreshold = *p.NetworkThreshold
+	}
+	if p.AppThreshold != nil {
+		if *p.AppThreshold < 0 {
+			return fmt.Errorf("Invalid application_failure_threshold: must not be negative")
+		}
+		endpoint.AppThreshold = *p.AppThreshold
+	}
+	if p.SuccessThreshold != nil {
+		if *p.SuccessThreshold < 1 {
+			return fmt.Errorf("Invalid success_threshold: must be at least 1")
+		}
+		endpoint.SuccessThreshold = *p.SuccessThreshold
+	}
+
+	if p.ResolveTo != nil {
+		endpoint.ResolveTo = *p.ResolveTo
+	}
+	if p.HostHeader != nil {
+		endpoint.HostHeader = *p.HostHeader
+	}
+	if p.DisableKeepAlive != nil {
+		endpoint.DisableKeepAlive = *p.DisableKeepAlive
+	}
+	if p.MaxIdleConns != nil {
+		if *p.MaxIdleConns < 0 {
+			return fmt.Errorf("Invalid max_idle_conns: must not be negative")
+		}
+		endpoint.MaxIdleConns = *p.MaxIdleConns
+	}
+	if p.UseCookies != nil {
+		endpoint.UseCookies = *p.UseCookies
+	}
+	if p.BackoffEnabled != nil {
+		endpoint.BackoffEnabled = *p.BackoffEnabled
+	}
+	if p.Priority != nil {
+		if !p.Priority.Valid() {
+			return fmt.Errorf("Invalid priority: must be critical, high, normal or low")
+		}
+		endpoint.Priority = *p.Priority
+	}
+	if p.InsecureSkipVerify != nil {
+		endpoint.InsecureSkipVerify = *p.InsecureSkipVerify
+	}
+	if p.CertFingerprint != nil {
+		endpoint.CertFingerprint = *p.CertFingerprint
+	}
+	if p.Tags != nil {
+		endpoint.Tags = p.Tags
+	}
+	if p.SLATarget != nil {
+		if *p.SLATarget < 0 || *p.SLATarget >= 100 {
+			return fmt.Errorf("Invalid sla_target: must be between 0 and 100")
+		}
+		endpoint.SLATarget = *p.SLATarget
+	}
+	if p.Description != nil {
+		endpoint.Description = *p.Description
+	}
+	if p.Owner != nil {
+		endpoint.Owner = *p.Owner
+	}
+	if p.RunbookURL != nil {
+		if !validRunbookURL(*p.RunbookURL) {
+			return fmt.Errorf("Invalid runbook_url: must be an http or https URL")
+		}
+		endpoint.RunbookURL = *p.RunbookURL
+	}
+	if p.Labels != nil {
+		if err := utils.ValidateLabels(p.Labels); err != nil {
+			return err
+		}
+		endpoint.Labels = p.Labels
+	}
+	if p.RateLimitMode != nil {
+		if !p.RateLimitMode.Valid() {
+			return fmt.Errorf("Invalid rate_limit_mode: must be degraded or failure")
+		}
+		endpoint.RateLimitMode = *p.RateLimitMode
+	}
+	if p.Schedule != nil {
+		if !validSchedule(*p.Schedule) {
+			return fmt.Errorf("Invalid schedule: must be a 5-field cron expression that fires")
+		}
+		endpoint.Schedule = *p.Schedule
+	}
+
+	if p.CheckType != nil {
+		if !p.CheckType.Valid() {
+			return fmt.Errorf("Invalid check_type: must be http, browser or negative")
+		}
+		// A negative endpoint must not answer at all unless given an expected status
+		if *p.CheckType == structs.CheckNegative && endpoint.CheckType != structs.CheckNegative && p.ExpectedStatus == nil {
+			endpoint.ExpectedStatus = 0
+		}
+		endpoint.CheckType = *p.CheckType
+	}
+	if p.ExpectedStatus != nil {
+		if *p.ExpectedStatus < 0 || *p.ExpectedStatus > 599 {
+			return fmt.Errorf("Invalid expected_status: must be an HTTP status code")
+		}
+		endpoint.ExpectedStatus = *p.ExpectedStatus
+	}
+	if p.WaitSelector != nil {
+		endpoint.WaitSelector = *p.WaitSelector
+	}
+	if p.ContentType != nil {
+		if *p.ContentType != "" {
+			if err := utils.ValidateContentType(*p.ContentType); err != nil {
+				return err
+			}
+		}
+		endpoint.ContentType = *p.ContentType
+	}
+	if p.SuccessExpression != nil {
+		if err := validSuccessExpression(*p.SuccessExpression); err != nil {
+			return err
+		}
+		endpoint.SuccessExpression = *p.SuccessExpression
+	}
+	if p.BodyContains != nil {
+		endpoint.BodyContains = *p.BodyContains
+	}
+	if p.BodyNotContains != nil {
+		endpoint.BodyNotContains = *p.BodyNotContains
+	}
+	if p.BodyRegex != nil {
+		if err := validBodyRegex(*p.BodyRegex); err != nil {
+			return err
+		}
+		endpoint.BodyRegex = *p.BodyRegex
+	}
+	if p.AlternateHosts != nil {
+		if err := validAlternateHostnames(p.AlternateHosts); err != nil {
+			return err
+		}
+		endpoint.AlternateHosts = p.AlternateHosts
+	}
+	if p.CallbackURL != nil {
+		if !validCallbackURL(*p.CallbackURL) {
+			return fmt.Errorf("Invalid callback_url: must be an http or https URL")
+		}
+		endpoint.CallbackURL = *p.CallbackURL
+	}
+	if p.HistorySample != nil {
+		if *p.HistorySample < 0 {
+			return fmt.Errorf("Invalid history_sample_every: must not be negative")
+		}
+		endpoint.HistorySample = *p.HistorySample
+	}
+	if p.Journey != nil {
+		endpoint.Journey = p.Journey
+	}
+	if err := applyJourney(&endpoint.CheckType, endpoint.Journey); err != nil {
+		return err
+	}
+
+	if p.Enabled != nil {
+		endpoint.Enabled = *p.Enabled
+	}
+	if p.AlertsSuppressed != nil {
+		endpoint.AlertsSuppressed = *p.AlertsSuppressed
+		endpoint.SuppressedUntil = time.Time{}
+	}
+	if p.HealthPaused != nil {
+		endpoint.HealthPaused = *p.HealthPaused
+	}
+	if p.SSLPaused != nil {
+		endpoint.SSLPaused = *p.SSLPaused
+	}
+	return nil
+}
+
+// PatchEndpoint changes any endpoint setting for PATCH /api/endpoints/{id}. The patch is
+// validated as a whole before anything is saved, and the running check picks it up at once.
+func (h *HealthHandler) PatchEndpoint(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/endpoints/")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, id) || !h.requireTOTP(w, r) {
+		return
+	}
+
+	// Unknown fields are rejected so a typo does not silently leave a setting unchanged
+	var patch endpointPatch
+	if !decodeJSON(w, r, &patch) {
+		return
+	}
+
+	endpoint, err := h.db.GetEndpoint(id)
+	if err != nil {
+		http.Error(w, "Endpoint not found", http.StatusNotFound)
+		return
+	}
+
+	if err := patch.apply(endpoint); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// A new name or URL must still be unique among the project's other endpoints
+	if patch.Name != nil || patch.URL != nil {
+		allEndpoints, err := h.db.GetAllEndpoints()
+		if err != nil {
+			http.Error(w, "Failed to check existing endpoints: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		others := make([]*structs.StoredEndpoint, 0, len(allEndpoints))
+		for _, ep := range allEndpoints {
+			if ep.ID != id {
+				others = append(others, ep)
+			}
+		}
+		if conflict := h.endpointConflict(others, endpoint.ProjectID, endpoint.Name, endpoint.URL); conflict != "" {
+			http.Error(w, conflict, http.StatusConflict)
+			return
+		}
+	}
+
+	if err := h.monitor.ReplaceEndpoint(endpoint); err != nil {
+		logger.Errorf("Failed to patch endpoint %s: %v", id, err)
+		http.Error(w, "Failed to update endpoint", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"endpoint": endpoint,
+	})
+}
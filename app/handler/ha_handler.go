@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetHAStatus reports whether this instance is the HA leader and who holds the lease
+func (h *HealthHandler) GetHAStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.monitor.LeaderStatus())
+}
@@ -2,7 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,41 +34,388 @@ func NewHealthHandler(monitor *worker.Monitor, db *models.Database, config *stru
 	}
 }
 
-// GetStatus returns the current status of all endpoints
+// statusCategory classifies an endpoint state for status filtering and aggregate counts
+func statusCategory(state *structs.EndpointState) string {
+	switch {
+	case state.Status == structs.StatusUnhealthy:
+		return "down"
+	case state.Status == structs.StatusHealthy && state.ConsecutiveFailures > 0:
+		// Failing but not yet past the failure threshold
+		return "degraded"
+	case state.Status == structs.StatusHealthy && state.RateLimited:
+		return "degraded"
+	case state.Status == structs.StatusHealthy:
+		return "up"
+	default:
+		return "unknown"
+	}
+}
+
+// matchesStatusFilter reports whether a state matches a ?status= value
+func matchesStatusFilter(state *structs.EndpointState, filter string) bool {
+	switch filter {
+	case "", "all":
+		return true
+	case string(structs.StatusHealthy), string(structs.StatusUnhealthy), string(structs.StatusUnknown):
+		return string(state.Status) == filter
+	case "in_maintenance":
+		return state.AlertsSuppressed
+	default:
+		return statusCategory(state) == filter
+	}
+}
+
+// endpointStatusData builds the status API representation of an endpoint state
+func (h *HealthHandler) endpointStatusData(state *structs.EndpointState) map[string]interface{} {
+	endpointData := map[string]interface{}{
+		"id":                    state.ID,
+		"name":                  state.Endpoint.Name,
+		"url":                   state.Endpoint.URL,
+		"method":                state.Endpoint.Method,
+		"priority":              string(state.Endpoint.Priority),
+		"tags":                  state.Endpoint.Tags,
+		"alerts_suppressed":     state.AlertsSuppressed,
+		"health_paused":         state.HealthPaused,
+		"ssl_paused":            state.SSLPaused,
+		"acknowledged":          state.Acknowledged,
+		"status":                string(state.Status),
+		"last_check":            state.LastCheck.Format(time.RFC3339),
+		"last_success":          state.LastSuccess.Format(time.RFC3339),
+		"last_error":            state.LastError,
+		"response_time_ms":      float64(state.ResponseTime.Microseconds()) / 1000.0,
+		"consecutive_failures":  state.ConsecutiveFailures,
+		"consecutive_successes": state.ConsecutiveSuccesses,
+		"ssl_expiring_soon":     state.SSLExpiringSoon,
+		"days_to_expiry":        state.DaysToExpiry,
+		"ssl_uncovered_hosts":   state.SSLUncoveredHosts,
+	}
+
+	if grace := h.config.NewEndpointGrace.Duration; state.InGracePeriod(grace, time.Now()) {
+		endpointData["grace_until"] = state.CreatedAt.Add(grace).Format(time.RFC3339)
+	}
+	if state.AlertsSuppressed && !state.SuppressedUntil.IsZero() {
+		endpointData["suppressed_until"] = state.SuppressedUntil.Format(time.RFC3339)
+	}
+	if state.SSLError != "" {
+		endpointData["ssl_error"] = state.SSLError
+	}
+	if state.TLSVersion != 0 {
+		endpointData["tls_version"] = worker.TLSVersionName(state.TLSVersion)
+		endpointData["tls_cipher"] = worker.TLSCipherName(state.TLSCipher)
+		endpointData["legacy_tls_accepted"] = worker.TLSVersionName(state.TLSLegacy)
+	}
+
+	// Ownership metadata tells responders who to call and what to do
+	if state.Endpoint.Description != "" {
+		endpointData["description"] = state.Endpoint.Description
+	}
+	if state.Endpoint.Owner != "" {
+		endpointData["owner"] = state.Endpoint.Owner
+	}
+	if state.Endpoint.RunbookURL != "" {
+		endpointData["runbook_url"] = state.Endpoint.RunbookURL
+	}
+	if len(state.Endpoint.Labels) > 0 {
+		endpointData["labels"] = state.Endpoint.Labels
+	}
+
+	// Break failures down so a flaky upstream can be told apart from a dead host
+	if state.LastFailureKind != "" {
+		endpointData["last_failure_kind"] = string(state.LastFailureKind)
+	}
+	if state.ConsecutiveFailures > 0 {
+		endpointData["consecutive_network_failures"] = state.ConsecutiveNetwork
+		endpointData["consecutive_application_failures"] = state.ConsecutiveApp
+	}
+	if state.Endpoint.Retries > 0 {
+		endpointData["retries"] = state.Endpoint.Retries
+		endpointData["retry_delay"] = worker.RetryDelay(state.Endpoint).String()
+	}
+	if state.Endpoint.NetworkThreshold > 0 {
+		endpointData["network_failure_threshold"] = state.Endpoint.NetworkThreshold
+	}
+	if state.Endpoint.AppThreshold > 0 {
+		endpointData["application_failure_threshold"] = state.Endpoint.AppThreshold
+	}
+	if d := state.Endpoint.FailureDuration.Duration; d > 0 {
+		endpointData["failure_duration"] = d.String()
+	}
+	if d := state.Endpoint.SuccessDuration.Duration; d > 0 {
+		endpointData["success_duration"] = d.String()
+	}
+	if !state.FailingSince.IsZero() {
+		endpointData["failing_since"] = state.FailingSince.Format(time.RFC3339)
+	}
+
+	// Surface 429 responses so operators can tune the check interval
+	if state.RateLimited {
+		endpointData["rate_limited"] = true
+	}
+	if state.RateLimitedUntil.After(time.Now()) {
+		endpointData["rate_limited_until"] = state.RateLimitedUntil.Format(time.RFC3339)
+	}
+	if state.RateLimitCount > 0 {
+		endpointData["rate_limit_count"] = state.RateLimitCount
+		endpointData["last_rate_limited"] = state.LastRateLimited.Format(time.RFC3339)
+	}
+
+	// Missed checks explain gaps in the history
+	if state.MissedChecks > 0 {
+		endpointData["checks_run"] = state.ChecksRun
+		endpointData["missed_checks"] = state.MissedChecks
+		endpointData["last_missed_check"] = state.LastMissedCheck.Format(time.RFC3339)
+	}
+
+	if state.Endpoint.CheckType == structs.CheckNegative {
+		endpointData["check_type"] = state.Endpoint.CheckType
+	}
+	if state.Endpoint.CheckType == structs.CheckBrowser {
+		endpointData["check_type"] = state.Endpoint.CheckType
+		endpointData["wait_selector"] = state.Endpoint.WaitSelector
+		endpointData["journey_steps"] = len(state.Endpoint.Journey)
+	}
+
+	// Cron-scheduled endpoints report their schedule and when they run next
+	if state.Endpoint.Schedule != "" {
+		endpointData["schedule"] = state.Endpoint.Schedule
+		endpointData["next_check"] = state.NextCheck.Format(time.RFC3339)
+	}
+
+	// Report the stretched interval while backing off
+	if state.BackoffInterval > 0 {
+		endpointData["backoff_interval"] = state.BackoffInterval.String()
+	}
+
+	// Add SSL expiry date if available
+	if !state.SSLCertExpiry.IsZero() {
+		endpointData["ssl_cert_expiry"] = state.SSLCertExpiry.Format(time.RFC3339)
+	}
+
+	// Add month-to-date availability for endpoints with an SLA target
+	if state.Endpoint.SLATarget > 0 {
+		endpointData["sla_target"] = state.Endpoint.SLATarget
+		if state.SLATotalChecks > 0 {
+			endpointData["sla_availability"] = float64(state.SLAHealthyChecks) / float64(state.SLATotalChecks) * 100
+		}
+	}
+
+	return endpointData
+}
+
+// endpointConflict returns why an endpoint with this name and URL can't be added, or "".
+// Names and URLs only need to be unique within a project; with allow_duplicate_urls only names do.
+func (h *HealthHandler) endpointConflict(endpoints []*structs.StoredEndpoint, projectID, name, url string) string {
+	sameURL := func(ep *structs.StoredEndpoint) bool {
+		return ep.URL == url && !h.config.AllowDuplicateURLs
+	}
+	for _, ep := range endpoints {
+		if ep.ProjectID != projectID {
+			continue
+		}
+		if ep.Archived && (ep.Name == name || sameURL(ep)) {
+			return "An archived endpoint with this name or URL exists; restore or purge it first"
+		}
+		if ep.Name == name {
+			return "Endpoint with this name already exists"
+		}
+		if sameURL(ep) {
+			return "Endpoint with this URL already exists"
+		}
+	}
+	return ""
+}
+
+// applyJourney validates a journey and makes an endpoint with one a browser check
+func applyJourney(checkType *structs.CheckType, steps []structs.JourneyStep) error {
+	if len(steps) == 0 {
+		return nil
+	}
+	if err := structs.ValidateJourney(steps); err != nil {
+		return err
+	}
+	switch *checkType {
+	case "":
+		*checkType = structs.CheckBrowser
+	case structs.CheckBrowser:
+	default:
+		return fmt.Errorf("a journey needs check_type browser")
+	}
+	return nil
+}
+
+// validSchedule reports whether a check schedule is empty or a cron expression that fires
+func validSchedule(schedule string) bool {
+	if schedule == "" {
+		return true
+	}
+	cron, err := utils.ParseCron(schedule)
+	return err == nil && !cron.Next(time.Now()).IsZero()
+}
+
+// validSuccessExpression checks that a success expression, if any, compiles
+func validSuccessExpression(expression string) error {
+	if expression == "" {
+		return nil
+	}
+	if _, err := utils.CompileExpr(expression); err != nil {
+		return fmt.Errorf("Invalid success_expression: %v", err)
+	}
+	return nil
+}
+
+// validAlternateHostnames checks that alternate_hostnames are bare hostnames
+func validAlternateHostnames(hosts []string) error {
+	for _, host := range hosts {
+		if err := utils.ValidateHostname(host); err != nil {
+			return fmt.Errorf("Invalid alternate_hostnames: %v", err)
+		}
+	}
+	return nil
+}
+
+// validRetries checks that retries is between 0 and structs.MaxRetries
+func validRetries(retries int) error {
+	if retries < 0 || retries > structs.MaxRetries {
+		return fmt.Errorf("Invalid retries: must be between 0 and %d", structs.MaxRetries)
+	}
+	return nil
+}
+
+// validBodyRegex checks that a body_regex, if any, compiles
+func validBodyRegex(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("Invalid body_regex: %v", err)
+	}
+	return nil
+}
+
+// parseHoldDuration parses a time-based status threshold, where empty means none
+func parseHoldDuration(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s format: %v", field, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("Invalid %s: must not be negative", field)
+	}
+	return d, nil
+}
+
+// validRunbookURL reports whether a runbook link is empty or an absolute http(s) URL
+func validRunbookURL(runbook string) bool {
+	if runbook == "" {
+		return true
+	}
+	u, err := url.Parse(runbook)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validCallbackURL reports whether a status callback is empty or an absolute http(s) URL
+func validCallbackURL(callback string) bool {
+	if callback == "" {
+		return true
+	}
+	u, err := url.Parse(callback)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// statusETag identifies a status response by state version, project scope and filters
+func (h *HealthHandler) statusETag(projectID string, r *http.Request) string {
+	hash := fnv.New64a()
+	hash.Write([]byte(projectID + "\x00" + r.URL.RawQuery + "\x00" + r.Header.Get("Accept-Timezone")))
+	return fmt.Sprintf(`W/"%x-%x"`, h.monitor.StateVersion(), hash.Sum64())
+}
+
+// etagMatches reports whether the request's If-None-Match lists etag, using weak comparison
+func etagMatches(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStatus returns the current status of all endpoints.
+// Supports ?status=, ?tag= and ?priority= filters and includes aggregate counts.
 func (h *HealthHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	// Unchanged polls are answered without serializing the status
+	etag := h.statusETag(projectID, r)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	states := h.monitor.GetStatus()
 
+	query := r.URL.Query()
+	statusFilter := strings.ToLower(query.Get("status"))
+	tagFilter := query.Get("tag")
+	priorityFilter := query.Get("priority")
+
 	response := map[string]interface{}{
 		"endpoints": make(map[string]interface{}),
 		"timestamp": time.Now(),
 	}
 
+	summary := map[string]int{
+		"total":          0,
+		"up":             0,
+		"down":           0,
+		"degraded":       0,
+		"unknown":        0,
+		"in_maintenance": 0,
+	}
+
 	endpoints := make(map[string]interface{})
 	for name, state := range states {
-		endpointData := map[string]interface{}{
-			"id":                    state.ID,
-			"name":                  state.Endpoint.Name,
-			"url":                   state.Endpoint.URL,
-			"method":                state.Endpoint.Method,
-			"status":                string(state.Status),
-			"last_check":            state.LastCheck.Format(time.RFC3339),
-			"last_success":          state.LastSuccess.Format(time.RFC3339),
-			"last_error":            state.LastError,
-			"response_time_ms":      float64(state.ResponseTime.Microseconds()) / 1000.0,
-			"consecutive_failures":  state.ConsecutiveFailures,
-			"consecutive_successes": state.ConsecutiveSuccesses,
-			"ssl_expiring_soon":     state.SSLExpiringSoon,
-			"days_to_expiry":        state.DaysToExpiry,
+		if !inScope(projectID, state.Endpoint.ProjectID) {
+			continue
+		}
+		if tagFilter != "" && !state.Endpoint.HasTag(tagFilter) {
+			continue
+		}
+		if priorityFilter != "" && !strings.EqualFold(string(state.Endpoint.Priority), priorityFilter) {
+			continue
 		}
 
-		// Add SSL expiry date if available
-		if !state.SSLCertExpiry.IsZero() {
-			endpointData["ssl_cert_expiry"] = state.SSLCertExpiry.Format(time.RFC3339)
+		// Counts cover the tag/priority selection so ?tag= gives per-tag totals
+		summary["total"]++
+		summary[statusCategory(state)]++
+		if state.AlertsSuppressed {
+			summary["in_maintenance"]++
 		}
 
-		endpoints[name] = endpointData
+		if !matchesStatusFilter(state, statusFilter) {
+			continue
+		}
+
+		data := h.endpointStatusData(state)
+		data["sparkline"] = h.monitor.Sparkline(state.ID)
+		endpoints[name] = data
 	}
 	response["endpoints"] = endpoints
+	response["summary"] = summary
+	response["connection_pool"] = h.monitor.GetPoolStats()
+	response["watchdog"] = h.monitor.GetWatchdogStats()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -71,7 +423,18 @@ func (h *HealthHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 
 // GetEndpoints returns all endpoints from the database
 func (h *HealthHandler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
-	endpoints, err := h.db.GetAllEndpoints()
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	var endpoints []*structs.StoredEndpoint
+	var err error
+	if projectID != "" {
+		endpoints, err = h.db.GetProjectEndpoints(projectID)
+	} else {
+		endpoints, err = h.db.GetAllEndpoints()
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -79,19 +442,27 @@ func (h *HealthHandler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"endpoints": endpoints,
+		"endpoints": filterArchived(endpoints, false),
 		"timestamp": time.Now().Format(time.RFC3339),
 	})
 }
 
 // GetExpiringCerts returns list of endpoints with expiring SSL certificates
 func (h *HealthHandler) GetExpiringCerts(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
 	states := h.monitor.GetStatus()
 
 	expiringCerts := []map[string]interface{}{}
 
 	for _, state := range states {
-		if state.SSLExpiringSoon {
+		if !inScope(projectID, state.Endpoint.ProjectID) {
+			continue
+		}
+		if state.SSLExpiringSoon && !state.SSLPaused {
 			certInfo := map[string]interface{}{
 				"id":             state.ID,
 				"name":           state.Endpoint.Name,
@@ -123,13 +494,33 @@ func (h *HealthHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	limit := 1000
-	records, err := h.db.GetHealthHistory(id, limit)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if !h.requireEndpointScope(w, r, id) {
 		return
 	}
 
+	limit := 1000
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+
+	// Small reads are answered from the in-memory ring without touching the database
+	records, ok := h.monitor.RecentResults(id, limit)
+	if !ok {
+		var err error
+		records, err = h.db.GetHealthHistory(id, limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Calculate average response time
 	var totalResponseTime int64
 	var count int
@@ -148,6 +539,7 @@ func (h *HealthHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"endpoint_id":          id,
 		"records":              records,
+		"deployments":          h.endpointDeployments(id, historySince(records)),
 		"avg_response_time_ms": avgResponseTimeMs,
 		"record_count":         count,
 		"timestamp":            time.Now().Format(time.RFC3339),
@@ -162,20 +554,58 @@ func (h *HealthHandler) AddEndpoint(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		Name             string            `json:"name"`
-		URL              string            `json:"url"`
-		MonitorHealth    bool              `json:"monitor_health"`
-		Method           string            `json:"method"`
-		Timeout          string            `json:"timeout"`
-		CheckInterval    string            `json:"check_interval"`
-		ExpectedStatus   int               `json:"expected_status"`
-		Headers          map[string]string `json:"headers"`
-		FailureThreshold int               `json:"failure_threshold"`
-		SuccessThreshold int               `json:"success_threshold"`
+		Name               string                `json:"name"`
+		URL                string                `json:"url"`
+		MonitorHealth      bool                  `json:"monitor_health"`
+		Method             string                `json:"method"`
+		Timeout            string                `json:"timeout"`
+		CheckInterval      string                `json:"check_interval"`
+		ExpectedStatus     int                   `json:"expected_status"`
+		Headers            map[string]string     `json:"headers"`
+		FailureThreshold   int                   `json:"failure_threshold"`
+		NetworkThreshold   int                   `json:"network_failure_threshold"`
+		AppThreshold       int                   `json:"application_failure_threshold"`
+		SuccessThreshold   int                   `json:"success_threshold"`
+		FailureDuration    string                `json:"failure_duration"`
+		SuccessDuration    string                `json:"success_duration"`
+		ResolveTo          string                `json:"resolve_to"`
+		HostHeader         string                `json:"host_header"`
+		UseCookies         bool                  `json:"use_cookies"`
+		BackoffEnabled     bool                  `json:"backoff_enabled"`
+		BackoffAfter       string                `json:"backoff_after"`
+		Retries            int                   `json:"retries"`
+		RetryDelay         string                `json:"retry_delay"`
+		BackoffMaxInterval string                `json:"backoff_max_interval"`
+		Priority           structs.Priority      `json:"priority"`
+		InsecureSkipVerify bool                  `json:"insecure_skip_verify"`
+		CertFingerprint    string                `json:"cert_fingerprint"`
+		Tags               []string              `json:"tags"`
+		SLATarget          float64               `json:"sla_target"`
+		Description        string                `json:"description"`
+		Owner              string                `json:"owner"`
+		RunbookURL         string                `json:"runbook_url"`
+		Labels             map[string]string     `json:"labels"`
+		RateLimitMode      structs.RateLimitMode `json:"rate_limit_mode"`
+		Schedule           string                `json:"schedule"`
+		CheckType          structs.CheckType     `json:"check_type"`
+		WaitSelector       string                `json:"wait_selector"`
+		Journey            []structs.JourneyStep `json:"journey"`
+		ContentType        string                `json:"expected_content_type"`
+		SuccessExpression  string                `json:"success_expression"`
+		BodyContains       string                `json:"body_contains"`
+		BodyNotContains    string                `json:"body_not_contains"`
+		BodyRegex          string                `json:"body_regex"`
+		AlternateHosts     []string              `json:"alternate_hostnames"`
+		CallbackURL        string                `json:"callback_url"`
+		HistorySample      int                   `json:"history_sample_every"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
 		return
 	}
 
@@ -191,6 +621,90 @@ func (h *HealthHandler) AddEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.SLATarget < 0 || req.SLATarget >= 100 {
+		http.Error(w, "Invalid sla_target: must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+
+	if req.NetworkThreshold < 0 || req.AppThreshold < 0 {
+		http.Error(w, "Invalid failure threshold: must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	if err := validRetries(req.Retries); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !validRunbookURL(req.RunbookURL) {
+		http.Error(w, "Invalid runbook_url: must be an http or https URL", http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.ValidateLabels(req.Labels); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Priority != "" && !req.Priority.Valid() {
+		http.Error(w, "Invalid priority: must be critical, high, normal or low", http.StatusBadRequest)
+		return
+	}
+
+	if !validSchedule(req.Schedule) {
+		http.Error(w, "Invalid schedule: must be a 5-field cron expression that fires", http.StatusBadRequest)
+		return
+	}
+
+	if !req.CheckType.Valid() {
+		http.Error(w, "Invalid check_type: must be http, browser or negative", http.StatusBadRequest)
+		return
+	}
+	if err := applyJourney(&req.CheckType, req.Journey); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.ContentType != "" {
+		if err := utils.ValidateContentType(req.ContentType); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := validSuccessExpression(req.SuccessExpression); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validBodyRegex(req.BodyRegex); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := validAlternateHostnames(req.AlternateHosts); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !validCallbackURL(req.CallbackURL) {
+		http.Error(w, "Invalid callback_url: must be an http or https URL", http.StatusBadRequest)
+		return
+	}
+
+	if req.HistorySample < 0 {
+		http.Error(w, "Invalid history_sample_every: must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	if req.RateLimitMode == "" {
+		req.RateLimitMode = structs.RateLimitDegraded
+	}
+	if !req.RateLimitMode.Valid() {
+		http.Error(w, "Invalid rate_limit_mode: must be degraded or failure", http.StatusBadRequest)
+		return
+	}
+
 	// Check if endpoint with same name or URL already exists
 	allEndpoints, err := h.db.GetAllEndpoints()
 	if err != nil {
@@ -198,18 +712,13 @@ func (h *HealthHandler) AddEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	for _, ep := range allEndpoints {
-		if ep.Name == req.Name {
-			http.Error(w, "Endpoint with this name already exists", http.StatusConflict)
-			return
-		}
-		if ep.URL == req.URL {
-			http.Error(w, "Endpoint with this URL already exists", http.StatusConflict)
-			return
-		}
+	if conflict := h.endpointConflict(allEndpoints, projectID, req.Name, req.URL); conflict != "" {
+		http.Error(w, conflict, http.StatusConflict)
+		return
 	}
 
-	timeout := 10 * time.Second
+	// Unset settings get the configured defaults when the endpoint is saved
+	var timeout time.Duration
 	if req.Timeout != "" && req.MonitorHealth {
 		var err error
 		timeout, err = time.ParseDuration(req.Timeout)
@@ -221,32 +730,103 @@ func (h *HealthHandler) AddEndpoint(w http.ResponseWriter, r *http.Request) {
 
 	// If health monitoring is disabled, set check interval to 0
 	var checkInterval time.Duration
-	if req.MonitorHealth {
-		checkInterval = 30 * time.Second
-		if req.CheckInterval != "" {
-			var err error
-			checkInterval, err = time.ParseDuration(req.CheckInterval)
-			if err != nil {
-				http.Error(w, "Invalid check_interval format: "+err.Error(), http.StatusBadRequest)
-				return
-			}
+	if req.MonitorHealth && req.CheckInterval != "" {
+		var err error
+		checkInterval, err = time.ParseDuration(req.CheckInterval)
+		if err != nil {
+			http.Error(w, "Invalid check_interval format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	failureDuration, err := parseHoldDuration("failure_duration", req.FailureDuration)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	successDuration, err := parseHoldDuration("success_duration", req.SuccessDuration)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var retryDelay time.Duration
+	if req.RetryDelay != "" {
+		var err error
+		retryDelay, err = time.ParseDuration(req.RetryDelay)
+		if err != nil || retryDelay < 0 {
+			http.Error(w, "Invalid retry_delay format: must be a non-negative duration", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var backoffAfter, backoffMaxInterval time.Duration
+	if req.BackoffAfter != "" {
+		var err error
+		backoffAfter, err = time.ParseDuration(req.BackoffAfter)
+		if err != nil {
+			http.Error(w, "Invalid backoff_after format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if req.BackoffMaxInterval != "" {
+		var err error
+		backoffMaxInterval, err = time.ParseDuration(req.BackoffMaxInterval)
+		if err != nil {
+			http.Error(w, "Invalid backoff_max_interval format: "+err.Error(), http.StatusBadRequest)
+			return
 		}
 	}
 
 	endpoint := &structs.StoredEndpoint{
-		ID:               utils.GenerateIDWithURL(req.Name, req.URL),
-		Name:             req.Name,
-		URL:              req.URL,
-		Method:           req.Method,
-		Timeout:          timeout,
-		CheckInterval:    checkInterval,
-		ExpectedStatus:   req.ExpectedStatus,
-		Headers:          req.Headers,
-		FailureThreshold: req.FailureThreshold,
-		SuccessThreshold: req.SuccessThreshold,
-		Enabled:          true,
-		AlertsSuppressed: false,
-		MonitorHealth:    req.MonitorHealth,
+		ID:                 utils.NewUUID(),
+		Name:               req.Name,
+		URL:                req.URL,
+		Method:             req.Method,
+		Timeout:            timeout,
+		CheckInterval:      checkInterval,
+		ExpectedStatus:     req.ExpectedStatus,
+		Headers:            req.Headers,
+		FailureThreshold:   req.FailureThreshold,
+		NetworkThreshold:   req.NetworkThreshold,
+		AppThreshold:       req.AppThreshold,
+		SuccessThreshold:   req.SuccessThreshold,
+		FailureDuration:    failureDuration,
+		SuccessDuration:    successDuration,
+		ResolveTo:          req.ResolveTo,
+		HostHeader:         req.HostHeader,
+		UseCookies:         req.UseCookies,
+		BackoffEnabled:     req.BackoffEnabled,
+		BackoffAfter:       backoffAfter,
+		Retries:            req.Retries,
+		RetryDelay:         retryDelay,
+		BackoffMaxInterval: backoffMaxInterval,
+		Priority:           req.Priority,
+		InsecureSkipVerify: req.InsecureSkipVerify,
+		CertFingerprint:    req.CertFingerprint,
+		Tags:               req.Tags,
+		SLATarget:          req.SLATarget,
+		Description:        req.Description,
+		Owner:              req.Owner,
+		RunbookURL:         req.RunbookURL,
+		Labels:             req.Labels,
+		RateLimitMode:      req.RateLimitMode,
+		Schedule:           req.Schedule,
+		CheckType:          req.CheckType,
+		WaitSelector:       req.WaitSelector,
+		ContentType:        req.ContentType,
+		SuccessExpression:  req.SuccessExpression,
+		BodyContains:       req.BodyContains,
+		BodyNotContains:    req.BodyNotContains,
+		BodyRegex:          req.BodyRegex,
+		AlternateHosts:     req.AlternateHosts,
+		CallbackURL:        req.CallbackURL,
+		HistorySample:      req.HistorySample,
+		Journey:            req.Journey,
+		ProjectID:          projectID,
+		Enabled:            true,
+		AlertsSuppressed:   false,
+		MonitorHealth:      req.MonitorHealth,
 	}
 
 	if err := h.monitor.AddEndpoint(endpoint); err != nil {
@@ -262,7 +842,7 @@ func (h *HealthHandler) AddEndpoint(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DeleteEndpoint removes an endpoint from monitoring
+// DeleteEndpoint archives an endpoint, stopping its checks but keeping config and history
 func (h *HealthHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
 	logger.Debugf("Delete endpoint request: method=%s", r.Method)
 
@@ -272,6 +852,10 @@ func (h *HealthHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.requireTOTP(w, r) {
+		return
+	}
+
 	id := r.URL.Query().Get("id")
 	logger.Debugf("Delete endpoint: query id=%s", id)
 
@@ -279,12 +863,11 @@ func (h *HealthHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
 		var req struct {
 			ID string `json:"id"`
 		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err == nil {
-			id = req.ID
-			logger.Debugf("Delete endpoint: body id=%s", id)
-		} else {
-			logger.Debugf("Delete endpoint: body decode error=%v", err)
+		if !decodeOptionalJSON(w, r, &req) {
+			return
 		}
+		id = req.ID
+		logger.Debugf("Delete endpoint: body id=%s", id)
 	}
 
 	if id == "" {
@@ -293,18 +876,23 @@ func (h *HealthHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Debugf("Delete endpoint: attempting to remove id=%s", id)
-	if err := h.monitor.RemoveEndpoint(id); err != nil {
+	if !h.requireEndpointScope(w, r, id) {
+		return
+	}
+
+	// Deleting archives the endpoint; purge it to remove it for good
+	logger.Debugf("Delete endpoint: attempting to archive id=%s", id)
+	if err := h.monitor.ArchiveEndpoint(id); err != nil {
 		logger.Errorf("Delete endpoint: error=%v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	logger.Infof("Delete endpoint: success id=%s", id)
+	logger.Infof("Delete endpoint: archived id=%s", id)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
-		"message": "Endpoint deleted",
+		"message": "Endpoint archived",
 	})
 }
 
@@ -315,12 +903,47 @@ func (h *HealthHandler) EnableEndpoint(w http.ResponseWriter, r *http.Request) {
 
 // DisableEndpoint disables an endpoint
 func (h *HealthHandler) DisableEndpoint(w http.ResponseWriter, r *http.Request) {
+	if !h.requireTOTP(w, r) {
+		return
+	}
 	h.handleEndpointAction(w, r, h.monitor.DisableEndpoint, "disabled")
 }
 
-// SuppressAlerts suppresses alerts for an endpoint
+// SuppressAlerts suppresses alerts for an endpoint, for a while when ?duration= or ?until= is set
 func (h *HealthHandler) SuppressAlerts(w http.ResponseWriter, r *http.Request) {
-	h.handleEndpointAction(w, r, h.monitor.SuppressAlerts, "alerts suppressed")
+	if !h.requireTOTP(w, r) {
+		return
+	}
+
+	duration, until := r.URL.Query().Get("duration"), r.URL.Query().Get("until")
+	if duration == "" && until == "" {
+		h.handleEndpointAction(w, r, h.monitor.SuppressAlerts, "alerts suppressed")
+		return
+	}
+
+	end, err := utils.ParseSnooze(duration, until, time.Now(), h.requestLocation(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.handleEndpointAction(w, r, func(id string) error {
+		return h.monitor.SnoozeAlerts(id, end)
+	}, "alerts suppressed until "+end.Format(time.RFC3339))
+}
+
+// requestLocation returns the zone named by ?tz= or Accept-Timezone, else the configured one
+func (h *HealthHandler) requestLocation(r *http.Request) *time.Location {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		name = r.Header.Get("Accept-Timezone")
+	}
+	if name == "" {
+		name = h.config.Timezone
+	}
+	if loc, err := utils.LoadTimezone(name); err == nil {
+		return loc
+	}
+	return time.UTC
 }
 
 // UnsuppressAlerts enables alerts for an endpoint
@@ -328,6 +951,32 @@ func (h *HealthHandler) UnsuppressAlerts(w http.ResponseWriter, r *http.Request)
 	h.handleEndpointAction(w, r, h.monitor.UnsuppressAlerts, "alerts enabled")
 }
 
+// PauseHealthChecks stops an endpoint's health checks but keeps tracking its certificate
+func (h *HealthHandler) PauseHealthChecks(w http.ResponseWriter, r *http.Request) {
+	if !h.requireTOTP(w, r) {
+		return
+	}
+	h.handleEndpointAction(w, r, h.monitor.PauseHealthChecks, "health checks paused")
+}
+
+// ResumeHealthChecks restarts an endpoint's health checks
+func (h *HealthHandler) ResumeHealthChecks(w http.ResponseWriter, r *http.Request) {
+	h.handleEndpointAction(w, r, h.monitor.ResumeHealthChecks, "health checks resumed")
+}
+
+// PauseSSLChecks stops tracking an endpoint's certificate but keeps checking its health
+func (h *HealthHandler) PauseSSLChecks(w http.ResponseWriter, r *http.Request) {
+	if !h.requireTOTP(w, r) {
+		return
+	}
+	h.handleEndpointAction(w, r, h.monitor.PauseSSLChecks, "SSL checks paused")
+}
+
+// ResumeSSLChecks restarts tracking an endpoint's certificate
+func (h *HealthHandler) ResumeSSLChecks(w http.ResponseWriter, r *http.Request) {
+	h.handleEndpointAction(w, r, h.monitor.ResumeSSLChecks, "SSL checks resumed")
+}
+
 // handleEndpointAction is a helper for endpoint actions
 func (h *HealthHandler) handleEndpointAction(w http.ResponseWriter, r *http.Request, action func(string) error, actionName string) {
 	if r.Method != http.MethodPost {
@@ -340,9 +989,10 @@ func (h *HealthHandler) handleEndpointAction(w http.ResponseWriter, r *http.Requ
 		var req struct {
 			ID string `json:"id"`
 		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err == nil {
-			id = req.ID
+		if !decodeOptionalJSON(w, r, &req) {
+			return
 		}
+		id = req.ID
 	}
 
 	if id == "" {
@@ -350,6 +1000,10 @@ func (h *HealthHandler) handleEndpointAction(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if !h.requireEndpointScope(w, r, id) {
+		return
+	}
+
 	if err := action(id); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -374,8 +1028,7 @@ func (h *HealthHandler) ToggleEndpoint(w http.ResponseWriter, r *http.Request) {
 		Enabled bool   `json:"enabled"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -411,8 +1064,7 @@ func (h *HealthHandler) ToggleAlerts(w http.ResponseWriter, r *http.Request) {
 		Suppressed bool   `json:"suppressed"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -444,15 +1096,53 @@ func (h *HealthHandler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		ID               string `json:"id"`
-		CheckInterval    string `json:"check_interval"`
-		Timeout          string `json:"timeout"`
-		FailureThreshold int    `json:"failure_threshold"`
-		SuccessThreshold int    `json:"success_threshold"`
+		ID                 string                `json:"id"`
+		CheckInterval      string                `json:"check_interval"`
+		Timeout            string                `json:"timeout"`
+		FailureThreshold   int                   `json:"failure_threshold"`
+		NetworkThreshold   *int                  `json:"network_failure_threshold"`
+		AppThreshold       *int                  `json:"application_failure_threshold"`
+		SuccessThreshold   int                   `json:"success_threshold"`
+		FailureDuration    *string               `json:"failure_duration"`
+		SuccessDuration    *string               `json:"success_duration"`
+		ResolveTo          *string               `json:"resolve_to"`
+		HostHeader         *string               `json:"host_header"`
+		UseCookies         *bool                 `json:"use_cookies"`
+		BackoffEnabled     *bool                 `json:"backoff_enabled"`
+		BackoffAfter       string                `json:"backoff_after"`
+		Retries            *int                  `json:"retries"`
+		RetryDelay         string                `json:"retry_delay"`
+		BackoffMaxInterval string                `json:"backoff_max_interval"`
+		Priority           string                `json:"priority"`
+		InsecureSkipVerify *bool                 `json:"insecure_skip_verify"`
+		CertFingerprint    *string               `json:"cert_fingerprint"`
+		Tags               []string              `json:"tags"`
+		SLATarget          *float64              `json:"sla_target"`
+		Description        *string               `json:"description"`
+		Owner              *string               `json:"owner"`
+		RunbookURL         *string               `json:"runbook_url"`
+		Labels             map[string]string     `json:"labels"`
+		RateLimitMode      string                `json:"rate_limit_mode"`
+		Schedule           *string               `json:"schedule"`
+		ExpectedStatus     *int                  `json:"expected_status"`
+		CheckType          *string               `json:"check_type"`
+		WaitSelector       *string               `json:"wait_selector"`
+		Journey            []structs.JourneyStep `json:"journey"`
+		ContentType        *string               `json:"expected_content_type"`
+		SuccessExpression  *string               `json:"success_expression"`
+		BodyContains       *string               `json:"body_contains"`
+		BodyNotContains    *string               `json:"body_not_contains"`
+		BodyRegex          *string               `json:"body_regex"`
+		AlternateHosts     []string              `json:"alternate_hostnames"`
+		CallbackURL        *string               `json:"callback_url"`
+		HistorySample      *int                  `json:"history_sample_every"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, req.ID) || !h.requireTOTP(w, r) {
 		return
 	}
 
@@ -482,9 +1172,227 @@ func (h *HealthHandler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 	if req.FailureThreshold > 0 {
 		endpoint.FailureThreshold = req.FailureThreshold
 	}
+	// Zero clears a per-kind threshold back to failure_threshold
+	if req.NetworkThreshold != nil {
+		if *req.NetworkThreshold < 0 {
+			http.Error(w, "Invalid network_failure_threshold: must not be negative", http.StatusBadRequest)
+			return
+		}
+		endpoint.NetworkThreshold = *req.NetworkThreshold
+	}
+	if req.AppThreshold != nil {
+		if *req.AppThreshold < 0 {
+			http.Error(w, "Invalid application_failure_threshold: must not be negative", http.StatusBadRequest)
+			return
+		}
+		endpoint.AppThreshold = *req.AppThreshold
+	}
+	// An empty string clears a time-based threshold
+	if req.FailureDuration != nil {
+		d, err := parseHoldDuration("failure_duration", *req.FailureDuration)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.FailureDuration = d
+	}
+	if req.SuccessDuration != nil {
+		d, err := parseHoldDuration("success_duration", *req.SuccessDuration)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.SuccessDuration = d
+	}
 	if req.SuccessThreshold > 0 {
 		endpoint.SuccessThreshold = req.SuccessThreshold
 	}
+	if req.ResolveTo != nil {
+		endpoint.ResolveTo = *req.ResolveTo
+	}
+	if req.HostHeader != nil {
+		endpoint.HostHeader = *req.HostHeader
+	}
+	if req.UseCookies != nil {
+		endpoint.UseCookies = *req.UseCookies
+	}
+	if req.Priority != "" {
+		priority := structs.Priority(req.Priority)
+		if !priority.Valid() {
+			http.Error(w, "Invalid priority: must be critical, high, normal or low", http.StatusBadRequest)
+			return
+		}
+		endpoint.Priority = priority
+	}
+	if req.InsecureSkipVerify != nil {
+		endpoint.InsecureSkipVerify = *req.InsecureSkipVerify
+	}
+	if req.CertFingerprint != nil {
+		endpoint.CertFingerprint = *req.CertFingerprint
+	}
+	if req.Tags != nil {
+		endpoint.Tags = req.Tags
+	}
+	if req.SLATarget != nil {
+		if *req.SLATarget < 0 || *req.SLATarget >= 100 {
+			http.Error(w, "Invalid sla_target: must be between 0 and 100", http.StatusBadRequest)
+			return
+		}
+		endpoint.SLATarget = *req.SLATarget
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if req.Owner != nil {
+		endpoint.Owner = *req.Owner
+	}
+	if req.RunbookURL != nil {
+		if !validRunbookURL(*req.RunbookURL) {
+			http.Error(w, "Invalid runbook_url: must be an http or https URL", http.StatusBadRequest)
+			return
+		}
+		endpoint.RunbookURL = *req.RunbookURL
+	}
+	if req.Labels != nil {
+		if err := utils.ValidateLabels(req.Labels); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.Labels = req.Labels
+	}
+	if req.RateLimitMode != "" {
+		mode := structs.RateLimitMode(req.RateLimitMode)
+		if !mode.Valid() {
+			http.Error(w, "Invalid rate_limit_mode: must be degraded or failure", http.StatusBadRequest)
+			return
+		}
+		endpoint.RateLimitMode = mode
+	}
+	if req.Schedule != nil {
+		// An empty schedule goes back to the fixed check interval
+		if !validSchedule(*req.Schedule) {
+			http.Error(w, "Invalid schedule: must be a 5-field cron expression that fires", http.StatusBadRequest)
+			return
+		}
+		endpoint.Schedule = *req.Schedule
+	}
+	if req.CheckType != nil {
+		checkType := structs.CheckType(*req.CheckType)
+		if !checkType.Valid() {
+			http.Error(w, "Invalid check_type: must be http, browser or negative", http.StatusBadRequest)
+			return
+		}
+		// A negative endpoint must not answer at all unless given an expected status
+		if checkType == structs.CheckNegative && endpoint.CheckType != structs.CheckNegative && req.ExpectedStatus == nil {
+			endpoint.ExpectedStatus = 0
+		}
+		endpoint.CheckType = checkType
+	}
+	if req.ExpectedStatus != nil {
+		if *req.ExpectedStatus < 0 || *req.ExpectedStatus > 599 {
+			http.Error(w, "Invalid expected_status: must be an HTTP status code", http.StatusBadRequest)
+			return
+		}
+		endpoint.ExpectedStatus = *req.ExpectedStatus
+	}
+	if req.WaitSelector != nil {
+		endpoint.WaitSelector = *req.WaitSelector
+	}
+	// An empty content type stops checking it
+	if req.ContentType != nil {
+		if *req.ContentType != "" {
+			if err := utils.ValidateContentType(*req.ContentType); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		endpoint.ContentType = *req.ContentType
+	}
+	// An empty expression goes back to checking expected_status
+	if req.SuccessExpression != nil {
+		if err := validSuccessExpression(*req.SuccessExpression); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.SuccessExpression = *req.SuccessExpression
+	}
+	// An empty value drops that body assertion
+	if req.BodyContains != nil {
+		endpoint.BodyContains = *req.BodyContains
+	}
+	if req.BodyNotContains != nil {
+		endpoint.BodyNotContains = *req.BodyNotContains
+	}
+	if req.BodyRegex != nil {
+		if err := validBodyRegex(*req.BodyRegex); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.BodyRegex = *req.BodyRegex
+	}
+	if req.AlternateHosts != nil {
+		if err := validAlternateHostnames(req.AlternateHosts); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.AlternateHosts = req.AlternateHosts
+	}
+	if req.CallbackURL != nil {
+		if !validCallbackURL(*req.CallbackURL) {
+			http.Error(w, "Invalid callback_url: must be an http or https URL", http.StatusBadRequest)
+			return
+		}
+		endpoint.CallbackURL = *req.CallbackURL
+	}
+	if req.HistorySample != nil {
+		if *req.HistorySample < 0 {
+			http.Error(w, "Invalid history_sample_every: must not be negative", http.StatusBadRequest)
+			return
+		}
+		endpoint.HistorySample = *req.HistorySample
+	}
+	// An empty journey clears it
+	if req.Journey != nil {
+		endpoint.Journey = req.Journey
+	}
+	if err := applyJourney(&endpoint.CheckType, endpoint.Journey); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.BackoffEnabled != nil {
+		endpoint.BackoffEnabled = *req.BackoffEnabled
+	}
+	if req.BackoffAfter != "" {
+		after, err := time.ParseDuration(req.BackoffAfter)
+		if err != nil {
+			http.Error(w, "Invalid backoff_after format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.BackoffAfter = after
+	}
+	if req.Retries != nil {
+		if err := validRetries(*req.Retries); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.Retries = *req.Retries
+	}
+	if req.RetryDelay != "" {
+		delay, err := time.ParseDuration(req.RetryDelay)
+		if err != nil || delay < 0 {
+			http.Error(w, "Invalid retry_delay format: must be a non-negative duration", http.StatusBadRequest)
+			return
+		}
+		endpoint.RetryDelay = delay
+	}
+	if req.BackoffMaxInterval != "" {
+		maxInterval, err := time.ParseDuration(req.BackoffMaxInterval)
+		if err != nil {
+			http.Error(w, "Invalid backoff_max_interval format: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		endpoint.BackoffMaxInterval = maxInterval
+	}
 
 	if err := h.db.SaveEndpoint(endpoint); err != nil {
 		logger.Errorf("Failed to update endpoint: %v", err)
@@ -503,10 +1411,17 @@ func (h *HealthHandler) UpdateEndpoint(w http.ResponseWriter, r *http.Request) {
 
 // GetConfig returns public configuration settings
 func (h *HealthHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	totpEnabled := false
+	if settings, err := h.totpSettings(); err == nil {
+		totpEnabled = settings.Enabled
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"ssl_expiry_warning_days": h.config.SSLExpiryWarningDays,
 		"has_passkey":             h.config.AdminPasskey != "",
+		"totp_enabled":            totpEnabled,
+		"read_only":               h.config.ReadOnly,
 	})
 }
 
@@ -521,8 +1436,7 @@ func (h *HealthHandler) VerifyPasskey(w http.ResponseWriter, r *http.Request) {
 		Passkey string `json:"passkey"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -551,8 +1465,7 @@ func (h *HealthHandler) EnableHealthMonitoring(w http.ResponseWriter, r *http.Re
 		SuccessThreshold int    `json:"success_threshold"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -562,6 +1475,10 @@ func (h *HealthHandler) EnableHealthMonitoring(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if !h.requireEndpointScope(w, r, req.ID) || !h.requireTOTP(w, r) {
+		return
+	}
+
 	endpoint, err := h.db.GetEndpoint(req.ID)
 	if err != nil {
 		http.Error(w, "Endpoint not found", http.StatusNotFound)
@@ -579,7 +1496,7 @@ func (h *HealthHandler) EnableHealthMonitoring(w http.ResponseWriter, r *http.Re
 		}
 		endpoint.CheckInterval = interval
 	} else {
-		endpoint.CheckInterval = 30 * time.Second
+		endpoint.CheckInterval = h.config.DefaultInterval.Duration
 	}
 
 	if req.Timeout != "" {
@@ -615,13 +1532,54 @@ func (h *HealthHandler) EnableHealthMonitoring(w http.ResponseWriter, r *http.Re
 	})
 }
 
-// ReRunSSLCheck triggers SSL validation for all endpoints
+// ReRunSSLCheck triggers SSL validation for one endpoint (?id=) or all endpoints
 func (h *HealthHandler) ReRunSSLCheck(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		var req struct {
+			ID string `json:"id"`
+		}
+		if !decodeOptionalJSON(w, r, &req) {
+			return
+		}
+		id = req.ID
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	if projectID != "" && id == "" {
+		http.Error(w, "Endpoint ID is required for project API keys", http.StatusBadRequest)
+		return
+	}
+
+	if id != "" {
+		if !h.endpointInScope(projectID, id) {
+			http.Error(w, "Endpoint not found", http.StatusNotFound)
+			return
+		}
+
+		logger.Infof("Manual SSL recheck triggered for endpoint: %s", id)
+
+		if err := h.monitor.TriggerSSLRecheckFor(id); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"message": "SSL validation triggered for endpoint " + id,
+		})
+		return
+	}
+
 	logger.Infof("Manual SSL recheck triggered")
 
 	// Trigger SSL check for all endpoints
@@ -633,3 +1591,58 @@ func (h *HealthHandler) ReRunSSLCheck(w http.ResponseWriter, r *http.Request) {
 		"message": "SSL validation triggered for all endpoints",
 	})
 }
+
+// SendSSLSummary sends the daily SSL expiry summary immediately
+func (h *HealthHandler) SendSSLSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	if projectID != "" {
+		http.Error(w, "Project API keys cannot send the global SSL summary", http.StatusForbidden)
+		return
+	}
+
+	logger.Infof("Manual SSL expiry summary triggered")
+
+	count := h.monitor.SendSSLExpirySummaryNow()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"count":   count,
+		"message": "SSL expiry summary sent",
+	})
+}
+
+// SendDigest sends the email digest named by ?name= immediately
+func (h *HealthHandler) SendDigest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "Digest name is required", http.StatusBadRequest)
+		return
+	}
+
+	logger.Infof("Manual digest triggered: %s", name)
+
+	if err := h.monitor.SendDigestNow(name); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Digest sent",
+	})
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/importer"
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// maxImportSize caps the size of an uploaded export
+const maxImportSize = 10 << 20
+
+// ImportEndpoints creates endpoints from an Uptime Kuma, UptimeRobot or Pingdom export
+// for POST /api/import?source=kuma|uptimerobot|pingdom[&dry_run=true]
+func (h *HealthHandler) ImportEndpoints(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		writeBodyError(w, describeBodyError(err, maxImportSize))
+		return
+	}
+
+	result, err := importer.Parse(r.URL.Query().Get("source"), data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	allEndpoints, err := h.db.GetAllEndpoints()
+	if err != nil {
+		http.Error(w, "Failed to check existing endpoints: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	imported := result.Endpoints[:0]
+	for _, endpoint := range result.Endpoints {
+		if !strings.Contains(endpoint.URL, "://") {
+			result.Skipped = append(result.Skipped, endpoint.Name+": invalid URL "+endpoint.URL)
+			continue
+		}
+		// Earlier monitors of the same export count as existing
+		if conflict := h.endpointConflict(allEndpoints, projectID, endpoint.Name, endpoint.URL); conflict != "" {
+			result.Skipped = append(result.Skipped, endpoint.Name+": "+conflict)
+			continue
+		}
+
+		endpoint.ProjectID = projectID
+		endpoint.ID = utils.NewUUID()
+
+		if !dryRun {
+			if err := h.monitor.AddEndpoint(endpoint); err != nil {
+				logger.Errorf("Failed to import endpoint %s: %v", endpoint.Name, err)
+				result.Skipped = append(result.Skipped, endpoint.Name+": "+err.Error())
+				continue
+			}
+		}
+		allEndpoints = append(allEndpoints, endpoint)
+		imported = append(imported, endpoint)
+	}
+	result.Endpoints = imported
+
+	if !dryRun {
+		logger.Infof("Imported %d endpoints, skipped %d", len(result.Endpoints), len(result.Skipped))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"dry_run":   dryRun,
+		"endpoints": result.Endpoints,
+		"skipped":   result.Skipped,
+		"warnings":  result.Warnings,
+	})
+}
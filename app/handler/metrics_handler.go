@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// metricLabelNames are the built-in labels on every endpoint series; endpoint labels never override them
+var metricLabelNames = []string{"id", "name", "url", "project"}
+
+// metricLabelEscaper escapes label values for the Prometheus text format
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricLabels renders the label set for an endpoint's series
+func metricLabels(state *structs.EndpointState) string {
+	values := map[string]string{
+		"id":      state.ID,
+		"name":    state.Endpoint.Name,
+		"url":     state.Endpoint.URL,
+		"project": state.Endpoint.ProjectID,
+	}
+
+	pairs := make([]string, 0, len(values)+len(state.Endpoint.Labels))
+	for _, name := range metricLabelNames {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, metricLabelEscaper.Replace(values[name])))
+	}
+	for _, name := range utils.SortedKeys(state.Endpoint.Labels) {
+		if _, builtin := values[name]; builtin {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, metricLabelEscaper.Replace(state.Endpoint.Labels[name])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// GetMetrics exposes endpoint health in the Prometheus text format, labelled with endpoint labels
+func (h *HealthHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	var states []*structs.EndpointState
+	for _, state := range h.monitor.GetStatus() {
+		if state.Enabled && inScope(projectID, state.Endpoint.ProjectID) {
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ID < states[j].ID
+	})
+
+	var b strings.Builder
+	series := func(name, help, kind string, value func(*structs.EndpointState) (float64, bool)) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, state := range states {
+			if v, ok := value(state); ok {
+				fmt.Fprintf(&b, "%s%s %g\n", name, metricLabels(state), v)
+			}
+		}
+	}
+	metric := func(name, help string, value func(*structs.EndpointState) (float64, bool)) {
+		series(name, help, "gauge", value)
+	}
+
+	metric("sitewatch_endpoint_up", "Whether the endpoint is healthy (1) or not (0).", func(state *structs.EndpointState) (float64, bool) {
+		if !state.MonitorHealth || state.Status == structs.StatusUnknown {
+			return 0, false
+		}
+		if state.Status == structs.StatusHealthy {
+			return 1, true
+		}
+		return 0, true
+	})
+	metric("sitewatch_endpoint_response_time_seconds", "Response time of the last check.", func(state *structs.EndpointState) (float64, bool) {
+		return state.ResponseTime.Seconds(), state.MonitorHealth && !state.LastCheck.IsZero()
+	})
+	metric("sitewatch_endpoint_consecutive_failures", "Consecutive failed checks.", func(state *structs.EndpointState) (float64, bool) {
+		return float64(state.ConsecutiveFailures), state.MonitorHealth
+	})
+	metric("sitewatch_endpoint_ssl_expiry_timestamp_seconds", "Expiry time of the endpoint's TLS certificate.", func(state *structs.EndpointState) (float64, bool) {
+		return float64(state.SSLCertExpiry.Unix()), !state.SSLCertExpiry.IsZero()
+	})
+	series("sitewatch_endpoint_checks_total", "Health checks started.", "counter", func(state *structs.EndpointState) (float64, bool) {
+		return float64(state.ChecksRun), state.MonitorHealth
+	})
+	series("sitewatch_endpoint_missed_checks_total", "Scheduled health checks that did not run, e.g. while the process stalled.", "counter", func(state *structs.EndpointState) (float64, bool) {
+		return float64(state.MissedChecks), state.MonitorHealth
+	})
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
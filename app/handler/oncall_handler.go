@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+	"github.com/ashanmugaraja/cronzee/app/worker"
+)
+
+// GetOnCallSchedules returns every on-call schedule with who is on call now and next
+func (h *HealthHandler) GetOnCallSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.db.GetAllOnCallSchedules()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	users, err := h.db.GetAllUsers()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names := make(map[string]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(schedules))
+	for _, schedule := range schedules {
+		entry := map[string]interface{}{
+			"schedule": schedule,
+		}
+		if shift, ok := worker.CurrentOnCall(schedule, now); ok {
+			entry["on_call"] = map[string]interface{}{
+				"user_id":      shift.UserID,
+				"name":         names[shift.UserID],
+				"next_user_id": shift.NextUserID,
+				"next_name":    names[shift.NextUserID],
+				"next_handoff": shift.NextHandoff.Format(time.RFC3339),
+			}
+		}
+		result = append(result, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"schedules": result,
+		"timestamp": now.Format(time.RFC3339),
+	})
+}
+
+// AddOnCallSchedule creates or updates a weekly on-call rotation (requires passkey)
+func (h *HealthHandler) AddOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID           string               `json:"id"`
+		Name         string               `json:"name"`
+		Participants []string             `json:"participants"`
+		HandoffDay   string               `json:"handoff_day"`
+		HandoffTime  string               `json:"handoff_time"`
+		Timezone     string               `json:"timezone"`
+		Start        time.Time            `json:"start"`
+		Filter       structs.Subscription `json:"filter"`
+		Passkey      string               `json:"passkey"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Participants) == 0 {
+		http.Error(w, "At least one participant is required", http.StatusBadRequest)
+		return
+	}
+	for _, id := range req.Participants {
+		if _, err := h.db.GetUser(id); err != nil {
+			http.Error(w, fmt.Sprintf("Unknown participant: %s", id), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if req.Filter.MinPriority != "" && !req.Filter.MinPriority.Valid() {
+		http.Error(w, "Invalid min_priority: must be critical, high, normal or low", http.StatusBadRequest)
+		return
+	}
+
+	// Hand off on Monday mornings in the server's zone unless told otherwise
+	if req.HandoffDay == "" {
+		req.HandoffDay = "monday"
+	}
+	if req.HandoffTime == "" {
+		req.HandoffTime = "09:00"
+	}
+	if req.Timezone == "" {
+		req.Timezone = h.config.Timezone
+	}
+	if _, err := time.Parse("15:04", req.HandoffTime); err != nil {
+		http.Error(w, "Invalid handoff_time: must be HH:MM", http.StatusBadRequest)
+		return
+	}
+	if !validWeekday(req.HandoffDay) {
+		http.Error(w, "Invalid handoff_day: must be a day of the week", http.StatusBadRequest)
+		return
+	}
+	if _, err := utils.LoadTimezone(req.Timezone); err != nil {
+		http.Error(w, "Invalid timezone: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schedule := &structs.OnCallSchedule{
+		ID:           req.ID,
+		Name:         req.Name,
+		Participants: req.Participants,
+		HandoffDay:   req.HandoffDay,
+		HandoffTime:  req.HandoffTime,
+		Timezone:     req.Timezone,
+		Start:        req.Start,
+		Filter:       req.Filter,
+	}
+	if schedule.ID == "" {
+		schedule.ID = utils.GenerateIDWithURL("oncall", req.Name)
+	}
+
+	// The first participant takes the current shift unless a start is given
+	if schedule.Start.IsZero() {
+		schedule.Start = worker.PreviousOnCallHandoff(schedule, time.Now())
+	}
+
+	// Keep the original creation time when updating
+	if existing, err := h.db.GetOnCallSchedule(schedule.ID); err == nil {
+		schedule.CreatedAt = existing.CreatedAt
+	}
+
+	if err := h.db.SaveOnCallSchedule(schedule); err != nil {
+		logger.Errorf("Failed to save on-call schedule: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadOnCall()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"schedule": schedule,
+	})
+}
+
+// DeleteOnCallSchedule removes an on-call schedule (requires passkey)
+func (h *HealthHandler) DeleteOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID      string `json:"id"`
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.ID == "" {
+		http.Error(w, "Schedule ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteOnCallSchedule(req.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadOnCall()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "On-call schedule deleted",
+	})
+}
+
+// validWeekday reports whether name is a weekday such as "monday" or "mon"
+func validWeekday(name string) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if len(name) >= 3 && strings.HasPrefix(full, strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
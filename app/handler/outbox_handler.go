@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// GetOutbox lists queued notifications, filtered by ?status=pending|delivered|dead (requires passkey)
+func (h *HealthHandler) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", structs.OutboxPending, structs.OutboxDelivered, structs.OutboxDead:
+	default:
+		http.Error(w, "Invalid status: must be pending, delivered or dead", http.StatusBadRequest)
+		return
+	}
+
+	messages, err := h.db.GetOutboxMessages(status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if messages == nil {
+		messages = []*structs.OutboxMessage{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"messages": messages,
+		"count":    len(messages),
+	})
+}
+
+// RetryOutboxMessage requeues a dead-lettered notification for POST /api/alerts/outbox/retry?id= (requires passkey)
+func (h *HealthHandler) RetryOutboxMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Message ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.db.GetOutboxMessage(id); err != nil {
+		http.Error(w, "Message not found", http.StatusNotFound)
+		return
+	}
+
+	if err := h.monitor.RetryOutboxMessage(id); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	logger.Infof("Requeued dead-lettered notification %s", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Notification requeued",
+	})
+}
+
+// DeleteOutboxMessage discards a queued notification for POST /api/alerts/outbox/delete?id= (requires passkey)
+func (h *HealthHandler) DeleteOutboxMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Message ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteOutboxMessage(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Notification discarded",
+	})
+}
+
+// GetAlertDeliveries reports every channel's delivery attempts for one alert
+// for GET /api/alerts/{id}/deliveries
+func (h *HealthHandler) GetAlertDeliveries(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/api/alerts/")
+	alertID, suffix, ok := strings.Cut(rest, "/")
+	if !ok || suffix != "deliveries" || alertID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	messages, err := h.db.GetAlertDeliveries(alertID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deliveries := make([]map[string]interface{}, 0, len(messages))
+	for _, message := range messages {
+		if !inScope(projectID, message.ProjectID) {
+			continue
+		}
+
+		retries := message.Attempts - 1
+		if retries < 0 {
+			retries = 0
+		}
+		delivery := map[string]interface{}{
+			"message_id":   message.ID,
+			"channel":      message.Channel,
+			"description":  message.Description,
+			"status":       message.Status,
+			"attempts":     message.Attempts,
+			"retries":      retries,
+			"queued_at":    message.CreatedAt,
+			"delivered_at": message.DeliveredAt,
+			"last_error":   message.LastError,
+			"history":      message.History,
+		}
+		// Webhook URLs embed secrets, so only the receiving host is shown
+		if message.URL != "" {
+			delivery["target"] = redactURL(message.URL)
+		}
+		if len(message.Recipients) > 0 {
+			delivery["target"] = strings.Join(message.Recipients, ", ")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if len(deliveries) == 0 {
+		http.Error(w, "Alert not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"alert_id":   alertID,
+		"deliveries": deliveries,
+	})
+}
+
+// redactURL keeps only the scheme and host of a URL
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(invalid url)"
+	}
+	return u.Scheme + "://" + u.Host
+}
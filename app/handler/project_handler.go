@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// apiKeyHeader carries a project API key
+const apiKeyHeader = "X-API-Key"
+
+// projectScope resolves the request's project from its API key or project-bound token.
+// An empty project ID means the request is not scoped to a project.
+func (h *HealthHandler) projectScope(w http.ResponseWriter, r *http.Request) (string, bool) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		if token, err := h.requestToken(r); err == nil && token != nil {
+			return token.ProjectID, true
+		}
+		if !h.unscopedReadAllowed(r) {
+			http.Error(w, "API key, API token or admin passkey required", http.StatusUnauthorized)
+			return "", false
+		}
+		return "", true
+	}
+
+	project, err := h.db.GetProjectByAPIKey(key)
+	if err != nil {
+		http.Error(w, "Invalid API key", http.StatusUnauthorized)
+		return "", false
+	}
+	return project.ID, true
+}
+
+// unscopedReadAllowed reports whether a read without an API key or token may see every
+// project. Once projects exist and an admin passkey is set, only the admin may; writes
+// check the passkey themselves.
+func (h *HealthHandler) unscopedReadAllowed(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return true
+	}
+	if h.isAdmin(r, "") {
+		return true
+	}
+	projects, err := h.db.GetAllProjects()
+	return err == nil && len(projects) == 0
+}
+
+// inScope reports whether a resource owned by ownerID is visible to projectID
+func inScope(projectID, ownerID string) bool {
+	return projectID == "" || projectID == ownerID
+}
+
+// endpointInScope reports whether an endpoint exists and is visible to projectID
+func (h *HealthHandler) endpointInScope(projectID, id string) bool {
+	if projectID == "" {
+		return true
+	}
+	endpoint, err := h.db.GetEndpoint(id)
+	if err != nil {
+		return false
+	}
+	return endpoint.ProjectID == projectID
+}
+
+// requireEndpointScope resolves the project and rejects endpoints owned by another project
+func (h *HealthHandler) requireEndpointScope(w http.ResponseWriter, r *http.Request, id string) bool {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return false
+	}
+	if !h.endpointInScope(projectID, id) {
+		http.Error(w, "Endpoint not found", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// isAdmin checks for an admin-scoped token or the admin passkey from the
+// X-Admin-Passkey header or a request field
+func (h *HealthHandler) isAdmin(r *http.Request, passkey string) bool {
+	if token, err := h.requestToken(r); err == nil && token != nil && !token.Expired(time.Now()) {
+		return token.HasScope(structs.ScopeAdmin) && token.ProjectID == ""
+	}
+	if passkey == "" {
+		passkey = r.Header.Get("X-Admin-Passkey")
+	}
+	return h.config.AdminPasskey == "" || passkey == h.config.AdminPasskey
+}
+
+// GetProjects returns all projects (requires passkey)
+func (h *HealthHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	projects, err := h.db.GetAllProjects()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"projects":  projects,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// AddProject creates a project and returns its API key once (requires passkey)
+func (h *HealthHandler) AddProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID       string            `json:"id"`
+		Name     string            `json:"name"`
+		Passkey  string            `json:"passkey"`
+		Alerting *structs.Alerting `json:"alerting"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Alerting != nil {
+		if err := validateAlertLanguages(req.Alerting); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	project := &structs.Project{
+		ID:       req.ID,
+		Name:     req.Name,
+		Alerting: req.Alerting,
+	}
+	if project.ID == "" {
+		project.ID = utils.GenerateIDWithURL("project", req.Name)
+	}
+
+	if _, err := h.db.GetProject(project.ID); err == nil {
+		http.Error(w, "Project with this ID already exists", http.StatusConflict)
+		return
+	}
+
+	apiKey, err := utils.GenerateAPIKey("sw_")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	project.APIKeyHash = utils.HashAPIKey(apiKey)
+
+	if err := h.db.SaveProject(project); err != nil {
+		logger.Errorf("Failed to save project: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadProjects()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"project": project,
+		"api_key": apiKey,
+	})
+}
+
+// validateAlertLanguages checks a project's alert languages have message catalogs and its
+// webhook version and channel severities are known
+func validateAlertLanguages(alerting *structs.Alerting) error {
+	if err := utils.ValidateLanguage(alerting.Language); err != nil {
+		return err
+	}
+	for channel, lang := range alerting.ChannelLanguages {
+		if err := utils.ValidateLanguage(lang); err != nil {
+			return fmt.Errorf("channel %s: %w", channel, err)
+		}
+	}
+	if !structs.ValidWebhookVersion(alerting.WebhookVersion) {
+		return fmt.Errorf("invalid webhook_version %d: must be 1 or 2", alerting.WebhookVersion)
+	}
+	return alerting.ValidateSeverities()
+}
+
+// DeleteProject removes a project that no longer owns any endpoints (requires passkey)
+func (h *HealthHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID      string `json:"id"`
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if !h.requireAdminTOTP(w, r) {
+		return
+	}
+
+	if req.ID == "" {
+		http.Error(w, "Project ID is required", http.StatusBadRequest)
+		return
+	}
+
+	endpoints, err := h.db.GetProjectEndpoints(req.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(endpoints) > 0 {
+		http.Error(w, "Project still has endpoints", http.StatusConflict)
+		return
+	}
+
+	if err := h.db.DeleteProject(req.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadProjects()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Project deleted",
+	})
+}
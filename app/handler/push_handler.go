@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// GetVAPIDKey returns the public key browsers need to create a push subscription
+func (h *HealthHandler) GetVAPIDKey(w http.ResponseWriter, r *http.Request) {
+	key := h.monitor.WebPushPublicKey()
+	if key == "" {
+		http.Error(w, "Web Push is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"public_key": key,
+		"enabled":    h.config.Alerting.WebPushEnabled,
+	})
+}
+
+// pushServiceHosts are the browser vendors' push services; a subscription endpoint must be
+// on one of them or a subdomain, so alerts cannot be sent to arbitrary hosts
+var pushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"push.services.mozilla.com",
+	"notify.windows.com",
+	"push.apple.com",
+}
+
+// validPushEndpoint reports whether endpoint is an https URL on a known push service
+func validPushEndpoint(endpoint string) bool {
+	target, err := url.Parse(endpoint)
+	if err != nil || target.Scheme != "https" || target.User != nil || target.Port() != "" {
+		return false
+	}
+	host := strings.ToLower(target.Hostname())
+	for _, service := range pushServiceHosts {
+		if host == service || strings.HasSuffix(host, "."+service) {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscribePush stores a browser push subscription
+func (h *HealthHandler) SubscribePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	// expirationTime is part of the browser's PushSubscription JSON but is not used
+	var req struct {
+		Endpoint       string               `json:"endpoint"`
+		ExpirationTime *float64             `json:"expirationTime"`
+		Keys           structs.PushKeys     `json:"keys"`
+		Filter         structs.Subscription `json:"filter"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !validPushEndpoint(req.Endpoint) {
+		http.Error(w, "Endpoint must be an https URL on a known browser push service", http.StatusBadRequest)
+		return
+	}
+	target, _ := url.Parse(req.Endpoint)
+
+	if req.Keys.P256dh == "" || req.Keys.Auth == "" {
+		http.Error(w, "Subscription keys p256dh and auth are required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Filter.MinPriority != "" && !req.Filter.MinPriority.Valid() {
+		http.Error(w, "Invalid min_priority: must be critical, high, normal or low", http.StatusBadRequest)
+		return
+	}
+
+	// Project-scoped callers only receive pushes for their own project
+	if projectID != "" {
+		req.Filter.Projects = []string{projectID}
+	}
+
+	sub := &structs.PushSubscription{
+		ID:       utils.HashAPIKey(req.Endpoint)[:16],
+		Endpoint: req.Endpoint,
+		Keys:     req.Keys,
+		Filter:   req.Filter,
+	}
+
+	if err := h.db.SavePushSubscription(sub); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infof("Push subscription registered: %s (%s)", sub.ID, target.Host)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"id":        sub.ID,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// UnsubscribePush removes a browser push subscription
+func (h *HealthHandler) UnsubscribePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Endpoint string `json:"endpoint"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Endpoint == "" {
+		http.Error(w, "Endpoint is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeletePushSubscription(utils.HashAPIKey(req.Endpoint)[:16]); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReloadEndpoints merges endpoint changes from the database into the running monitor for
+// POST /api/admin/reload (requires passkey); statuses and check times are kept
+func (h *HealthHandler) ReloadEndpoints(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	result, err := h.monitor.ReloadEndpoints()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"added":   result.Added,
+		"updated": result.Updated,
+		"removed": result.Removed,
+		"total":   result.Total,
+	})
+}
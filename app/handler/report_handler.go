@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/reports"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// GetReports lists stored availability reports for GET /api/reports, or returns one
+// for GET /api/reports?id=&format=json|html|pdf
+func (h *HealthHandler) GetReports(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		all, err := h.db.GetAllReports()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The list carries the summary only; endpoints are in the full report
+		summaries := []map[string]interface{}{}
+		for _, report := range all {
+			if !reportInScope(projectID, report) {
+				continue
+			}
+			summaries = append(summaries, map[string]interface{}{
+				"id":           report.ID,
+				"name":         report.Name,
+				"month":        report.Month,
+				"filter":       report.Filter,
+				"generated_at": report.GeneratedAt,
+				"availability": report.Availability,
+				"sla_breaches": report.SLABreaches,
+				"endpoints":    len(report.Endpoints),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"reports": summaries,
+			"count":   len(summaries),
+		})
+		return
+	}
+
+	report, err := h.db.GetReport(id)
+	if err != nil || !reportInScope(projectID, report) {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+	case "html":
+		var buf bytes.Buffer
+		if err := reports.WriteHTML(&buf, report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(buf.Bytes())
+	case "pdf":
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+report.ID+`.pdf"`)
+		w.Write(reports.RenderPDF(report))
+	default:
+		http.Error(w, "Invalid format: must be json, html or pdf", http.StatusBadRequest)
+	}
+}
+
+// GenerateReport builds and stores a report for POST /api/reports/generate, either a configured
+// report by name or an ad-hoc one for the given tags and projects; month defaults to last month
+func (h *HealthHandler) GenerateReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	if projectID != "" {
+		http.Error(w, "Reports can only be generated with global access", http.StatusForbidden)
+		return
+	}
+
+	var req struct {
+		Name     string   `json:"name"`
+		Month    string   `json:"month"`
+		Tags     []string `json:"tags"`
+		Projects []string `json:"projects"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Month == "" {
+		req.Month = time.Now().UTC().AddDate(0, -1, 0).Format("2006-01")
+	}
+
+	filter := structs.Subscription{Tags: req.Tags, Projects: req.Projects}
+	if config, found := h.monitor.ReportConfig(req.Name); found {
+		if len(req.Tags) > 0 || len(req.Projects) > 0 {
+			http.Error(w, "Configured report "+req.Name+" has its own filter", http.StatusBadRequest)
+			return
+		}
+		filter = config.Filter
+	}
+
+	report, err := h.monitor.GenerateReport(req.Name, req.Month, filter)
+	if err != nil {
+		logger.Errorf("Failed to generate report %s: %v", req.Name, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"report":  report,
+	})
+}
+
+// DeleteReport removes a stored report for DELETE /api/reports/delete?id= (requires passkey)
+func (h *HealthHandler) DeleteReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Report ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.db.GetReport(id); err != nil {
+		http.Error(w, "Report not found", http.StatusNotFound)
+		return
+	}
+
+	if err := h.db.DeleteReport(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infof("Deleted report %s", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Report deleted",
+	})
+}
+
+// reportInScope reports whether a project-scoped caller may read a report:
+// only reports filtered to exactly their project are visible
+func reportInScope(projectID string, report *structs.Report) bool {
+	if projectID == "" {
+		return true
+	}
+	return len(report.Filter.Tags) == 0 && len(report.Filter.Projects) == 1 && report.Filter.Projects[0] == projectID
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/worker"
+)
+
+// GetScheduler lists every endpoint's next check, last check duration and place in the
+// dispatch queue, so operators can see whether intervals are honored. ?overdue=true
+// returns only endpoints whose check is late.
+func (h *HealthHandler) GetScheduler(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	overdueOnly := r.URL.Query().Get("overdue") == "true"
+
+	now := time.Now()
+	entries := []worker.SchedulerEntry{}
+	overdue := 0
+	for _, entry := range h.monitor.Scheduler(now) {
+		if !inScope(projectID, entry.ProjectID) {
+			continue
+		}
+		if entry.Overdue {
+			overdue++
+		} else if overdueOnly {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"endpoints":             entries,
+		"count":                 len(entries),
+		"overdue":               overdue,
+		"active":                h.monitor.IsActive(),
+		"max_checks_per_second": h.config.MaxChecksPerSecond,
+		"watchdog":              h.monitor.GetWatchdogStats(),
+		"timestamp":             now.Format(time.RFC3339),
+	})
+}
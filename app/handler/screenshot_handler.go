@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// GetScreenshot serves a browser check screenshot for GET /api/screenshot?id=&at=, where at is
+// the timestamp of a history record with "screenshot": true; without at the newest one is served
+func (h *HealthHandler) GetScreenshot(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Endpoint ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !h.requireEndpointScope(w, r, id) {
+		return
+	}
+
+	var image []byte
+	var taken time.Time
+	var err error
+	if at := r.URL.Query().Get("at"); at != "" {
+		taken, err = time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			http.Error(w, "Invalid at: must be an RFC 3339 timestamp", http.StatusBadRequest)
+			return
+		}
+		image, err = h.db.GetScreenshot(id, taken)
+	} else {
+		image, taken, err = h.db.GetLatestScreenshot(id)
+	}
+	if err != nil {
+		http.Error(w, "Screenshot not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Last-Modified", taken.UTC().Format(http.TimeFormat))
+	w.Write(image)
+}
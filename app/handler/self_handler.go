@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetSelfStatus reports SiteWatch's own health: database writes, alert delivery and scheduling
+func (h *HealthHandler) GetSelfStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.monitor.SelfStatus())
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// GetServices returns all services with their rolled-up status
+func (h *HealthHandler) GetServices(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	all, err := h.monitor.GetServiceStatuses()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	statuses := []structs.ServiceStatus{}
+	for _, status := range all {
+		if inScope(projectID, status.Service.ProjectID) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"services":  statuses,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// AddService creates or updates a service composed of existing endpoints
+func (h *HealthHandler) AddService(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID               string             `json:"id"`
+		Name             string             `json:"name"`
+		EndpointIDs      []string           `json:"endpoint_ids"`
+		Policy           string             `json:"policy"`
+		Quorum           int                `json:"quorum"`
+		Weights          map[string]float64 `json:"weights"`
+		WeightThreshold  float64            `json:"weight_threshold"`
+		AlertsSuppressed bool               `json:"alerts_suppressed"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	if req.Name == "" || len(req.EndpointIDs) == 0 {
+		http.Error(w, "Name and endpoint_ids are required", http.StatusBadRequest)
+		return
+	}
+
+	switch req.Policy {
+	case "", structs.PolicyAllHealthy, structs.PolicyQuorum, structs.PolicyWeighted:
+	default:
+		http.Error(w, "Invalid policy: must be all_healthy, quorum or weighted", http.StatusBadRequest)
+		return
+	}
+
+	for _, id := range req.EndpointIDs {
+		if endpoint, err := h.db.GetEndpoint(id); err != nil || !inScope(projectID, endpoint.ProjectID) {
+			http.Error(w, "Unknown endpoint: "+id, http.StatusBadRequest)
+			return
+		}
+	}
+
+	service := &structs.Service{
+		ID:               req.ID,
+		Name:             req.Name,
+		EndpointIDs:      req.EndpointIDs,
+		Policy:           req.Policy,
+		Quorum:           req.Quorum,
+		Weights:          req.Weights,
+		WeightThreshold:  req.WeightThreshold,
+		AlertsSuppressed: req.AlertsSuppressed,
+		ProjectID:        projectID,
+	}
+	if service.ID == "" {
+		service.ID = utils.GenerateIDWithURL("service", req.Name)
+		if projectID != "" {
+			service.ID = projectID + "-" + service.ID
+		}
+	}
+
+	// Keep the original creation time and owner when updating
+	if existing, err := h.db.GetService(service.ID); err == nil {
+		if !inScope(projectID, existing.ProjectID) {
+			http.Error(w, "Service with this ID already exists", http.StatusConflict)
+			return
+		}
+		service.CreatedAt = existing.CreatedAt
+		service.ProjectID = existing.ProjectID
+	}
+
+	if err := h.db.SaveService(service); err != nil {
+		logger.Errorf("Failed to save service: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"service": service,
+	})
+}
+
+// DeleteService removes a service
+func (h *HealthHandler) DeleteService(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		var req struct {
+			ID string `json:"id"`
+		}
+		if !decodeOptionalJSON(w, r, &req) {
+			return
+		}
+		id = req.ID
+	}
+
+	if id == "" {
+		http.Error(w, "Service ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if !h.requireTOTP(w, r) {
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	if existing, err := h.db.GetService(id); err == nil && !inScope(projectID, existing.ProjectID) {
+		http.Error(w, "Service not found", http.StatusNotFound)
+		return
+	}
+
+	if err := h.db.DeleteService(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Service deleted",
+	})
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// silenceMatches reports whether an endpoint carries every tag and label of a silence
+func silenceMatches(endpoint structs.Endpoint, tags []string, labels map[string]string) bool {
+	for _, tag := range tags {
+		if !endpoint.HasTag(tag) {
+			return false
+		}
+	}
+	for key, value := range labels {
+		if endpoint.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// SilenceAlerts snoozes the alerts of every endpoint matching all of the given tags and
+// labels, for planned maintenance that spans many endpoints. Endpoints already suppressed
+// for longer are left alone.
+func (h *HealthHandler) SilenceAlerts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.requireTOTP(w, r) {
+		return
+	}
+
+	var req struct {
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Duration string            `json:"duration"`
+		Until    string            `json:"until"`
+		Comment  string            `json:"comment"`
+		DryRun   bool              `json:"dry_run"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if len(req.Tags) == 0 && len(req.Labels) == 0 {
+		http.Error(w, "At least one tag or label matcher is required", http.StatusBadRequest)
+		return
+	}
+
+	end, err := utils.ParseSnooze(req.Duration, req.Until, time.Now(), h.requestLocation(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	silenced, skipped := []string{}, []string{}
+	for id, state := range h.monitor.GetStatus() {
+		if !inScope(projectID, state.Endpoint.ProjectID) || !silenceMatches(state.Endpoint, req.Tags, req.Labels) {
+			continue
+		}
+		// A permanent suppression or a longer snooze must not be cut short by this one
+		if state.AlertsSuppressed && (state.SuppressedUntil.IsZero() || state.SuppressedUntil.After(end)) {
+			skipped = append(skipped, id)
+			continue
+		}
+		silenced = append(silenced, id)
+	}
+	sort.Strings(silenced)
+	sort.Strings(skipped)
+
+	if len(silenced) == 0 && len(skipped) == 0 {
+		http.Error(w, "No endpoints match the silence", http.StatusNotFound)
+		return
+	}
+
+	if !req.DryRun {
+		for _, id := range silenced {
+			if err := h.monitor.SnoozeAlerts(id, end); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		logger.Infof("Silenced alerts for %d endpoints until %s (tags %v, labels %v): %s",
+			len(silenced), end.Format(time.RFC3339), req.Tags, req.Labels, req.Comment)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":          true,
+		"dry_run":          req.DryRun,
+		"suppressed_until": end.Format(time.RFC3339),
+		"silenced":         silenced,
+		"skipped":          skipped,
+		"count":            len(silenced),
+	})
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// icsTimeFormat is the UTC date-time format used in iCalendar files
+const icsTimeFormat = "20060102T150405Z"
+
+// icsEscape escapes text values for iCalendar content lines
+func icsEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(s)
+}
+
+// GetSSLCalendar returns an iCalendar feed with an event for each known certificate expiry.
+// Reminder lead times (in days) come from ssl_calendar_reminders or the ?reminders=30,7,1 query.
+func (h *HealthHandler) GetSSLCalendar(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+
+	reminders := h.config.SSLCalendarReminders
+	if param := r.URL.Query().Get("reminders"); param != "" {
+		reminders = nil
+		for _, part := range strings.Split(param, ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || days < 0 {
+				http.Error(w, "Invalid reminders: must be a comma-separated list of days", http.StatusBadRequest)
+				return
+			}
+			reminders = append(reminders, days)
+		}
+	}
+
+	states := h.monitor.GetStatus()
+
+	var withExpiry []*structs.EndpointState
+	for _, state := range states {
+		if !state.SSLCertExpiry.IsZero() && !state.SSLPaused && inScope(projectID, state.Endpoint.ProjectID) {
+			withExpiry = append(withExpiry, state)
+		}
+	}
+	sort.Slice(withExpiry, func(i, j int) bool {
+		return withExpiry[i].SSLCertExpiry.Before(withExpiry[j].SSLCertExpiry)
+	})
+
+	now := time.Now().UTC().Format(icsTimeFormat)
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//SiteWatch//SSL Expiry//EN\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+	b.WriteString("X-WR-CALNAME:SSL Certificate Expiry\r\n")
+
+	for _, state := range withExpiry {
+		expiry := state.SSLCertExpiry.UTC()
+
+		b.WriteString("BEGIN:VEVENT\r\n")
+		b.WriteString(fmt.Sprintf("UID:%s-%d@sitewatch\r\n", icsEscape(state.ID), expiry.Unix()))
+		b.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", now))
+		b.WriteString(fmt.Sprintf("DTSTART:%s\r\n", expiry.Format(icsTimeFormat)))
+		b.WriteString(fmt.Sprintf("DTEND:%s\r\n", expiry.Add(time.Hour).Format(icsTimeFormat)))
+		b.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", icsEscape("SSL certificate expires: "+state.Endpoint.Name)))
+		b.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", icsEscape("Renew the certificate for "+state.Endpoint.URL)))
+		b.WriteString(fmt.Sprintf("URL:%s\r\n", state.Endpoint.URL))
+
+		for _, days := range reminders {
+			b.WriteString("BEGIN:VALARM\r\n")
+			b.WriteString("ACTION:DISPLAY\r\n")
+			b.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", icsEscape(fmt.Sprintf("%s certificate expires in %d days", state.Endpoint.Name, days))))
+			b.WriteString(fmt.Sprintf("TRIGGER:-P%dD\r\n", days))
+			b.WriteString("END:VALARM\r\n")
+		}
+
+		b.WriteString("END:VEVENT\r\n")
+	}
+
+	b.WriteString("END:VCALENDAR\r\n")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="ssl-expiry.ics"`)
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// summaryDefaultLimit is how many slowest endpoints and certificates a summary lists by default
+	summaryDefaultLimit = 5
+	// summaryMaxLimit caps ?worst= and ?certs=
+	summaryMaxLimit = 50
+)
+
+// summaryLatency is an endpoint in a summary's slowest list
+type summaryLatency struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Status         string  `json:"status"`
+	ResponseTimeMs float64 `json:"response_time_ms"`
+}
+
+// summaryIncident is an endpoint that is down now
+type summaryIncident struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Priority     string    `json:"priority"`
+	Since        time.Time `json:"since"`
+	Error        string    `json:"error"`
+	Acknowledged bool      `json:"acknowledged"`
+}
+
+// summaryCert is a certificate in a summary's next expiries
+type summaryCert struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	DaysToExpiry int       `json:"days_to_expiry"`
+}
+
+// summaryLimit reads a list size from the query, defaulting and capping it
+func summaryLimit(r *http.Request, name string) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return summaryDefaultLimit, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if n > summaryMaxLimit {
+		n = summaryMaxLimit
+	}
+	return n, true
+}
+
+// GetSummary returns one compact payload for wallboards: counts by status, the slowest
+// endpoints, active incidents and the next certificate expiries. ?worst= and ?certs= size
+// the lists and ?tag= narrows the endpoints. Unchanged polls are answered with 304.
+func (h *HealthHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	worst, ok := summaryLimit(r, "worst")
+	if !ok {
+		http.Error(w, "Invalid worst: must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+	certLimit, ok := summaryLimit(r, "certs")
+	if !ok {
+		http.Error(w, "Invalid certs: must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	etag := h.statusETag(projectID, r)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	tagFilter := r.URL.Query().Get("tag")
+	loc := h.requestLocation(r)
+
+	counts := map[string]int{
+		"total":          0,
+		"up":             0,
+		"down":           0,
+		"degraded":       0,
+		"unknown":        0,
+		"in_maintenance": 0,
+	}
+	latencies := []summaryLatency{}
+	incidents := []summaryIncident{}
+	certs := []summaryCert{}
+
+	for _, state := range h.monitor.GetStatus() {
+		if !state.Enabled || !inScope(projectID, state.Endpoint.ProjectID) {
+			continue
+		}
+		if tagFilter != "" && !state.Endpoint.HasTag(tagFilter) {
+			continue
+		}
+
+		counts["total"]++
+		counts[statusCategory(state)]++
+		if state.AlertsSuppressed {
+			counts["in_maintenance"]++
+		}
+
+		if state.ChecksHealth() && !state.LastCheck.IsZero() {
+			latencies = append(latencies, summaryLatency{
+				ID:             state.ID,
+				Name:           state.Endpoint.Name,
+				Status:         statusCategory(state),
+				ResponseTimeMs: float64(state.ResponseTime.Microseconds()) / 1000.0,
+			})
+		}
+		if state.Status == structs.StatusUnhealthy {
+			incidents = append(incidents, summaryIncident{
+				ID:           state.ID,
+				Name:         state.Endpoint.Name,
+				Priority:     string(state.Endpoint.Priority),
+				Since:        state.LastStatusChange.In(loc),
+				Error:        state.LastError,
+				Acknowledged: state.Acknowledged,
+			})
+		}
+		if !state.SSLCertExpiry.IsZero() && !state.SSLPaused {
+			certs = append(certs, summaryCert{
+				ID:           state.ID,
+				Name:         state.Endpoint.Name,
+				ExpiresAt:    state.SSLCertExpiry.In(loc),
+				DaysToExpiry: state.DaysToExpiry,
+			})
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i].ResponseTimeMs > latencies[j].ResponseTimeMs })
+	if len(latencies) > worst {
+		latencies = latencies[:worst]
+	}
+	// Longest-running incidents first, they are the ones nobody has picked up
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].Since.Before(incidents[j].Since) })
+	sort.Slice(certs, func(i, j int) bool { return certs[i].ExpiresAt.Before(certs[j].ExpiresAt) })
+	if len(certs) > certLimit {
+		certs = certs[:certLimit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"counts":       counts,
+		"slowest":      latencies,
+		"incidents":    incidents,
+		"certificates": certs,
+		"timestamp":    time.Now().In(loc).Format(time.RFC3339),
+	})
+}
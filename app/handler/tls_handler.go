@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/worker"
+)
+
+// tlsEntry is an endpoint's negotiated TLS protocol, as reported for compliance
+type tlsEntry struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	Version      string    `json:"tls_version"`
+	Cipher       string    `json:"tls_cipher"`
+	LegacyTLS    string    `json:"legacy_tls_accepted"`
+	LastSSLCheck time.Time `json:"last_ssl_check"`
+}
+
+// GetTLSReport lists the TLS version and cipher suite negotiated with every HTTPS endpoint
+// and whether it still accepts TLS 1.0 or 1.1. ?legacy=true lists only those that do.
+func (h *HealthHandler) GetTLSReport(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	legacyOnly := r.URL.Query().Get("legacy") == "true"
+	loc := h.requestLocation(r)
+
+	entries := []tlsEntry{}
+	legacy := 0
+	for _, state := range h.monitor.GetStatus() {
+		if !inScope(projectID, state.Endpoint.ProjectID) || state.TLSVersion == 0 {
+			continue
+		}
+		if state.TLSLegacy != 0 {
+			legacy++
+		} else if legacyOnly {
+			continue
+		}
+		entries = append(entries, tlsEntry{
+			ID:           state.ID,
+			Name:         state.Endpoint.Name,
+			URL:          state.Endpoint.URL,
+			Version:      worker.TLSVersionName(state.TLSVersion),
+			Cipher:       worker.TLSCipherName(state.TLSCipher),
+			LegacyTLS:    worker.TLSVersionName(state.TLSLegacy),
+			LastSSLCheck: state.LastSSLCheck.In(loc),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"endpoints":    entries,
+		"legacy_count": legacy,
+		"timestamp":    time.Now().In(loc).Format(time.RFC3339),
+	})
+}
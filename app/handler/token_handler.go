@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// bearerToken extracts the token from an "Authorization: Bearer" header
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
+
+// requestToken returns the request's API token, or nil if none was sent
+func (h *HealthHandler) requestToken(r *http.Request) (*structs.APIToken, error) {
+	value := bearerToken(r)
+	if value == "" {
+		return nil, nil
+	}
+	return h.db.GetAPITokenByValue(value)
+}
+
+// RequireScope rejects requests whose bearer token is invalid, expired or lacks the scope.
+// Requests without a token keep the existing passkey/API key checks.
+func (h *HealthHandler) RequireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, err := h.requestToken(r)
+		if err != nil {
+			http.Error(w, "Invalid API token", http.StatusUnauthorized)
+			return
+		}
+		if token != nil {
+			if token.Expired(time.Now()) {
+				http.Error(w, "API token expired", http.StatusUnauthorized)
+				return
+			}
+			if !token.HasScope(scope) {
+				http.Error(w, "API token lacks scope "+scope, http.StatusForbidden)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// validScope reports whether a scope name is known
+func validScope(scope string) bool {
+	switch scope {
+	case structs.ScopeReadStatus, structs.ScopeWriteEndpoints, structs.ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// GetTokens lists API tokens without their secrets (requires passkey)
+func (h *HealthHandler) GetTokens(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := h.db.GetAllAPITokens()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"tokens":    tokens,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// AddToken creates a scoped API token and returns it once (requires passkey)
+func (h *HealthHandler) AddToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Name      string   `json:"name"`
+		Scopes    []string `json:"scopes"`
+		ExpiresIn string   `json:"expires_in"`
+		ProjectID string   `json:"project_id"`
+		Passkey   string   `json:"passkey"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.Name == "" || len(req.Scopes) == 0 {
+		http.Error(w, "Name and scopes are required", http.StatusBadRequest)
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !validScope(scope) {
+			http.Error(w, "Invalid scope: must be read:status, write:endpoints or admin", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if req.ProjectID != "" {
+		if _, err := h.db.GetProject(req.ProjectID); err != nil {
+			http.Error(w, "Unknown project: "+req.ProjectID, http.StatusBadRequest)
+			return
+		}
+	}
+
+	token := &structs.APIToken{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ProjectID: req.ProjectID,
+	}
+
+	if req.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || expiresIn <= 0 {
+			http.Error(w, "Invalid expires_in format", http.StatusBadRequest)
+			return
+		}
+		expiresAt := time.Now().Add(expiresIn)
+		token.ExpiresAt = &expiresAt
+	}
+
+	value, err := utils.GenerateAPIKey("swt_")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token.TokenHash = utils.HashAPIKey(value)
+	// The hash prefix identifies the token without revealing it
+	token.ID = token.TokenHash[:16]
+
+	if err := h.db.SaveAPIToken(token); err != nil {
+		logger.Errorf("Failed to save API token: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"token":   token,
+		"value":   value,
+	})
+}
+
+// DeleteToken revokes an API token (requires passkey)
+func (h *HealthHandler) DeleteToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID      string `json:"id"`
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.ID == "" {
+		http.Error(w, "Token ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteAPIToken(req.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Token revoked",
+	})
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// totpHeader carries the admin's current TOTP code for destructive operations
+const totpHeader = "X-TOTP-Code"
+
+// totpSettings loads the admin TOTP enrollment
+func (h *HealthHandler) totpSettings() (*structs.TOTPSettings, error) {
+	var settings structs.TOTPSettings
+	if _, err := h.db.GetSetting(models.TOTPSettingKey, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// requireTOTP enforces a valid TOTP code on admin requests once TOTP is enabled.
+// Requests carrying a valid project API key are authorized by the key instead.
+func (h *HealthHandler) requireTOTP(w http.ResponseWriter, r *http.Request) bool {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		if _, err := h.db.GetProjectByAPIKey(key); err == nil {
+			return true
+		}
+	}
+	return h.requireAdminTOTP(w, r)
+}
+
+// requireAdminTOTP enforces a valid TOTP code once TOTP is enabled, with no API key
+// exemption, for admin-only operations
+func (h *HealthHandler) requireAdminTOTP(w http.ResponseWriter, r *http.Request) bool {
+	settings, err := h.totpSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if !settings.Enabled {
+		return true
+	}
+
+	code := r.Header.Get(totpHeader)
+	if code == "" {
+		http.Error(w, "TOTP code required", http.StatusUnauthorized)
+		return false
+	}
+	if !utils.ValidateTOTP(settings.Secret, code, time.Now()) {
+		http.Error(w, "Invalid TOTP code", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// EnrollTOTP generates a new TOTP secret awaiting confirmation (requires passkey)
+func (h *HealthHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	// Re-enrolling replaces the secret, so it needs the current code
+	if !h.requireAdminTOTP(w, r) {
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	settings := &structs.TOTPSettings{Secret: secret}
+	if err := h.db.SaveSetting(models.TOTPSettingKey, settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"secret":      secret,
+		"otpauth_uri": utils.TOTPURI("SiteWatch", "admin", secret),
+		"message":     "Confirm enrollment with a code from your authenticator app",
+	})
+}
+
+// ConfirmTOTP enables TOTP enforcement after verifying a code for the pending secret (requires passkey)
+func (h *HealthHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Passkey string `json:"passkey"`
+		Code    string `json:"code"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.totpSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if settings.Secret == "" {
+		http.Error(w, "TOTP enrollment has not been started", http.StatusBadRequest)
+		return
+	}
+	if settings.Enabled {
+		http.Error(w, "TOTP is already enabled", http.StatusConflict)
+		return
+	}
+
+	if !utils.ValidateTOTP(settings.Secret, req.Code, time.Now()) {
+		http.Error(w, "Invalid TOTP code", http.StatusUnauthorized)
+		return
+	}
+
+	settings.Enabled = true
+	settings.EnrolledAt = time.Now()
+	if err := h.db.SaveSetting(models.TOTPSettingKey, settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infof("Admin TOTP enabled")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "TOTP enabled",
+	})
+}
+
+// DisableTOTP removes the TOTP enrollment (requires passkey and current code)
+func (h *HealthHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if !h.requireAdminTOTP(w, r) {
+		return
+	}
+
+	if err := h.db.DeleteSetting(models.TOTPSettingKey); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Infof("Admin TOTP disabled")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "TOTP disabled",
+	})
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// defaultUptimeWindows are the windows reported when ?window= is not given
+var defaultUptimeWindows = []string{"24h", "7d", "30d"}
+
+// uptimeWindow is an endpoint's uptime over one window, judged against its SLA target
+type uptimeWindow struct {
+	models.Uptime
+	SLAMet *bool `json:"sla_met,omitempty"`
+}
+
+// uptimeEntry is an endpoint's uptime over every requested window
+type uptimeEntry struct {
+	ID        string                  `json:"id"`
+	Name      string                  `json:"name"`
+	ProjectID string                  `json:"project_id,omitempty"`
+	SLATarget float64                 `json:"sla_target,omitempty"`
+	Windows   map[string]uptimeWindow `json:"windows"`
+}
+
+// GetUptime reports uptime percentage, downtime, incidents and MTTR per endpoint over
+// 24h, 7d and 30d, or the windows in ?window= (comma-separated, e.g. 1h,90d). ?id= reports
+// one endpoint and ?tag= narrows the list.
+func (h *HealthHandler) GetUptime(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := h.projectScope(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+
+	names := defaultUptimeWindows
+	if v := query.Get("window"); v != "" {
+		names = strings.Split(v, ",")
+	}
+	windows := make(map[string]time.Duration, len(names))
+	for _, name := range names {
+		d, ok := parseChartRange(name)
+		if !ok || d > models.DailyStatsRetentionDays*24*time.Hour {
+			http.Error(w, "Invalid window: use a duration such as 24h or up to 400 days such as 30d", http.StatusBadRequest)
+			return
+		}
+		windows[name] = d
+	}
+
+	var states []*structs.EndpointState
+	if id := query.Get("id"); id != "" {
+		if !h.requireEndpointScope(w, r, id) {
+			return
+		}
+		state, ok := h.monitor.GetEndpointState(id)
+		if !ok {
+			http.Error(w, "Endpoint not found", http.StatusNotFound)
+			return
+		}
+		states = append(states, state)
+	} else {
+		tagFilter := query.Get("tag")
+		for _, state := range h.monitor.GetStatus() {
+			if !inScope(projectID, state.Endpoint.ProjectID) {
+				continue
+			}
+			if tagFilter != "" && !state.Endpoint.HasTag(tagFilter) {
+				continue
+			}
+			states = append(states, state)
+		}
+	}
+
+	now := time.Now()
+	loc := h.requestLocation(r)
+	entries := make([]uptimeEntry, 0, len(states))
+	for _, state := range states {
+		entry := uptimeEntry{
+			ID:        state.ID,
+			Name:      state.Endpoint.Name,
+			ProjectID: state.Endpoint.ProjectID,
+			SLATarget: state.Endpoint.SLATarget,
+			Windows:   make(map[string]uptimeWindow, len(windows)),
+		}
+		for name, window := range windows {
+			uptime, err := h.db.GetUptime(state.ID, window, now)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			uptime.From, uptime.To = uptime.From.In(loc), uptime.To.In(loc)
+			result := uptimeWindow{Uptime: uptime}
+			if entry.SLATarget > 0 && uptime.UptimePercent != nil {
+				met := *uptime.UptimePercent >= entry.SLATarget
+				result.SLAMet = &met
+			}
+			entry.Windows[name] = result
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"endpoints": entries,
+		"count":     len(entries),
+		"timestamp": now.In(loc).Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// GetUsers returns all users with their notification preferences (requires passkey)
+func (h *HealthHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	if !h.isAdmin(r, "") {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	users, err := h.db.GetAllUsers()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"users":     users,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// AddUser creates or updates a user and their subscriptions (requires passkey)
+func (h *HealthHandler) AddUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID             string               `json:"id"`
+		Name           string               `json:"name"`
+		Email          string               `json:"email"`
+		SlackWebhook   string               `json:"slack_webhook"`
+		WebhookURL     string               `json:"webhook_url"`
+		WebhookVersion int                  `json:"webhook_version"`
+		Language       string               `json:"language"`
+		Subscriptions  structs.Subscription `json:"subscriptions"`
+		Disabled       bool                 `json:"disabled"`
+		Passkey        string               `json:"passkey"`
+	}
+
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Email == "" && req.SlackWebhook == "" && req.WebhookURL == "" {
+		http.Error(w, "At least one contact channel (email, slack_webhook, webhook_url) is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Subscriptions.MinPriority != "" && !req.Subscriptions.MinPriority.Valid() {
+		http.Error(w, "Invalid min_priority: must be critical, high, normal or low", http.StatusBadRequest)
+		return
+	}
+
+	if !structs.ValidWebhookVersion(req.WebhookVersion) {
+		http.Error(w, "Invalid webhook_version: must be 1 or 2", http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.ValidateLanguage(req.Language); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user := &structs.User{
+		ID:             req.ID,
+		Name:           req.Name,
+		Email:          req.Email,
+		SlackWebhook:   req.SlackWebhook,
+		WebhookURL:     req.WebhookURL,
+		WebhookVersion: req.WebhookVersion,
+		Language:       req.Language,
+		Subscriptions:  req.Subscriptions,
+		Disabled:       req.Disabled,
+	}
+	if user.ID == "" {
+		user.ID = utils.GenerateIDWithURL("user", req.Name)
+	}
+
+	// Keep the original creation time when updating
+	if existing, err := h.db.GetUser(user.ID); err == nil {
+		user.CreatedAt = existing.CreatedAt
+	}
+
+	if err := h.db.SaveUser(user); err != nil {
+		logger.Errorf("Failed to save user: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadUsers()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"user":    user,
+	})
+}
+
+// DeleteUser removes a user (requires passkey)
+func (h *HealthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID      string `json:"id"`
+		Passkey string `json:"passkey"`
+	}
+	if !decodeOptionalJSON(w, r, &req) {
+		return
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		req.ID = id
+	}
+
+	if !h.isAdmin(r, req.Passkey) {
+		http.Error(w, "Invalid passkey", http.StatusUnauthorized)
+		return
+	}
+
+	if !h.requireAdminTOTP(w, r) {
+		return
+	}
+
+	if req.ID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.db.DeleteUser(req.ID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.monitor.ReloadUsers()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "User deleted",
+	})
+}
@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// Supported export formats
+const (
+	SourceKuma        = "kuma"
+	SourceUptimeRobot = "uptimerobot"
+	SourcePingdom     = "pingdom"
+)
+
+// Result holds the endpoints converted from an export and the monitors that could not be
+type Result struct {
+	Endpoints []*structs.StoredEndpoint `json:"endpoints"`
+	Skipped   []string                  `json:"skipped"`
+	Warnings  []string                  `json:"warnings"`
+}
+
+// skip records a monitor that has no SiteWatch equivalent
+func (r *Result) skip(name, reason string) {
+	r.Skipped = append(r.Skipped, fmt.Sprintf("%s: %s", name, reason))
+}
+
+// warn records a setting that was dropped or approximated while converting a monitor
+func (r *Result) warn(name, message string) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf("%s: %s", name, message))
+}
+
+// Parse converts an export from another monitoring tool into endpoints.
+// IDs and project are left empty for the caller to assign.
+func Parse(source string, data []byte) (*Result, error) {
+	var (
+		result *Result
+		err    error
+	)
+	switch strings.ToLower(source) {
+	case SourceKuma:
+		result, err = parseKuma(data)
+	case SourceUptimeRobot:
+		result, err = parseUptimeRobot(data)
+	case SourcePingdom:
+		result, err = parsePingdom(data)
+	default:
+		return nil, fmt.Errorf("unknown source %q: must be kuma, uptimerobot or pingdom", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpoint := range result.Endpoints {
+		endpoint.MonitorHealth = true
+		endpoint.RateLimitMode = structs.RateLimitDegraded
+	}
+	return result, nil
+}
+
+// flexBool decodes booleans that exports write as true/false, 0/1 or "0"/"1"
+type flexBool bool
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	switch s {
+	case "true", "1":
+		*b = true
+	case "false", "0", "", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// flexInt decodes integers that exports write either as numbers or as strings
+type flexInt int
+
+// UnmarshalJSON implements json.Unmarshaler
+func (n *flexInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	*n = flexInt(f)
+	return nil
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// kumaBackup is the backup JSON exported from Uptime Kuma's settings page
+type kumaBackup struct {
+	MonitorList []kumaMonitor `json:"monitorList"`
+}
+
+type kumaMonitor struct {
+	Name                string    `json:"name"`
+	Type                string    `json:"type"`
+	URL                 string    `json:"url"`
+	Method              string    `json:"method"`
+	Interval            flexInt   `json:"interval"`
+	Timeout             flexInt   `json:"timeout"`
+	MaxRetries          flexInt   `json:"maxretries"`
+	Active              *flexBool `json:"active"`
+	IgnoreTLS           flexBool  `json:"ignoreTls"`
+	AcceptedStatusCodes []string  `json:"accepted_statuscodes"`
+	Headers             *string   `json:"headers"`
+	Body                *string   `json:"body"`
+	BasicAuthUser       *string   `json:"basic_auth_user"`
+	BasicAuthPass       *string   `json:"basic_auth_pass"`
+	Description         *string   `json:"description"`
+	Tags                []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+}
+
+// parseKuma converts the HTTP monitors of an Uptime Kuma backup
+func parseKuma(data []byte) (*Result, error) {
+	var backup kumaBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("invalid Uptime Kuma backup: %w", err)
+	}
+	if backup.MonitorList == nil {
+		return nil, fmt.Errorf("invalid Uptime Kuma backup: missing monitorList")
+	}
+
+	result := &Result{}
+	for _, monitor := range backup.MonitorList {
+		switch monitor.Type {
+		case "http":
+		case "keyword", "json-query":
+			result.warn(monitor.Name, monitor.Type+" check imported as a plain HTTP check")
+		default:
+			result.skip(monitor.Name, "unsupported monitor type "+monitor.Type)
+			continue
+		}
+		if monitor.URL == "" {
+			result.skip(monitor.Name, "missing URL")
+			continue
+		}
+
+		endpoint := &structs.StoredEndpoint{
+			Name:               monitor.Name,
+			URL:                monitor.URL,
+			Method:             strings.ToUpper(monitor.Method),
+			CheckInterval:      time.Duration(monitor.Interval) * time.Second,
+			Timeout:            time.Duration(monitor.Timeout) * time.Second,
+			FailureThreshold:   int(monitor.MaxRetries) + 1,
+			InsecureSkipVerify: bool(monitor.IgnoreTLS),
+			Enabled:            monitor.Active == nil || bool(*monitor.Active),
+		}
+		if monitor.Description != nil {
+			endpoint.Description = *monitor.Description
+		}
+		for _, tag := range monitor.Tags {
+			endpoint.Tags = append(endpoint.Tags, tag.Name)
+		}
+
+		if len(monitor.AcceptedStatusCodes) > 0 {
+			status, exact := kumaStatus(monitor.AcceptedStatusCodes[0])
+			endpoint.ExpectedStatus = status
+			if !exact || len(monitor.AcceptedStatusCodes) > 1 {
+				result.warn(monitor.Name, fmt.Sprintf("accepted status codes %v narrowed to %d", monitor.AcceptedStatusCodes, status))
+			}
+		}
+
+		if monitor.Headers != nil && strings.TrimSpace(*monitor.Headers) != "" {
+			if err := json.Unmarshal([]byte(*monitor.Headers), &endpoint.Headers); err != nil {
+				result.warn(monitor.Name, "headers dropped: not a JSON object")
+			}
+		}
+		if monitor.BasicAuthUser != nil && *monitor.BasicAuthUser != "" {
+			var password string
+			if monitor.BasicAuthPass != nil {
+				password = *monitor.BasicAuthPass
+			}
+			if endpoint.Headers == nil {
+				endpoint.Headers = make(map[string]string)
+			}
+			endpoint.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(*monitor.BasicAuthUser+":"+password))
+		}
+		if monitor.Body != nil && strings.TrimSpace(*monitor.Body) != "" {
+			result.warn(monitor.Name, "request body dropped")
+		}
+
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+	return result, nil
+}
+
+// kumaStatus picks a single expected status from a Kuma status entry such as "200" or "200-299"
+func kumaStatus(accepted string) (int, bool) {
+	low, _, isRange := strings.Cut(accepted, "-")
+	status, err := strconv.Atoi(strings.TrimSpace(low))
+	if err != nil {
+		return 200, false
+	}
+	return status, !isRange
+}
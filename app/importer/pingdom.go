@@ -0,0 +1,156 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// pingdomResponse is the response of Pingdom's GET /checks (list) or GET /checks/{id} (detail) API
+type pingdomResponse struct {
+	Checks []pingdomCheck `json:"checks"`
+	Check  *pingdomCheck  `json:"check"`
+}
+
+type pingdomCheck struct {
+	Name       string          `json:"name"`
+	Hostname   string          `json:"hostname"`
+	Type       json.RawMessage `json:"type"`
+	Resolution flexInt         `json:"resolution"`
+	Status     string          `json:"status"`
+	Paused     flexBool        `json:"paused"`
+	Encryption *flexBool       `json:"encryption"`
+	Tags       []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+}
+
+// pingdomHTTP holds the type details of an HTTP check in the detail response
+type pingdomHTTP struct {
+	URL            string            `json:"url"`
+	Encryption     flexBool          `json:"encryption"`
+	Port           flexInt           `json:"port"`
+	Username       string            `json:"username"`
+	Password       string            `json:"password"`
+	RequestHeaders map[string]string `json:"requestheaders"`
+	ShouldContain  string            `json:"shouldcontain"`
+	PostData       string            `json:"postdata"`
+}
+
+// parsePingdom converts the HTTP checks of a Pingdom checks response
+func parsePingdom(data []byte) (*Result, error) {
+	var response pingdomResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid Pingdom response: %w", err)
+	}
+	checks := response.Checks
+	if response.Check != nil {
+		checks = append(checks, *response.Check)
+	}
+	if checks == nil {
+		return nil, fmt.Errorf("invalid Pingdom response: missing checks")
+	}
+
+	result := &Result{}
+	for _, check := range checks {
+		kind, details, err := pingdomType(check.Type)
+		if err != nil {
+			result.skip(check.Name, err.Error())
+			continue
+		}
+		if kind != "http" {
+			result.skip(check.Name, "unsupported check type "+kind)
+			continue
+		}
+		if check.Hostname == "" {
+			result.skip(check.Name, "missing hostname")
+			continue
+		}
+
+		endpoint := &structs.StoredEndpoint{
+			Name:          check.Name,
+			CheckInterval: time.Duration(check.Resolution) * time.Minute,
+			Enabled:       check.Status != "paused" && !bool(check.Paused),
+		}
+		for _, tag := range check.Tags {
+			endpoint.Tags = append(endpoint.Tags, tag.Name)
+		}
+
+		// The list response carries no path or scheme, only the detail response does
+		if details == nil {
+			details = &pingdomHTTP{}
+			if check.Encryption != nil {
+				details.Encryption = *check.Encryption
+			} else {
+				result.warn(check.Name, "list response has no path or scheme, imported as http://"+check.Hostname)
+			}
+		}
+		endpoint.URL = pingdomURL(check.Hostname, details)
+		endpoint.Headers = details.RequestHeaders
+		if details.Username != "" {
+			if endpoint.Headers == nil {
+				endpoint.Headers = make(map[string]string)
+			}
+			endpoint.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(details.Username+":"+details.Password))
+		}
+		if details.ShouldContain != "" {
+			result.warn(check.Name, "shouldcontain dropped, imported as a plain HTTP check")
+		}
+		if details.PostData != "" {
+			endpoint.Method = "POST"
+			result.warn(check.Name, "post data dropped")
+		}
+
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+	return result, nil
+}
+
+// pingdomType reads a check type, written as "http" in list responses and
+// as {"http": {...}} in detail responses
+func pingdomType(raw json.RawMessage) (string, *pingdomHTTP, error) {
+	var kind string
+	if err := json.Unmarshal(raw, &kind); err == nil {
+		return kind, nil, nil
+	}
+
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &typed); err != nil || len(typed) != 1 {
+		return "", nil, fmt.Errorf("unrecognised check type")
+	}
+	for kind, body := range typed {
+		if kind != "http" {
+			return kind, nil, nil
+		}
+		details := &pingdomHTTP{}
+		if err := json.Unmarshal(body, details); err != nil {
+			return "", nil, fmt.Errorf("invalid http check details: %v", err)
+		}
+		return kind, details, nil
+	}
+	return "", nil, nil
+}
+
+// pingdomURL builds a full URL from a check's hostname and HTTP details
+func pingdomURL(hostname string, details *pingdomHTTP) string {
+	scheme, defaultPort := "http", 80
+	if details.Encryption {
+		scheme, defaultPort = "https", 443
+	}
+
+	host := hostname
+	if details.Port != 0 && int(details.Port) != defaultPort {
+		host += ":" + strconv.Itoa(int(details.Port))
+	}
+
+	path := details.URL
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return scheme + "://" + host + path
+}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// uptimeRobotResponse is the response of UptimeRobot's getMonitors API
+type uptimeRobotResponse struct {
+	Stat  string `json:"stat"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Monitors []uptimeRobotMonitor `json:"monitors"`
+}
+
+type uptimeRobotMonitor struct {
+	FriendlyName  string            `json:"friendly_name"`
+	URL           string            `json:"url"`
+	Type          flexInt           `json:"type"`
+	Interval      flexInt           `json:"interval"`
+	Timeout       flexInt           `json:"timeout"`
+	Status        flexInt           `json:"status"`
+	HTTPMethod    flexInt           `json:"http_method"`
+	HTTPUsername  string            `json:"http_username"`
+	HTTPPassword  string            `json:"http_password"`
+	CustomHeaders map[string]string `json:"custom_http_headers"`
+}
+
+// UptimeRobot monitor types and the paused status
+const (
+	uptimeRobotHTTP    = 1
+	uptimeRobotKeyword = 2
+	uptimeRobotPaused  = 0
+)
+
+// uptimeRobotMethods maps UptimeRobot's http_method codes to HTTP methods
+var uptimeRobotMethods = map[int]string{
+	1: "HEAD",
+	2: "GET",
+	3: "POST",
+	4: "PUT",
+	5: "PATCH",
+	6: "DELETE",
+	7: "OPTIONS",
+}
+
+// parseUptimeRobot converts the HTTP monitors of an UptimeRobot getMonitors response
+func parseUptimeRobot(data []byte) (*Result, error) {
+	var response uptimeRobotResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("invalid UptimeRobot response: %w", err)
+	}
+	if response.Stat == "fail" {
+		message := "request failed"
+		if response.Error != nil {
+			message = response.Error.Message
+		}
+		return nil, fmt.Errorf("UptimeRobot API error: %s", message)
+	}
+	if response.Monitors == nil {
+		return nil, fmt.Errorf("invalid UptimeRobot response: missing monitors")
+	}
+
+	result := &Result{}
+	for _, monitor := range response.Monitors {
+		switch monitor.Type {
+		case uptimeRobotHTTP:
+		case uptimeRobotKeyword:
+			result.warn(monitor.FriendlyName, "keyword check imported as a plain HTTP check")
+		default:
+			result.skip(monitor.FriendlyName, "unsupported monitor type "+strconv.Itoa(int(monitor.Type)))
+			continue
+		}
+		if monitor.URL == "" {
+			result.skip(monitor.FriendlyName, "missing URL")
+			continue
+		}
+
+		endpoint := &structs.StoredEndpoint{
+			Name:          monitor.FriendlyName,
+			URL:           monitor.URL,
+			Method:        uptimeRobotMethods[int(monitor.HTTPMethod)],
+			CheckInterval: time.Duration(monitor.Interval) * time.Second,
+			Timeout:       time.Duration(monitor.Timeout) * time.Second,
+			Headers:       monitor.CustomHeaders,
+			Enabled:       monitor.Status != uptimeRobotPaused,
+		}
+		if monitor.HTTPUsername != "" {
+			if endpoint.Headers == nil {
+				endpoint.Headers = make(map[string]string)
+			}
+			endpoint.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(monitor.HTTPUsername+":"+monitor.HTTPPassword))
+		}
+
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+	return result, nil
+}
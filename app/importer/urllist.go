@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// ParseURLList converts a pasted list of URLs into endpoints. Each line is a URL, optionally
+// followed by a comma and a name. A first line naming the columns url, name and tags (tags
+// separated by spaces or semicolons) reads the list as CSV with those columns in any order.
+// Endpoints without a name are named after the URL's host and path.
+func ParseURLList(data []byte) (*Result, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.Comment = '#'
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL list: %w", err)
+	}
+
+	columns := map[string]int{"url": 0, "name": 1, "tags": -1}
+	header := make(map[string]int)
+	if len(rows) > 0 {
+		for i, field := range rows[0] {
+			header[strings.ToLower(strings.TrimSpace(field))] = i
+		}
+	}
+	if _, ok := header["url"]; ok {
+		columns = map[string]int{"url": header["url"], "name": -1, "tags": -1}
+		for _, column := range []string{"name", "tags"} {
+			if i, ok := header[column]; ok {
+				columns[column] = i
+			}
+		}
+		rows = rows[1:]
+	}
+
+	field := func(row []string, column string) string {
+		i := columns[column]
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	result := &Result{}
+	for _, row := range rows {
+		rawURL := field(row, "url")
+		if rawURL == "" {
+			continue
+		}
+		name := field(row, "name")
+		if name == "" {
+			name = nameFromURL(rawURL)
+		}
+
+		parsed, err := url.Parse(rawURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			result.skip(name, "invalid URL "+rawURL)
+			continue
+		}
+
+		endpoint := &structs.StoredEndpoint{
+			Name:    name,
+			URL:     rawURL,
+			Enabled: true,
+		}
+		if tags := field(row, "tags"); tags != "" {
+			endpoint.Tags = strings.FieldsFunc(tags, func(r rune) bool { return r == ';' || r == ' ' })
+		}
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+
+	for _, endpoint := range result.Endpoints {
+		endpoint.MonitorHealth = true
+		endpoint.RateLimitMode = structs.RateLimitDegraded
+	}
+	return result, nil
+}
+
+// nameFromURL names an endpoint after its URL's host and path
+func nameFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return strings.TrimSuffix(parsed.Host+parsed.Path, "/")
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveBlackoutCalendar saves or updates a blackout calendar
+func (d *Database) SaveBlackoutCalendar(calendar *structs.BlackoutCalendar) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(BlackoutsBucket))
+
+		now := time.Now()
+		if calendar.CreatedAt.IsZero() {
+			calendar.CreatedAt = now
+		}
+		calendar.UpdatedAt = now
+
+		data, err := json.Marshal(calendar)
+		if err != nil {
+			return fmt.Errorf("failed to marshal blackout calendar: %w", err)
+		}
+
+		return b.Put([]byte(calendar.ID), data)
+	})
+}
+
+// GetBlackoutCalendar retrieves a blackout calendar by ID
+func (d *Database) GetBlackoutCalendar(id string) (*structs.BlackoutCalendar, error) {
+	var calendar structs.BlackoutCalendar
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(BlackoutsBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("blackout calendar not found: %s", id)
+		}
+		return json.Unmarshal(data, &calendar)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// GetAllBlackoutCalendars retrieves all blackout calendars
+func (d *Database) GetAllBlackoutCalendars() ([]*structs.BlackoutCalendar, error) {
+	var calendars []*structs.BlackoutCalendar
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(BlackoutsBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var calendar structs.BlackoutCalendar
+			if err := json.Unmarshal(v, &calendar); err != nil {
+				return err
+			}
+			calendars = append(calendars, &calendar)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// DeleteBlackoutCalendar removes a blackout calendar
+func (d *Database) DeleteBlackoutCalendar(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(BlackoutsBucket))
+		return b.Delete([]byte(id))
+	})
+}
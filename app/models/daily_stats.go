@@ -0,0 +1,181 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// DailyStatsRetentionDays is how long daily rollups are kept, long enough for a year of monthly reports
+	DailyStatsRetentionDays = 400
+
+	// dailyStatsDateFormat is the UTC day a rollup covers
+	dailyStatsDateFormat = "2006-01-02"
+)
+
+// DailyStats is a per-endpoint rollup of one UTC day of health checks. Raw history is only
+// kept for DataRetentionDays, so availability reports are built from these.
+type DailyStats struct {
+	EndpointID      string  `json:"endpoint_id"`
+	Date            string  `json:"date"`
+	Checks          int     `json:"checks"`
+	Healthy         int     `json:"healthy"`
+	Unhealthy       int     `json:"unhealthy"`
+	ResponseTimeSum float64 `json:"response_time_sum_ms"`
+	ResponseCount   int     `json:"response_count"`
+
+	// Incidents started and resolved during the day, with the downtime and time to repair they
+	// added. Down, DownSince and LastCheck carry an open incident over to the next day.
+	Incidents       int       `json:"incidents,omitempty"`
+	Resolved        int       `json:"resolved,omitempty"`
+	DowntimeSeconds float64   `json:"downtime_seconds,omitempty"`
+	RepairSeconds   float64   `json:"repair_seconds,omitempty"`
+	Down            bool      `json:"down,omitempty"`
+	DownSince       time.Time `json:"down_since"`
+	LastCheck       time.Time `json:"last_check"`
+}
+
+// rollupRecord adds a health check record to its endpoint's daily rollup
+func rollupRecord(tx *bolt.Tx, record *structs.HealthCheckRecord) error {
+	b := tx.Bucket([]byte(DailyStatsBucket))
+	date := record.Timestamp.UTC().Format(dailyStatsDateFormat)
+	key := []byte(record.EndpointID + ":" + date)
+
+	stats := DailyStats{EndpointID: record.EndpointID, Date: date}
+	if data := b.Get(key); data != nil {
+		if err := json.Unmarshal(data, &stats); err != nil {
+			return fmt.Errorf("failed to unmarshal daily stats: %w", err)
+		}
+	} else if previous := previousDailyStats(b, key, record.EndpointID); previous != nil {
+		stats.Down, stats.DownSince, stats.LastCheck = previous.Down, previous.DownSince, previous.LastCheck
+	}
+
+	weight := record.Weight()
+	stats.Checks += weight
+	// Unknown records, before thresholds are met, count as checks but not towards availability
+	switch structs.HealthStatus(record.Status) {
+	case structs.StatusHealthy:
+		stats.Healthy += weight
+	case structs.StatusUnhealthy:
+		stats.Unhealthy += weight
+	}
+	if record.ResponseTime > 0 {
+		stats.ResponseTimeSum += float64(record.ResponseTime.Microseconds()) / 1000.0
+		stats.ResponseCount++
+	}
+	trackIncident(&stats, record)
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal daily stats: %w", err)
+	}
+	return b.Put(key, data)
+}
+
+// trackIncident follows an endpoint's incidents as its records arrive: an incident runs from the
+// first unhealthy record to the next healthy one, like DetectIncidents
+func trackIncident(stats *DailyStats, record *structs.HealthCheckRecord) {
+	// Records arriving out of order still count, but cannot move incidents back in time
+	if record.Timestamp.Before(stats.LastCheck) {
+		return
+	}
+	if stats.Down {
+		stats.DowntimeSeconds += record.Timestamp.Sub(stats.LastCheck).Seconds()
+	}
+	switch structs.HealthStatus(record.Status) {
+	case structs.StatusUnhealthy:
+		if !stats.Down {
+			stats.Down = true
+			stats.DownSince = record.Timestamp
+			stats.Incidents++
+		}
+	case structs.StatusHealthy:
+		if stats.Down {
+			stats.Down = false
+			stats.Resolved++
+			stats.RepairSeconds += record.Timestamp.Sub(stats.DownSince).Seconds()
+			stats.DownSince = time.Time{}
+		}
+	}
+	stats.LastCheck = record.Timestamp
+}
+
+// previousDailyStats returns the endpoint's newest rollup before key, or nil if there is none
+func previousDailyStats(b *bolt.Bucket, key []byte, endpointID string) *DailyStats {
+	c := b.Cursor()
+	k, v := c.Seek(key)
+	if k == nil {
+		k, v = c.Last()
+	} else {
+		k, v = c.Prev()
+	}
+	// Keys of endpoints whose ID extends this one sort in between
+	for ; k != nil && bytes.HasPrefix(k, []byte(endpointID+":")); k, v = c.Prev() {
+		var stats DailyStats
+		if err := json.Unmarshal(v, &stats); err != nil || stats.EndpointID != endpointID {
+			continue
+		}
+		return &stats
+	}
+	return nil
+}
+
+// GetDailyStats retrieves an endpoint's daily rollups for UTC days in [from, to), oldest first
+func (d *Database) GetDailyStats(endpointID string, from, to time.Time) ([]*DailyStats, error) {
+	var days []*DailyStats
+	first := []byte(endpointID + ":" + from.UTC().Format(dailyStatsDateFormat))
+	last := endpointID + ":" + to.UTC().Format(dailyStatsDateFormat)
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(DailyStatsBucket)).Cursor()
+		for k, v := c.Seek(first); k != nil && string(k) < last; k, v = c.Next() {
+			var stats DailyStats
+			if err := json.Unmarshal(v, &stats); err != nil {
+				continue
+			}
+			// Keys of endpoints whose ID extends this one sort in between
+			if stats.EndpointID != endpointID {
+				continue
+			}
+			days = append(days, &stats)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// cleanupDailyStats removes rollups for days before cutoff
+func cleanupDailyStats(tx *bolt.Tx, cutoff time.Time) (int, error) {
+	b := tx.Bucket([]byte(DailyStatsBucket))
+	oldest := cutoff.UTC().Format(dailyStatsDateFormat)
+
+	var keysToDelete [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var stats DailyStats
+		if err := json.Unmarshal(v, &stats); err != nil {
+			return nil
+		}
+		if stats.Date < oldest {
+			keysToDelete = append(keysToDelete, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keysToDelete {
+		if err := b.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	return len(keysToDelete), nil
+}
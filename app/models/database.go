@@ -1,43 +1,74 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"sync"
+	"os"
 	"time"
 
 	"github.com/ashanmugaraja/cronzee/app/logger"
 	"github.com/ashanmugaraja/cronzee/app/structs"
-	"github.com/ashanmugaraja/cronzee/app/utils"
 	bolt "go.etcd.io/bbolt"
 )
 
 const (
 	// Bucket names
-	EndpointsBucket = "endpoints"
-	HistoryBucket   = "history"
-	SettingsBucket  = "settings"
+	EndpointsBucket   = "endpoints"
+	HistoryBucket     = "history"
+	SettingsBucket    = "settings"
+	StateBucket       = "state"
+	ServicesBucket    = "services"
+	ProjectsBucket    = "projects"
+	UsersBucket       = "users"
+	TokensBucket      = "tokens"
+	DeploysBucket     = "deployments"
+	PushBucket        = "push_subscriptions"
+	OnCallBucket      = "oncall"
+	BlackoutsBucket   = "blackouts"
+	OutboxBucket      = "outbox"
+	DailyStatsBucket  = "daily_stats"
+	ReportsBucket     = "reports"
+	ScreenshotsBucket = "screenshots"
 
 	// Data retention period
 	DataRetentionDays = 3
 )
 
-// Database wraps BoltDB operations
+// buckets are created in every database opened for writing
+var buckets = []string{EndpointsBucket, HistoryBucket, SettingsBucket, StateBucket, ServicesBucket, ProjectsBucket, UsersBucket, TokensBucket, DeploysBucket, PushBucket, OnCallBucket, BlackoutsBucket, OutboxBucket, DailyStatsBucket, ReportsBucket, ScreenshotsBucket}
+
+// Database wraps BoltDB operations. Bolt runs one writer at a time alongside any number of
+// readers, so status and history reads are not held up by check results being written.
 type Database struct {
-	db *bolt.DB
-	mu sync.RWMutex
+	db   *bolt.DB
+	path string
+	// recoveredFrom names the backup restored at startup after corruption, if any
+	recoveredFrom string
+	// defaults fill the unset check settings of saved endpoints
+	defaults structs.EndpointDefaults
 }
 
-// NewDatabase creates and initializes a new BoltDB database
+// NewDatabase opens and verifies a BoltDB database, restoring the latest backup if it is corrupt
 func NewDatabase(path string) (*Database, error) {
-	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
-	if err != nil {
+	var recoveredFrom string
+	db, err := openVerified(path)
+	if errors.Is(err, bolt.ErrTimeout) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	if err != nil {
+		logger.Errorf("Database integrity check failed: %v", err)
+		var recoverErr error
+		db, recoveredFrom, recoverErr = recoverFromBackup(path)
+		if recoverErr != nil {
+			return nil, fmt.Errorf("failed to open database: %w (recovery failed: %v)", err, recoverErr)
+		}
+		logger.Infof("Restored database from backup %s", recoveredFrom)
+	}
 
 	// Create buckets
 	err = db.Update(func(tx *bolt.Tx) error {
-		buckets := []string{EndpointsBucket, HistoryBucket, SettingsBucket}
 		for _, bucket := range buckets {
 			_, err := tx.CreateBucketIfNotExists([]byte(bucket))
 			if err != nil {
@@ -51,7 +82,16 @@ func NewDatabase(path string) (*Database, error) {
 		return nil, err
 	}
 
-	database := &Database{db: db}
+	database := &Database{db: db, path: path, recoveredFrom: recoveredFrom, defaults: structs.BuiltinEndpointDefaults}
+
+	if err := database.migrateEndpointIDs(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := database.compactIfDue(); err != nil {
+		return nil, err
+	}
 
 	// Start cleanup goroutine
 	go database.startCleanupRoutine()
@@ -59,6 +99,45 @@ func NewDatabase(path string) (*Database, error) {
 	return database, nil
 }
 
+// NewReadOnlyDatabase opens an existing BoltDB database without writing to it. It skips
+// bucket creation, migrations, compaction, backup recovery and the cleanup routine, and
+// fails on a corrupt file or one not yet initialized by a read-write instance.
+func NewReadOnlyDatabase(path string) (*Database, error) {
+	// bolt creates a missing file even when opening read-only
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open database read-only: %w", err)
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database read-only: %w", err)
+	}
+	if err := checkIntegrity(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database read-only: %w", err)
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		for _, bucket := range buckets {
+			if tx.Bucket([]byte(bucket)) == nil {
+				return fmt.Errorf("database has no %s bucket, open it read-write once to upgrade it", bucket)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Database{db: db, path: path, defaults: structs.BuiltinEndpointDefaults}, nil
+}
+
+// SetEndpointDefaults sets the check settings given to saved endpoints that leave them unset.
+// Call it before the database is shared.
+func (d *Database) SetEndpointDefaults(defaults structs.EndpointDefaults) {
+	d.defaults = defaults
+}
+
 // Close closes the database
 func (d *Database) Close() error {
 	return d.db.Close()
@@ -66,9 +145,6 @@ func (d *Database) Close() error {
 
 // SaveEndpoint saves or updates an endpoint
 func (d *Database) SaveEndpoint(endpoint *structs.StoredEndpoint) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	return d.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(EndpointsBucket))
 
@@ -83,20 +159,9 @@ func (d *Database) SaveEndpoint(endpoint *structs.StoredEndpoint) error {
 		if endpoint.Method == "" {
 			endpoint.Method = "GET"
 		}
-		if endpoint.Timeout == 0 {
-			endpoint.Timeout = 10 * time.Second
-		}
-		if endpoint.ExpectedStatus == 0 {
-			endpoint.ExpectedStatus = 200
-		}
-		if endpoint.FailureThreshold == 0 {
-			endpoint.FailureThreshold = 3
-		}
-		if endpoint.SuccessThreshold == 0 {
-			endpoint.SuccessThreshold = 2
-		}
-		if endpoint.CheckInterval == 0 {
-			endpoint.CheckInterval = 30 * time.Second
+		d.defaults.Apply(endpoint)
+		if endpoint.Priority == "" {
+			endpoint.Priority = structs.PriorityNormal
 		}
 
 		data, err := json.Marshal(endpoint)
@@ -110,9 +175,6 @@ func (d *Database) SaveEndpoint(endpoint *structs.StoredEndpoint) error {
 
 // GetEndpoint retrieves an endpoint by ID
 func (d *Database) GetEndpoint(id string) (*structs.StoredEndpoint, error) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-
 	var endpoint structs.StoredEndpoint
 	err := d.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(EndpointsBucket))
@@ -130,9 +192,6 @@ func (d *Database) GetEndpoint(id string) (*structs.StoredEndpoint, error) {
 
 // GetAllEndpoints retrieves all endpoints
 func (d *Database) GetAllEndpoints() ([]*structs.StoredEndpoint, error) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-
 	var endpoints []*structs.StoredEndpoint
 	err := d.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(EndpointsBucket))
@@ -151,6 +210,22 @@ func (d *Database) GetAllEndpoints() ([]*structs.StoredEndpoint, error) {
 	return endpoints, nil
 }
 
+// GetProjectEndpoints retrieves the endpoints belonging to a project
+func (d *Database) GetProjectEndpoints(projectID string) ([]*structs.StoredEndpoint, error) {
+	all, err := d.GetAllEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*structs.StoredEndpoint
+	for _, ep := range all {
+		if ep.ProjectID == projectID {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
 // GetEnabledEndpoints retrieves only enabled endpoints
 func (d *Database) GetEnabledEndpoints() ([]*structs.StoredEndpoint, error) {
 	all, err := d.GetAllEndpoints()
@@ -167,17 +242,56 @@ func (d *Database) GetEnabledEndpoints() ([]*structs.StoredEndpoint, error) {
 	return enabled, nil
 }
 
-// DeleteEndpoint removes an endpoint
+// DeleteEndpoint removes an endpoint along with its saved state, check history and screenshots
 func (d *Database) DeleteEndpoint(id string) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	return d.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(StateBucket)).Delete([]byte(id)); err != nil {
+			return err
+		}
+
+		// History keys are "<endpoint id>:<unix nanos>"
+		prefix := []byte(id + ":")
+		c := tx.Bucket([]byte(HistoryBucket)).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+
+		if err := deleteScreenshots(tx, id); err != nil {
+			return err
+		}
+
 		b := tx.Bucket([]byte(EndpointsBucket))
 		return b.Delete([]byte(id))
 	})
 }
 
+// ArchiveEndpoint marks an endpoint archived so it is no longer checked
+func (d *Database) ArchiveEndpoint(id string) error {
+	endpoint, err := d.GetEndpoint(id)
+	if err != nil {
+		return err
+	}
+	endpoint.Archived = true
+	endpoint.ArchivedAt = time.Now()
+	return d.SaveEndpoint(endpoint)
+}
+
+// RestoreEndpoint clears the archived flag of an endpoint and returns it
+func (d *Database) RestoreEndpoint(id string) (*structs.StoredEndpoint, error) {
+	endpoint, err := d.GetEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Archived = false
+	endpoint.ArchivedAt = time.Time{}
+	if err := d.SaveEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
 // EnableEndpoint enables an endpoint
 func (d *Database) EnableEndpoint(id string) error {
 	endpoint, err := d.GetEndpoint(id)
@@ -198,13 +312,15 @@ func (d *Database) DisableEndpoint(id string) error {
 	return d.SaveEndpoint(endpoint)
 }
 
-// SuppressAlerts suppresses alerts for an endpoint
-func (d *Database) SuppressAlerts(id string) error {
+// SuppressAlerts suppresses alerts for an endpoint until the given time, or until
+// unsuppressed when it is zero
+func (d *Database) SuppressAlerts(id string, until time.Time) error {
 	endpoint, err := d.GetEndpoint(id)
 	if err != nil {
 		return err
 	}
 	endpoint.AlertsSuppressed = true
+	endpoint.SuppressedUntil = until
 	return d.SaveEndpoint(endpoint)
 }
 
@@ -215,15 +331,50 @@ func (d *Database) UnsuppressAlerts(id string) error {
 		return err
 	}
 	endpoint.AlertsSuppressed = false
+	endpoint.SuppressedUntil = time.Time{}
 	return d.SaveEndpoint(endpoint)
 }
 
-// SaveHealthCheckRecord saves a health check result to history
-func (d *Database) SaveHealthCheckRecord(record *structs.HealthCheckRecord) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
+// SaveEndpointState persists a snapshot of an endpoint's runtime state. Saves made at the
+// same time share one commit.
+func (d *Database) SaveEndpointState(id string, snapshot *structs.EndpointStateSnapshot) error {
+	return d.db.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(StateBucket))
 
-	return d.db.Update(func(tx *bolt.Tx) error {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal endpoint state: %w", err)
+		}
+
+		return b.Put([]byte(id), data)
+	})
+}
+
+// GetAllEndpointStates retrieves all persisted endpoint state snapshots keyed by endpoint ID
+func (d *Database) GetAllEndpointStates() (map[string]*structs.EndpointStateSnapshot, error) {
+	snapshots := make(map[string]*structs.EndpointStateSnapshot)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(StateBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var snapshot structs.EndpointStateSnapshot
+			if err := json.Unmarshal(v, &snapshot); err != nil {
+				// Skip unreadable snapshots, the endpoint starts fresh
+				return nil
+			}
+			snapshots[string(k)] = &snapshot
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// SaveHealthCheckRecord saves a health check result to history. Saves made at the same time
+// share one commit.
+func (d *Database) SaveHealthCheckRecord(record *structs.HealthCheckRecord) error {
+	return d.db.Batch(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(HistoryBucket))
 
 		// Create a unique key using endpoint ID and timestamp
@@ -234,55 +385,141 @@ func (d *Database) SaveHealthCheckRecord(record *structs.HealthCheckRecord) erro
 			return fmt.Errorf("failed to marshal health check record: %w", err)
 		}
 
-		return b.Put([]byte(key), data)
+		if err := b.Put([]byte(key), data); err != nil {
+			return err
+		}
+		return rollupRecord(tx, record)
 	})
 }
 
-// GetHealthHistory retrieves health check history for an endpoint
-func (d *Database) GetHealthHistory(endpointID string, limit int) ([]*structs.HealthCheckRecord, error) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+// SaveHealthCheckRecords saves a batch of health check records in one transaction
+func (d *Database) SaveHealthCheckRecords(records []*structs.HealthCheckRecord) error {
+	return d.db.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(HistoryBucket))
+
+		for _, record := range records {
+			key := fmt.Sprintf("%s:%d", record.EndpointID, record.Timestamp.UnixNano())
+
+			data, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to marshal health check record: %w", err)
+			}
 
+			if err := b.Put([]byte(key), data); err != nil {
+				return err
+			}
+			if err := rollupRecord(tx, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetHealthHistory retrieves an endpoint's newest health check records, newest first.
+// A limit of 0 or less returns them all.
+func (d *Database) GetHealthHistory(endpointID string, limit int) ([]*structs.HealthCheckRecord, error) {
 	var records []*structs.HealthCheckRecord
 	prefix := []byte(endpointID + ":")
 
 	err := d.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte(HistoryBucket))
-		c := b.Cursor()
-
-		// Collect all matching records
-		for k, v := c.Seek(prefix); k != nil && len(k) >= len(prefix) && string(k[:len(prefix)]) == string(prefix); k, v = c.Next() {
+		c := tx.Bucket([]byte(HistoryBucket)).Cursor()
+
+		// Walk back from the newest key so only the records returned are decoded. Keys are
+		// "<id>:<unixnano>", so "<id>;" sorts right after the endpoint's last record.
+		k, v := c.Seek([]byte(endpointID + ";"))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Prev() {
 			var record structs.HealthCheckRecord
 			if err := json.Unmarshal(v, &record); err != nil {
 				continue
 			}
 			records = append(records, &record)
+			if limit > 0 && len(records) == limit {
+				break
+			}
 		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	return records, nil
+}
 
-	// Sort by timestamp descending and limit
-	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
-		records[i], records[j] = records[j], records[i]
-	}
+// GetHealthHistoryRange retrieves an endpoint's records in [from, to), oldest first
+func (d *Database) GetHealthHistoryRange(endpointID string, from, to time.Time) ([]*structs.HealthCheckRecord, error) {
+	var records []*structs.HealthCheckRecord
+	prefix := []byte(endpointID + ":")
+	start := []byte(fmt.Sprintf("%s:%d", endpointID, from.UnixNano()))
 
-	if limit > 0 && len(records) > limit {
-		records = records[:limit]
-	}
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(HistoryBucket)).Cursor()
 
+		for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var record structs.HealthCheckRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				continue
+			}
+			if !record.Timestamp.Before(to) {
+				break
+			}
+			records = append(records, &record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return records, nil
 }
 
+// ForEachHealthRecord calls fn for every endpoint's records in [from, to), endpoint by endpoint
+// and oldest first. It seeks past each endpoint's records outside the range instead of reading them.
+func (d *Database) ForEachHealthRecord(from, to time.Time, fn func(*structs.HealthCheckRecord) error) error {
+	return d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(HistoryBucket)).Cursor()
+
+		k, _ := c.First()
+		for k != nil {
+			id, _, ok := bytes.Cut(k, []byte(":"))
+			if !ok {
+				k, _ = c.Next()
+				continue
+			}
+			prefix := append(append([]byte(nil), id...), ':')
+
+			var v []byte
+			for k, v = c.Seek([]byte(fmt.Sprintf("%s:%d", id, from.UnixNano()))); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				var record structs.HealthCheckRecord
+				if err := json.Unmarshal(v, &record); err != nil {
+					continue
+				}
+				if !record.Timestamp.Before(to) {
+					break
+				}
+				if err := fn(&record); err != nil {
+					return err
+				}
+			}
+			// "<id>;" sorts right after the endpoint's last record
+			k, _ = c.Seek(append(append([]byte(nil), id...), ';'))
+		}
+		return nil
+	})
+}
+
 // CleanupOldData removes data older than retention period
 func (d *Database) CleanupOldData() error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	cutoff := time.Now().AddDate(0, 0, -DataRetentionDays)
 	deletedCount := 0
+	outboxCount := 0
+	rollupCount := 0
+	screenshotCount := 0
 
 	err := d.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(HistoryBucket))
@@ -307,12 +544,31 @@ func (d *Database) CleanupOldData() error {
 			deletedCount++
 		}
 
-		return nil
+		var err error
+		outboxCount, err = cleanupOutbox(tx, cutoff)
+		if err != nil {
+			return err
+		}
+		screenshotCount, err = cleanupScreenshots(tx, cutoff)
+		if err != nil {
+			return err
+		}
+		rollupCount, err = cleanupDailyStats(tx, time.Now().AddDate(0, 0, -DailyStatsRetentionDays))
+		return err
 	})
 
 	if err == nil && deletedCount > 0 {
 		logger.Infof("Cleaned up %d old health check records (older than %d days)", deletedCount, DataRetentionDays)
 	}
+	if err == nil && outboxCount > 0 {
+		logger.Infof("Cleaned up %d finished outbox messages (older than %d days)", outboxCount, DataRetentionDays)
+	}
+	if err == nil && screenshotCount > 0 {
+		logger.Infof("Cleaned up %d screenshots (older than %d days)", screenshotCount, DataRetentionDays)
+	}
+	if err == nil && rollupCount > 0 {
+		logger.Infof("Cleaned up %d daily rollups (older than %d days)", rollupCount, DailyStatsRetentionDays)
+	}
 
 	return err
 }
@@ -326,11 +582,13 @@ func (d *Database) startCleanupRoutine() {
 	if err := d.CleanupOldData(); err != nil {
 		logger.Errorf("Error during initial cleanup: %v", err)
 	}
+	d.backupIfDue()
 
 	for range ticker.C {
 		if err := d.CleanupOldData(); err != nil {
 			logger.Errorf("Error during cleanup: %v", err)
 		}
+		d.backupIfDue()
 	}
 }
 
@@ -338,17 +596,55 @@ func (d *Database) startCleanupRoutine() {
 func (d *Database) MigrateFromConfig(endpoints []structs.Endpoint) error {
 	for _, ep := range endpoints {
 		stored := &structs.StoredEndpoint{
-			ID:               utils.GenerateIDWithURL(ep.Name, ep.URL),
-			Name:             ep.Name,
-			URL:              ep.URL,
-			Method:           ep.Method,
-			Timeout:          ep.Timeout.Duration,
-			ExpectedStatus:   ep.ExpectedStatus,
-			Headers:          ep.Headers,
-			FailureThreshold: ep.FailureThreshold,
-			SuccessThreshold: ep.SuccessThreshold,
-			Enabled:          true,
-			AlertsSuppressed: false,
+			ID:                 ConfigEndpointID(ep.Name, ep.URL),
+			Name:               ep.Name,
+			URL:                ep.URL,
+			Method:             ep.Method,
+			Timeout:            ep.Timeout.Duration,
+			ExpectedStatus:     ep.ExpectedStatus,
+			Headers:            ep.Headers,
+			FailureThreshold:   ep.FailureThreshold,
+			NetworkThreshold:   ep.NetworkThreshold,
+			AppThreshold:       ep.AppThreshold,
+			SuccessThreshold:   ep.SuccessThreshold,
+			FailureDuration:    ep.FailureDuration.Duration,
+			SuccessDuration:    ep.SuccessDuration.Duration,
+			ResolveTo:          ep.ResolveTo,
+			HostHeader:         ep.HostHeader,
+			DisableKeepAlive:   ep.DisableKeepAlive,
+			MaxIdleConns:       ep.MaxIdleConns,
+			UseCookies:         ep.UseCookies,
+			BackoffEnabled:     ep.BackoffEnabled,
+			BackoffAfter:       ep.BackoffAfter.Duration,
+			BackoffMaxInterval: ep.BackoffMaxInterval.Duration,
+			Retries:            ep.Retries,
+			RetryDelay:         ep.RetryDelay.Duration,
+			Priority:           ep.Priority,
+			InsecureSkipVerify: ep.InsecureSkipVerify,
+			CertFingerprint:    ep.CertFingerprint,
+			Tags:               ep.Tags,
+			SLATarget:          ep.SLATarget,
+			ProjectID:          ep.ProjectID,
+			Description:        ep.Description,
+			Owner:              ep.Owner,
+			RunbookURL:         ep.RunbookURL,
+			Labels:             ep.Labels,
+			RateLimitMode:      ep.RateLimitMode,
+			Schedule:           ep.Schedule,
+			CheckType:          ep.CheckType,
+			WaitSelector:       ep.WaitSelector,
+			ContentType:        ep.ContentType,
+			SuccessExpression:  ep.SuccessExpression,
+			BodyContains:       ep.BodyContains,
+			BodyNotContains:    ep.BodyNotContains,
+			BodyRegex:          ep.BodyRegex,
+			AlternateHosts:     ep.AlternateHosts,
+			CallbackURL:        ep.CallbackURL,
+			HistorySample:      ep.HistorySample,
+			Journey:            ep.Journey,
+			MonitorHealth:      true,
+			Enabled:            true,
+			AlertsSuppressed:   false,
 		}
 
 		// Check if endpoint already exists
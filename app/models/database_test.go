@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	benchEndpoints = 100
+	benchRecords   = 300
+	benchWriters   = 8
+)
+
+// newBenchDatabase opens a fresh database holding benchRecords history records and a state
+// snapshot for each of benchEndpoints endpoints
+func newBenchDatabase(b *testing.B) *Database {
+	b.Helper()
+	logger.Init()
+
+	db, err := NewDatabase(filepath.Join(b.TempDir(), "sitewatch.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { db.Close() })
+
+	start := time.Now().Add(-benchRecords * time.Minute)
+	for e := 0; e < benchEndpoints; e++ {
+		id := fmt.Sprintf("endpoint-%03d", e)
+		records := make([]*structs.HealthCheckRecord, benchRecords)
+		for i := range records {
+			records[i] = &structs.HealthCheckRecord{
+				EndpointID:   id,
+				Timestamp:    start.Add(time.Duration(i) * time.Minute),
+				Status:       string(structs.StatusHealthy),
+				ResponseTime: 120 * time.Millisecond,
+			}
+		}
+		if err := db.SaveHealthCheckRecords(records); err != nil {
+			b.Fatal(err)
+		}
+		if err := db.SaveEndpointState(id, &structs.EndpointStateSnapshot{Status: structs.StatusHealthy, LastCheck: time.Now()}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return db
+}
+
+// startBenchWriters saves check results the way the monitor does until the returned stop
+// function is called
+func startBenchWriters(b *testing.B, db *Database) (stop func()) {
+	b.Helper()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := 0; w < benchWriters; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				id := fmt.Sprintf("endpoint-%03d", (w+i*benchWriters)%benchEndpoints)
+				now := time.Now()
+				if err := db.SaveHealthCheckRecord(&structs.HealthCheckRecord{EndpointID: id, Timestamp: now, Status: string(structs.StatusHealthy)}); err != nil {
+					b.Error(err)
+					return
+				}
+				if err := db.SaveEndpointState(id, &structs.EndpointStateSnapshot{Status: structs.StatusHealthy, LastCheck: now}); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		}(w)
+	}
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// BenchmarkGetHealthHistoryUnderLoad reads the latest 50 records of an endpoint, as the
+// history API does, while check results are being written
+func BenchmarkGetHealthHistoryUnderLoad(b *testing.B) {
+	db := newBenchDatabase(b)
+	stop := startBenchWriters(b, db)
+	defer stop()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if _, err := db.GetHealthHistory(fmt.Sprintf("endpoint-%03d", i%benchEndpoints), 50); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+}
+
+// BenchmarkGetAllEndpointStatesUnderLoad loads every endpoint's state snapshot while check
+// results are being written
+func BenchmarkGetAllEndpointStatesUnderLoad(b *testing.B) {
+	db := newBenchDatabase(b)
+	stop := startBenchWriters(b, db)
+	defer stop()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := db.GetAllEndpointStates(); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveDeployment stores a deployment marker keyed by time so markers stay in order
+func (d *Database) SaveDeployment(deployment *structs.Deployment) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(DeploysBucket))
+
+		if deployment.Timestamp.IsZero() {
+			deployment.Timestamp = time.Now()
+		}
+		if deployment.ID == "" {
+			deployment.ID = fmt.Sprintf("%020d", deployment.Timestamp.UnixNano())
+		}
+
+		data, err := json.Marshal(deployment)
+		if err != nil {
+			return fmt.Errorf("failed to marshal deployment: %w", err)
+		}
+
+		return b.Put([]byte(deployment.ID), data)
+	})
+}
+
+// GetDeploymentsSince retrieves deployment markers at or after since, oldest first
+func (d *Database) GetDeploymentsSince(since time.Time) ([]*structs.Deployment, error) {
+	var deployments []*structs.Deployment
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(DeploysBucket))
+		c := b.Cursor()
+
+		for k, v := c.Seek([]byte(fmt.Sprintf("%020d", since.UnixNano()))); k != nil; k, v = c.Next() {
+			var deployment structs.Deployment
+			if err := json.Unmarshal(v, &deployment); err != nil {
+				continue
+			}
+			deployments = append(deployments, &deployment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ConfigEndpointID returns the ID of an endpoint imported from the config file. It is derived
+// from the name and URL so the entry maps to the same endpoint on every start and every node.
+func ConfigEndpointID(name, url string) string {
+	return utils.NameUUID(utils.GenerateIDWithURL(name, url))
+}
+
+// migrateEndpointIDs moves endpoints with the old name-and-URL IDs to UUIDs, together with
+// their state, history, rollups, screenshots and references from services, deployments and
+// reports. The new ID is derived from the old one, so a config file entry keeps its endpoint.
+func (d *Database) migrateEndpointIDs() error {
+	var moved int
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		endpoints := tx.Bucket([]byte(EndpointsBucket))
+
+		ids := make(map[string]string)
+		err := endpoints.ForEach(func(k, v []byte) error {
+			if !utils.IsUUID(string(k)) {
+				ids[string(k)] = utils.NameUUID(string(k))
+			}
+			return nil
+		})
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		for oldID, newID := range ids {
+			var endpoint structs.StoredEndpoint
+			if err := json.Unmarshal(endpoints.Get([]byte(oldID)), &endpoint); err != nil {
+				return fmt.Errorf("failed to read endpoint %s: %w", oldID, err)
+			}
+			endpoint.ID = newID
+			endpoint.LegacyID = oldID
+			if err := putJSON(endpoints, newID, &endpoint); err != nil {
+				return err
+			}
+			if err := endpoints.Delete([]byte(oldID)); err != nil {
+				return err
+			}
+
+			if err := moveKey(tx.Bucket([]byte(StateBucket)), oldID, newID); err != nil {
+				return err
+			}
+			if err := moveEndpointKeys(tx.Bucket([]byte(HistoryBucket)), oldID, newID, func(v []byte) ([]byte, error) {
+				var record structs.HealthCheckRecord
+				if err := json.Unmarshal(v, &record); err != nil {
+					return v, nil
+				}
+				record.EndpointID = newID
+				return json.Marshal(&record)
+			}); err != nil {
+				return err
+			}
+			if err := moveEndpointKeys(tx.Bucket([]byte(DailyStatsBucket)), oldID, newID, func(v []byte) ([]byte, error) {
+				var stats DailyStats
+				if err := json.Unmarshal(v, &stats); err != nil {
+					return v, nil
+				}
+				stats.EndpointID = newID
+				return json.Marshal(&stats)
+			}); err != nil {
+				return err
+			}
+
+			screenshots := tx.Bucket([]byte(ScreenshotsBucket))
+			if err := moveEndpointKeys(screenshots, oldID, newID, nil); err != nil {
+				return err
+			}
+			if latest := screenshots.Get([]byte(latestScreenshotPrefix + oldID)); latest != nil {
+				pointer := newID + string(bytes.TrimPrefix(latest, []byte(oldID)))
+				if err := screenshots.Put([]byte(latestScreenshotPrefix+newID), []byte(pointer)); err != nil {
+					return err
+				}
+				if err := screenshots.Delete([]byte(latestScreenshotPrefix + oldID)); err != nil {
+					return err
+				}
+			}
+
+			logger.Infof("Migrated endpoint %s to ID %s", oldID, newID)
+			moved++
+		}
+
+		return rewriteEndpointReferences(tx, ids)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to migrate endpoint IDs: %w", err)
+	}
+	if moved > 0 {
+		logger.Infof("Migrated %d endpoints to UUIDs; their old IDs are kept as legacy_id", moved)
+	}
+	return nil
+}
+
+// rewriteEndpointReferences replaces old endpoint IDs in services, deployments and reports
+func rewriteEndpointReferences(tx *bolt.Tx, ids map[string]string) error {
+	rename := func(list []string) {
+		for i, id := range list {
+			if newID, ok := ids[id]; ok {
+				list[i] = newID
+			}
+		}
+	}
+
+	err := rewriteJSON(tx.Bucket([]byte(ServicesBucket)), func(service *structs.Service) {
+		rename(service.EndpointIDs)
+		for oldID, newID := range ids {
+			if weight, ok := service.Weights[oldID]; ok {
+				service.Weights[newID] = weight
+				delete(service.Weights, oldID)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = rewriteJSON(tx.Bucket([]byte(DeploysBucket)), func(deployment *structs.Deployment) {
+		rename(deployment.EndpointIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return rewriteJSON(tx.Bucket([]byte(ReportsBucket)), func(report *structs.Report) {
+		for i := range report.Endpoints {
+			if newID, ok := ids[report.Endpoints[i].ID]; ok {
+				report.Endpoints[i].ID = newID
+			}
+		}
+	})
+}
+
+// rewriteJSON decodes every value of a bucket, applies fn and stores the result
+func rewriteJSON[T any](b *bolt.Bucket, fn func(*T)) error {
+	updates := make(map[string][]byte)
+	err := b.ForEach(func(k, v []byte) error {
+		var item T
+		if err := json.Unmarshal(v, &item); err != nil {
+			return nil
+		}
+		fn(&item)
+		data, err := json.Marshal(&item)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = data
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for k, v := range updates {
+		if err := b.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveEndpointKeys moves every "<oldID>:..." key to "<newID>:...", rewriting values with rewrite if set
+func moveEndpointKeys(b *bolt.Bucket, oldID, newID string, rewrite func([]byte) ([]byte, error)) error {
+	prefix := []byte(oldID + ":")
+	var keys [][]byte
+	c := b.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+
+	for _, key := range keys {
+		value := append([]byte(nil), b.Get(key)...)
+		if rewrite != nil {
+			var err error
+			if value, err = rewrite(value); err != nil {
+				return err
+			}
+		}
+		if err := b.Put(append([]byte(newID), key[len(oldID):]...), value); err != nil {
+			return err
+		}
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveKey moves a single value to a new key
+func moveKey(b *bolt.Bucket, oldKey, newKey string) error {
+	value := b.Get([]byte(oldKey))
+	if value == nil {
+		return nil
+	}
+	if err := b.Put([]byte(newKey), append([]byte(nil), value...)); err != nil {
+		return err
+	}
+	return b.Delete([]byte(oldKey))
+}
+
+// putJSON stores v as JSON under key
+func putJSON(b *bolt.Bucket, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(key), data)
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// dumpBuckets copies every key and value of the database, for comparing it across runs
+func dumpBuckets(t *testing.T, db *Database) map[string]map[string]string {
+	t.Helper()
+	dump := make(map[string]map[string]string)
+	err := db.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			values := make(map[string]string)
+			dump[string(name)] = values
+			return b.ForEach(func(k, v []byte) error {
+				values[string(k)] = string(v)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dump
+}
+
+func TestMigrateEndpointIDs(t *testing.T) {
+	logger.Init()
+	db, err := NewDatabase(filepath.Join(t.TempDir(), "sitewatch.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	oldID := utils.GenerateIDWithURL("API", "https://api.example.com/health")
+	keptID := utils.NewUUID()
+	newID := utils.NameUUID(oldID)
+	// Noon UTC yesterday, so both checks fall in one daily rollup
+	at := time.Now().UTC().Truncate(24 * time.Hour).Add(-12 * time.Hour)
+
+	for _, endpoint := range []*structs.StoredEndpoint{
+		{ID: oldID, Name: "API", URL: "https://api.example.com/health", Enabled: true},
+		{ID: keptID, Name: "Web", URL: "https://www.example.com", Enabled: true},
+	} {
+		if err := db.SaveEndpoint(endpoint); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.SaveEndpointState(oldID, &structs.EndpointStateSnapshot{Status: structs.StatusHealthy, LastCheck: at}); err != nil {
+		t.Fatal(err)
+	}
+	err = db.SaveHealthCheckRecords([]*structs.HealthCheckRecord{
+		{EndpointID: oldID, Timestamp: at, Status: string(structs.StatusHealthy)},
+		{EndpointID: oldID, Timestamp: at.Add(time.Minute), Status: string(structs.StatusUnhealthy)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveScreenshot(oldID, at, []byte("failed"), true); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveScreenshot(oldID, at.Add(time.Minute), []byte("latest"), false); err != nil {
+		t.Fatal(err)
+	}
+	service := &structs.Service{
+		ID:          "checkout",
+		Name:        "Checkout",
+		EndpointIDs: []string{oldID, keptID},
+		Weights:     map[string]float64{oldID: 2, keptID: 1},
+	}
+	if err := db.SaveService(service); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveDeployment(&structs.Deployment{ID: "deploy", EndpointIDs: []string{oldID}, Timestamp: at}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveReport(&structs.Report{ID: "report", Month: "2026-09", Endpoints: []structs.ReportEndpoint{{ID: oldID}, {ID: keptID}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.migrateEndpointIDs(); err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint, err := db.GetEndpoint(newID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.LegacyID != oldID {
+		t.Errorf("legacy_id = %q, want %q", endpoint.LegacyID, oldID)
+	}
+	if _, err := db.GetEndpoint(oldID); err == nil {
+		t.Error("endpoint is still stored under its old ID")
+	}
+	if kept, err := db.GetEndpoint(keptID); err != nil || kept.LegacyID != "" {
+		t.Errorf("UUID endpoint was changed: %+v, %v", kept, err)
+	}
+
+	states, err := db.GetAllEndpointStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := states[oldID]; ok {
+		t.Error("state is still stored under the old ID")
+	}
+	if state := states[newID]; state == nil || !state.LastCheck.Equal(at) {
+		t.Errorf("state under the new ID = %+v", state)
+	}
+
+	history, err := db.GetHealthHistory(newID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d history records under the new ID, want 2", len(history))
+	}
+	for _, record := range history {
+		if record.EndpointID != newID {
+			t.Errorf("history record endpoint_id = %q, want %q", record.EndpointID, newID)
+		}
+	}
+	if old, _ := db.GetHealthHistory(oldID, 10); len(old) != 0 {
+		t.Errorf("%d history records left under the old ID", len(old))
+	}
+
+	from, to := at.AddDate(0, 0, -1), at.AddDate(0, 0, 2)
+	days, err := db.GetDailyStats(newID, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) == 0 || days[0].Checks != 2 || days[0].EndpointID != newID {
+		t.Errorf("daily stats under the new ID = %+v", days)
+	}
+	if old, _ := db.GetDailyStats(oldID, from, to); len(old) != 0 {
+		t.Errorf("%d daily rollups left under the old ID", len(old))
+	}
+
+	if image, err := db.GetScreenshot(newID, at); err != nil || string(image) != "failed" {
+		t.Errorf("failed check screenshot = %q, %v", image, err)
+	}
+	image, taken, err := db.GetLatestScreenshot(newID)
+	if err != nil || string(image) != "latest" || !taken.Equal(at.Add(time.Minute)) {
+		t.Errorf("latest screenshot = %q at %v, %v", image, taken, err)
+	}
+	err = db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ScreenshotsBucket))
+		if b.Get([]byte(latestScreenshotPrefix+oldID)) != nil {
+			t.Error("latest screenshot pointer is still stored under the old ID")
+		}
+		pointer := b.Get([]byte(latestScreenshotPrefix + newID))
+		if !bytes.HasPrefix(pointer, []byte(newID+":")) || b.Get(pointer) == nil {
+			t.Errorf("latest screenshot pointer %q does not point at a screenshot of the new ID", pointer)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := db.GetService("checkout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{newID, keptID}; !reflect.DeepEqual(migrated.EndpointIDs, want) {
+		t.Errorf("service endpoint_ids = %v, want %v", migrated.EndpointIDs, want)
+	}
+	if want := map[string]float64{newID: 2, keptID: 1}; !reflect.DeepEqual(migrated.Weights, want) {
+		t.Errorf("service weights = %v, want %v", migrated.Weights, want)
+	}
+
+	deployments, err := db.GetDeploymentsSince(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments) != 1 || !reflect.DeepEqual(deployments[0].EndpointIDs, []string{newID}) {
+		t.Errorf("deployments = %+v", deployments)
+	}
+
+	report, err := db.GetReport("report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Endpoints) != 2 || report.Endpoints[0].ID != newID || report.Endpoints[1].ID != keptID {
+		t.Errorf("report endpoints = %+v", report.Endpoints)
+	}
+
+	// A second run finds nothing left to move
+	before := dumpBuckets(t, db)
+	if err := db.migrateEndpointIDs(); err != nil {
+		t.Fatal(err)
+	}
+	if after := dumpBuckets(t, db); !reflect.DeepEqual(before, after) {
+		t.Error("second migration changed the database")
+	}
+}
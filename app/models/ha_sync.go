@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// EndpointSet is the copy of the endpoints, their state and the settings that an HA leader
+// shares with its standby, so the standby takes over with the same configuration
+type EndpointSet struct {
+	Holder    string                                    `json:"holder"`
+	Written   time.Time                                 `json:"written"`
+	Endpoints []*structs.StoredEndpoint                 `json:"endpoints"`
+	States    map[string]*structs.EndpointStateSnapshot `json:"states"`
+	Settings  map[string]json.RawMessage                `json:"settings"`
+}
+
+// ExportEndpointSet reads the endpoints, state snapshots and settings in one transaction
+func (d *Database) ExportEndpointSet() (*EndpointSet, error) {
+	set := &EndpointSet{
+		States:   make(map[string]*structs.EndpointStateSnapshot),
+		Settings: make(map[string]json.RawMessage),
+	}
+	err := d.db.View(func(tx *bolt.Tx) error {
+		err := tx.Bucket([]byte(EndpointsBucket)).ForEach(func(k, v []byte) error {
+			var endpoint structs.StoredEndpoint
+			if err := json.Unmarshal(v, &endpoint); err != nil {
+				return err
+			}
+			set.Endpoints = append(set.Endpoints, &endpoint)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Bucket([]byte(StateBucket)).ForEach(func(k, v []byte) error {
+			var snapshot structs.EndpointStateSnapshot
+			if err := json.Unmarshal(v, &snapshot); err != nil {
+				// Skip unreadable snapshots, the endpoint starts fresh
+				return nil
+			}
+			set.States[string(k)] = &snapshot
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket([]byte(SettingsBucket)).ForEach(func(k, v []byte) error {
+			// Compaction is tracked per database file
+			if string(k) == LastCompactionSettingKey {
+				return nil
+			}
+			set.Settings[string(k)] = append(json.RawMessage(nil), v...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// ImportEndpointSet replaces the endpoints, state snapshots and settings with those of set in
+// one transaction. Endpoints missing from set are removed with their state; their history is
+// left to the retention cleanup.
+func (d *Database) ImportEndpointSet(set *EndpointSet) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		endpoints := tx.Bucket([]byte(EndpointsBucket))
+		states := tx.Bucket([]byte(StateBucket))
+		settings := tx.Bucket([]byte(SettingsBucket))
+
+		keep := make(map[string]bool, len(set.Endpoints))
+		for _, endpoint := range set.Endpoints {
+			keep[endpoint.ID] = true
+			data, err := json.Marshal(endpoint)
+			if err != nil {
+				return fmt.Errorf("failed to marshal endpoint: %w", err)
+			}
+			if err := endpoints.Put([]byte(endpoint.ID), data); err != nil {
+				return err
+			}
+		}
+
+		var stale [][]byte
+		err := endpoints.ForEach(func(k, v []byte) error {
+			if !keep[string(k)] {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range stale {
+			if err := endpoints.Delete(id); err != nil {
+				return err
+			}
+			if err := states.Delete(id); err != nil {
+				return err
+			}
+		}
+
+		for id, snapshot := range set.States {
+			if !keep[id] {
+				continue
+			}
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				return fmt.Errorf("failed to marshal endpoint state: %w", err)
+			}
+			if err := states.Put([]byte(id), data); err != nil {
+				return err
+			}
+		}
+
+		for key, value := range set.Settings {
+			if err := settings.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// Incident is a contiguous run of unhealthy health check records
+type Incident struct {
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`
+	Duration string     `json:"duration"`
+	Checks   int        `json:"checks"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// LatencyStats summarises response times in milliseconds
+type LatencyStats struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min_ms"`
+	Max   float64 `json:"max_ms"`
+	Avg   float64 `json:"avg_ms"`
+	P50   float64 `json:"p50_ms"`
+	P95   float64 `json:"p95_ms"`
+	P99   float64 `json:"p99_ms"`
+}
+
+// RecordsSince returns the records with a timestamp at or after since
+func RecordsSince(records []*structs.HealthCheckRecord, since time.Time) []*structs.HealthCheckRecord {
+	var filtered []*structs.HealthCheckRecord
+	for _, record := range records {
+		if !record.Timestamp.Before(since) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// RecordsBetween returns the records with a timestamp in [from, to)
+func RecordsBetween(records []*structs.HealthCheckRecord, from, to time.Time) []*structs.HealthCheckRecord {
+	var filtered []*structs.HealthCheckRecord
+	for _, record := range records {
+		if !record.Timestamp.Before(from) && record.Timestamp.Before(to) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// CountChecks returns the number of checks the records stand for, counting sampled history
+func CountChecks(records []*structs.HealthCheckRecord) int {
+	checks := 0
+	for _, record := range records {
+		checks += record.Weight()
+	}
+	return checks
+}
+
+// UptimePercent returns the percentage of healthy records, or -1 if there are none.
+// Records still in the unknown state (before thresholds are met) are ignored.
+func UptimePercent(records []*structs.HealthCheckRecord) float64 {
+	var total, healthy int
+	for _, record := range records {
+		switch structs.HealthStatus(record.Status) {
+		case structs.StatusHealthy:
+			healthy += record.Weight()
+			total += record.Weight()
+		case structs.StatusUnhealthy:
+			total += record.Weight()
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	return float64(healthy) / float64(total) * 100
+}
+
+// ComputeLatencyStats calculates response time statistics for records with a response time
+func ComputeLatencyStats(records []*structs.HealthCheckRecord) LatencyStats {
+	var values []float64
+	for _, record := range records {
+		if record.ResponseTime > 0 {
+			values = append(values, float64(record.ResponseTime.Microseconds())/1000.0)
+		}
+	}
+
+	stats := LatencyStats{Count: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+
+	sort.Float64s(values)
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	stats.Min = values[0]
+	stats.Max = values[len(values)-1]
+	stats.Avg = sum / float64(len(values))
+	stats.P50 = percentile(values, 50)
+	stats.P95 = percentile(values, 95)
+	stats.P99 = percentile(values, 99)
+	return stats
+}
+
+// ChartBucket aggregates the records falling in one chart interval
+type ChartBucket struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Checks       int       `json:"checks"`
+	Healthy      int       `json:"healthy"`
+	Unhealthy    int       `json:"unhealthy"`
+	Availability *float64  `json:"availability"`
+	AvgMs        *float64  `json:"avg_ms"`
+	P95Ms        *float64  `json:"p95_ms"`
+	MaxMs        *float64  `json:"max_ms"`
+}
+
+// BucketRecords splits [from, to) into n equal buckets of availability and latency.
+// Empty buckets have null values so charts can show gaps.
+func BucketRecords(records []*structs.HealthCheckRecord, from, to time.Time, n int) []ChartBucket {
+	width := to.Sub(from) / time.Duration(n)
+	if width <= 0 {
+		return nil
+	}
+
+	grouped := make([][]*structs.HealthCheckRecord, n)
+	for _, record := range records {
+		if record.Timestamp.Before(from) || !record.Timestamp.Before(to) {
+			continue
+		}
+		i := int(record.Timestamp.Sub(from) / width)
+		if i >= n {
+			i = n - 1
+		}
+		grouped[i] = append(grouped[i], record)
+	}
+
+	buckets := make([]ChartBucket, n)
+	for i, group := range grouped {
+		bucket := ChartBucket{
+			Start:  from.Add(time.Duration(i) * width),
+			End:    from.Add(time.Duration(i+1) * width),
+			Checks: CountChecks(group),
+		}
+		for _, record := range group {
+			switch structs.HealthStatus(record.Status) {
+			case structs.StatusHealthy:
+				bucket.Healthy += record.Weight()
+			case structs.StatusUnhealthy:
+				bucket.Unhealthy += record.Weight()
+			}
+		}
+		if uptime := UptimePercent(group); uptime >= 0 {
+			bucket.Availability = &uptime
+		}
+		if stats := ComputeLatencyStats(group); stats.Count > 0 {
+			bucket.AvgMs, bucket.P95Ms, bucket.MaxMs = &stats.Avg, &stats.P95, &stats.Max
+		}
+		buckets[i] = bucket
+	}
+	return buckets
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(p/100*float64(len(sorted)) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// DetectIncidents finds unhealthy periods in records, most recent first
+func DetectIncidents(records []*structs.HealthCheckRecord) []Incident {
+	ordered := make([]*structs.HealthCheckRecord, len(records))
+	copy(ordered, records)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp.Before(ordered[j].Timestamp)
+	})
+
+	var incidents []Incident
+	var current *Incident
+	for _, record := range ordered {
+		unhealthy := structs.HealthStatus(record.Status) == structs.StatusUnhealthy
+		switch {
+		case unhealthy && current == nil:
+			current = &Incident{Start: record.Timestamp, Checks: 1, Error: record.Error}
+		case unhealthy:
+			current.Checks++
+		case current != nil && structs.HealthStatus(record.Status) == structs.StatusHealthy:
+			end := record.Timestamp
+			current.End = &end
+			current.Duration = end.Sub(current.Start).Round(time.Second).String()
+			incidents = append(incidents, *current)
+			current = nil
+		}
+	}
+
+	// An incident still in progress has no end yet
+	if current != nil {
+		current.Duration = time.Since(current.Start).Round(time.Second).String()
+		incidents = append(incidents, *current)
+	}
+
+	for i, j := 0, len(incidents)-1; i < j; i, j = i+1, j-1 {
+		incidents[i], incidents[j] = incidents[j], incidents[i]
+	}
+	return incidents
+}
@@ -0,0 +1,335 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// LastCompactionSettingKey records when the database file was last compacted
+	LastCompactionSettingKey = "last_compaction"
+
+	// backupMarker separates the database path from a backup's timestamp
+	backupMarker = ".bak-"
+	// backupTimeFormat sorts lexically in time order
+	backupTimeFormat = "20060102T150405"
+	// maxBackups is how many backups are kept next to the database
+	maxBackups = 3
+	// backupInterval is how often the cleanup routine takes a backup
+	backupInterval = 24 * time.Hour
+	// compactionInterval is how often the file is compacted at startup
+	compactionInterval = 7 * 24 * time.Hour
+	// compactTxMaxSize bounds the write transactions used while compacting
+	compactTxMaxSize = 64 << 20
+)
+
+// DBHealth describes the database file for the admin health API
+type DBHealth struct {
+	Path           string         `json:"path"`
+	FileSize       int64          `json:"file_size_bytes"`
+	Integrity      string         `json:"integrity"`
+	Buckets        map[string]int `json:"buckets"`
+	LastCompaction *time.Time     `json:"last_compaction"`
+	LastBackup     *time.Time     `json:"last_backup"`
+	Backups        []string       `json:"backups"`
+	RecoveredFrom  string         `json:"recovered_from,omitempty"`
+}
+
+// openVerified opens a bolt file and runs a full consistency check on it
+func openVerified(path string) (db *bolt.DB, err error) {
+	// A badly damaged file can make bolt panic instead of returning an error
+	defer func() {
+		if r := recover(); r != nil {
+			if db != nil {
+				db.Close()
+			}
+			db, err = nil, fmt.Errorf("database is corrupt: %v", r)
+		}
+	}()
+
+	db, err = openBolt(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIntegrity(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// checkIntegrity reads every bucket and key in the database, returning the first problem found.
+// bolt's own Tx.Check panics on damaged pages in a goroutine that cannot be recovered,
+// so the walk runs here where such panics are turned into errors.
+func checkIntegrity(db *bolt.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("database is corrupt: %v", r)
+		}
+	}()
+
+	return db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return walkBucket(b)
+		})
+	})
+}
+
+// walkBucket visits every key of a bucket and its nested buckets
+func walkBucket(b *bolt.Bucket) error {
+	return b.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return nil
+		}
+		nested := b.Bucket(k)
+		if nested == nil {
+			return fmt.Errorf("key %q has no value and is not a bucket", k)
+		}
+		return walkBucket(nested)
+	})
+}
+
+// recoverFromBackup moves a corrupt database aside and restores the newest backup that
+// passes the integrity check, returning the opened database and the backup used
+func recoverFromBackup(path string) (*bolt.DB, string, error) {
+	backups, err := listBackups(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(backups) == 0 {
+		return nil, "", fmt.Errorf("no backups found")
+	}
+
+	corrupt := path + ".corrupt-" + time.Now().Format(backupTimeFormat)
+	if err := os.Rename(path, corrupt); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("failed to move corrupt database aside: %w", err)
+	}
+	logger.Errorf("Moved corrupt database to %s", corrupt)
+
+	// Newest first
+	for i := len(backups) - 1; i >= 0; i-- {
+		if err := copyFile(backups[i], path); err != nil {
+			logger.Errorf("Failed to restore backup %s: %v", backups[i], err)
+			continue
+		}
+		db, err := openVerified(path)
+		if err != nil {
+			logger.Errorf("Backup %s is unusable: %v", backups[i], err)
+			continue
+		}
+		return db, backups[i], nil
+	}
+	return nil, "", fmt.Errorf("no usable backup among %d", len(backups))
+}
+
+// batchDelay is how long a batched write waits for others to share its commit. It is kept
+// short because state snapshots are saved while the endpoint's state is locked.
+const batchDelay = 2 * time.Millisecond
+
+// openBolt opens a bolt file. The hashmap freelist keeps allocation fast as history cleanup
+// leaves many free pages behind.
+func openBolt(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second, FreelistType: bolt.FreelistMapType})
+	if err != nil {
+		return nil, err
+	}
+	db.MaxBatchDelay = batchDelay
+	return db, nil
+}
+
+// compact rewrites the database into a fresh file, reclaiming pages freed by history cleanup.
+// db must be the only handle on path; it is closed and the compacted file is reopened.
+func compact(db *bolt.DB, path string) (*bolt.DB, error) {
+	tmp := path + ".compact"
+	os.Remove(tmp)
+
+	dst, err := openBolt(tmp)
+	if err != nil {
+		return db, err
+	}
+	if err := bolt.Compact(dst, db, compactTxMaxSize); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return db, err
+	}
+	dst.Close()
+	db.Close()
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		reopened, openErr := openBolt(path)
+		if openErr != nil {
+			return nil, openErr
+		}
+		return reopened, err
+	}
+	return openBolt(path)
+}
+
+// compactIfDue compacts the database when the last compaction is older than compactionInterval.
+// Only a failure to reopen the database is returned; a failed compaction keeps the original file.
+func (d *Database) compactIfDue() error {
+	var last time.Time
+	if _, err := d.GetSetting(LastCompactionSettingKey, &last); err != nil {
+		logger.Errorf("Failed to read last compaction time: %v", err)
+		return nil
+	}
+	if time.Since(last) < compactionInterval {
+		return nil
+	}
+
+	before := fileSize(d.path)
+	db, err := compact(d.db, d.path)
+	if db == nil {
+		return fmt.Errorf("failed to reopen database after compaction: %w", err)
+	}
+	d.db = db
+	if err != nil {
+		logger.Errorf("Database compaction failed: %v", err)
+		return nil
+	}
+
+	if err := d.SaveSetting(LastCompactionSettingKey, time.Now()); err != nil {
+		logger.Errorf("Failed to record compaction time: %v", err)
+	}
+	logger.Infof("Compacted database from %d to %d bytes", before, fileSize(d.path))
+	return nil
+}
+
+// Backup copies the database to a timestamped file next to it, keeping the newest maxBackups
+func (d *Database) Backup() (string, error) {
+	name := d.path + backupMarker + time.Now().Format(backupTimeFormat)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(name, 0600)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	backups, err := listBackups(d.path)
+	if err != nil {
+		return name, err
+	}
+	for len(backups) > maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			logger.Errorf("Failed to remove old backup %s: %v", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+	return name, nil
+}
+
+// backupIfDue takes a backup when the newest one is older than backupInterval
+func (d *Database) backupIfDue() {
+	if last := d.lastBackup(); last != nil && time.Since(*last) < backupInterval {
+		return
+	}
+	name, err := d.Backup()
+	if err != nil {
+		logger.Errorf("Database backup failed: %v", err)
+		return
+	}
+	logger.Infof("Backed up database to %s", name)
+}
+
+// lastBackup returns the time of the newest backup, or nil if there is none
+func (d *Database) lastBackup() *time.Time {
+	backups, err := listBackups(d.path)
+	if err != nil || len(backups) == 0 {
+		return nil
+	}
+	newest := backups[len(backups)-1]
+	t, err := time.ParseInLocation(backupTimeFormat, newest[strings.LastIndex(newest, backupMarker)+len(backupMarker):], time.Local)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// Health reports file size, integrity, per-bucket key counts, compaction and backups
+func (d *Database) Health() (*DBHealth, error) {
+	health := &DBHealth{
+		Path:          d.path,
+		FileSize:      fileSize(d.path),
+		Integrity:     "ok",
+		Buckets:       make(map[string]int),
+		LastBackup:    d.lastBackup(),
+		RecoveredFrom: d.recoveredFrom,
+	}
+
+	var last time.Time
+	if found, err := d.GetSetting(LastCompactionSettingKey, &last); err == nil && found {
+		health.LastCompaction = &last
+	}
+
+	backups, err := listBackups(d.path)
+	if err != nil {
+		return nil, err
+	}
+	health.Backups = make([]string, 0, len(backups))
+	for _, backup := range backups {
+		health.Backups = append(health.Backups, filepath.Base(backup))
+	}
+
+	if err := checkIntegrity(d.db); err != nil {
+		health.Integrity = err.Error()
+	}
+
+	err = d.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			health.Buckets[string(name)] = b.Stats().KeyN
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+// listBackups returns the backups of the database at path, oldest first
+func listBackups(path string) ([]string, error) {
+	backups, err := filepath.Glob(path + backupMarker + "*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// copyFile copies src to dst, replacing dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// fileSize returns the size of the file at path, or 0 if it cannot be read
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
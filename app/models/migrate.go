@@ -0,0 +1,267 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	bolt "go.etcd.io/bbolt"
+)
+
+// StorageBackends lists the storage backends data can be migrated from and to
+var StorageBackends = []string{"bolt"}
+
+const (
+	// migrateBatchKeys is how many keys are copied per write transaction
+	migrateBatchKeys = 5000
+	// migrateProgressKeys is how often progress is logged within a large bucket
+	migrateProgressKeys = 50000
+)
+
+// StorageSpec names a storage backend and where its data lives
+type StorageSpec struct {
+	Backend  string
+	Location string
+}
+
+func (s StorageSpec) String() string {
+	return s.Backend + ":" + s.Location
+}
+
+// ParseStorageSpec parses backend or backend:location; a bare bolt means the file at defaultPath
+func ParseStorageSpec(spec, defaultPath string) (StorageSpec, error) {
+	backend, location, _ := strings.Cut(spec, ":")
+	backend = strings.ToLower(strings.TrimSpace(backend))
+	if backend != "bolt" {
+		return StorageSpec{}, fmt.Errorf("storage backend %q is not available, supported: %s", backend, strings.Join(StorageBackends, ", "))
+	}
+	if location == "" {
+		location = defaultPath
+	}
+	return StorageSpec{Backend: backend, Location: location}, nil
+}
+
+// ParseMigrationArgs reads the from= and to= arguments of --migrate-storage
+func ParseMigrationArgs(args []string, defaultPath string) (from, to StorageSpec, err error) {
+	var fromSpec, toSpec string
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		switch {
+		case ok && key == "from":
+			fromSpec = value
+		case ok && key == "to":
+			toSpec = value
+		default:
+			return from, to, fmt.Errorf("unexpected argument %q, want from=<backend> to=<backend>", arg)
+		}
+	}
+	if fromSpec == "" || toSpec == "" {
+		return from, to, errors.New("both from= and to= are required, e.g. from=bolt to=bolt:/var/lib/sitewatch/new.db")
+	}
+	if from, err = ParseStorageSpec(fromSpec, defaultPath); err != nil {
+		return from, to, err
+	}
+	if to, err = ParseStorageSpec(toSpec, defaultPath); err != nil {
+		return from, to, err
+	}
+	if from == to {
+		return from, to, errors.New("from and to are the same storage")
+	}
+	return from, to, nil
+}
+
+// BucketMigration reports the copy of one bucket
+type BucketMigration struct {
+	Bucket string
+	Keys   int
+}
+
+// MigrateStorage copies endpoints, history, settings and every other bucket from one storage
+// to another, logging progress, then verifies that the copy matches the source key for key.
+// The destination must not exist yet, and SiteWatch must not be running on the source.
+func MigrateStorage(from, to StorageSpec) (report []BucketMigration, err error) {
+	if _, err := os.Stat(to.Location); err == nil {
+		return nil, fmt.Errorf("%s already exists, refusing to overwrite it", to.Location)
+	}
+
+	src, err := bolt.Open(from.Location, 0600, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("%s is in use, stop SiteWatch before migrating", from.Location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", from, err)
+	}
+	defer src.Close()
+	if err := checkIntegrity(src); err != nil {
+		return nil, fmt.Errorf("source %s: %w", from, err)
+	}
+
+	dst, err := openBolt(to.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", to, err)
+	}
+	// A failed migration leaves no half-written copy behind
+	defer func() {
+		dst.Close()
+		if err != nil {
+			os.Remove(to.Location)
+		}
+	}()
+
+	var buckets []string
+	src.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			buckets = append(buckets, string(name))
+			return nil
+		})
+	})
+
+	logger.Infof("Migrating %d buckets from %s to %s", len(buckets), from, to)
+	for i, bucket := range buckets {
+		keys, err := copyBucket(src, dst, bucket)
+		if err != nil {
+			return report, fmt.Errorf("copying %s: %w", bucket, err)
+		}
+		report = append(report, BucketMigration{Bucket: bucket, Keys: keys})
+		logger.Infof("[%d/%d] %s: %d keys copied", i+1, len(buckets), bucket, keys)
+	}
+
+	// Verify against the source before anyone starts SiteWatch on the copy
+	for _, bucket := range buckets {
+		want, err := bucketDigest(src, bucket)
+		if err != nil {
+			return report, err
+		}
+		got, err := bucketDigest(dst, bucket)
+		if err != nil {
+			return report, err
+		}
+		if want != got {
+			return report, fmt.Errorf("verification failed: %s differs from the source", bucket)
+		}
+	}
+	logger.Infof("Verified all %d buckets against the source", len(buckets))
+	return report, nil
+}
+
+// migrateEntry is a key waiting to be written, with the nested bucket path it belongs in
+type migrateEntry struct {
+	path  [][]byte
+	key   []byte
+	value []byte
+}
+
+// copyBucket copies a top-level bucket and its nested buckets in batches, returning the keys copied
+func copyBucket(src, dst *bolt.DB, name string) (int, error) {
+	var batch []migrateEntry
+	copied := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := dst.Update(func(tx *bolt.Tx) error {
+			for _, entry := range batch {
+				b, err := tx.CreateBucketIfNotExists(entry.path[0])
+				if err != nil {
+					return err
+				}
+				for _, nested := range entry.path[1:] {
+					if b, err = b.CreateBucketIfNotExists(nested); err != nil {
+						return err
+					}
+				}
+				if entry.value == nil {
+					// An empty nested bucket still has to exist in the copy
+					if _, err := b.CreateBucketIfNotExists(entry.key); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := b.Put(entry.key, entry.value); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		previous := copied
+		copied += len(batch)
+		if copied/migrateProgressKeys != previous/migrateProgressKeys {
+			logger.Infof("%s: %d keys copied so far", name, copied)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	var walk func(b *bolt.Bucket, path [][]byte) error
+	walk = func(b *bolt.Bucket, path [][]byte) error {
+		// Keys and values are cloned since bolt owns them only for the read transaction
+		return b.ForEach(func(k, v []byte) error {
+			if v == nil {
+				nestedPath := append(append([][]byte(nil), path...), bytes.Clone(k))
+				batch = append(batch, migrateEntry{path: path, key: bytes.Clone(k)})
+				return walk(b.Bucket(k), nestedPath)
+			}
+			batch = append(batch, migrateEntry{path: path, key: bytes.Clone(k), value: bytes.Clone(v)})
+			if len(batch) >= migrateBatchKeys {
+				return flush()
+			}
+			return nil
+		})
+	}
+
+	err := src.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(name))
+		// Create the bucket even when it is empty
+		if err := dst.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists([]byte(name))
+			return err
+		}); err != nil {
+			return err
+		}
+		if err := walk(b, [][]byte{[]byte(name)}); err != nil {
+			return err
+		}
+		return flush()
+	})
+	return copied, err
+}
+
+// bucketDigest hashes every key, value and nested bucket of a top-level bucket in order
+func bucketDigest(db *bolt.DB, name string) (string, error) {
+	hash := sha256.New()
+	var walk func(b *bolt.Bucket, depth int) error
+	walk = func(b *bolt.Bucket, depth int) error {
+		return b.ForEach(func(k, v []byte) error {
+			fmt.Fprintf(hash, "%d:%d:%x", depth, len(k), k)
+			if v == nil {
+				hash.Write([]byte("{"))
+				if err := walk(b.Bucket(k), depth+1); err != nil {
+					return err
+				}
+				hash.Write([]byte("}"))
+				return nil
+			}
+			fmt.Fprintf(hash, "=%d:", len(v))
+			hash.Write(v)
+			return nil
+		})
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(name))
+		if b == nil {
+			return fmt.Errorf("bucket %s is missing", name)
+		}
+		return walk(b, 0)
+	})
+	return hex.EncodeToString(hash.Sum(nil)), err
+}
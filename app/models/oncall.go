@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveOnCallSchedule saves or updates an on-call schedule
+func (d *Database) SaveOnCallSchedule(schedule *structs.OnCallSchedule) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OnCallBucket))
+
+		now := time.Now()
+		if schedule.CreatedAt.IsZero() {
+			schedule.CreatedAt = now
+		}
+		schedule.UpdatedAt = now
+
+		data, err := json.Marshal(schedule)
+		if err != nil {
+			return fmt.Errorf("failed to marshal on-call schedule: %w", err)
+		}
+
+		return b.Put([]byte(schedule.ID), data)
+	})
+}
+
+// GetOnCallSchedule retrieves an on-call schedule by ID
+func (d *Database) GetOnCallSchedule(id string) (*structs.OnCallSchedule, error) {
+	var schedule structs.OnCallSchedule
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OnCallBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("on-call schedule not found: %s", id)
+		}
+		return json.Unmarshal(data, &schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetAllOnCallSchedules retrieves all on-call schedules
+func (d *Database) GetAllOnCallSchedules() ([]*structs.OnCallSchedule, error) {
+	var schedules []*structs.OnCallSchedule
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OnCallBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var schedule structs.OnCallSchedule
+			if err := json.Unmarshal(v, &schedule); err != nil {
+				return err
+			}
+			schedules = append(schedules, &schedule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// DeleteOnCallSchedule removes an on-call schedule
+func (d *Database) DeleteOnCallSchedule(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OnCallBucket))
+		return b.Delete([]byte(id))
+	})
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveOutboxMessage stores a queued notification, assigning an ordered ID to new messages
+func (d *Database) SaveOutboxMessage(message *structs.OutboxMessage) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OutboxBucket))
+
+		if message.ID == "" {
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			message.ID = fmt.Sprintf("%016d", seq)
+		}
+		if message.CreatedAt.IsZero() {
+			message.CreatedAt = time.Now()
+		}
+
+		data, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox message: %w", err)
+		}
+
+		return b.Put([]byte(message.ID), data)
+	})
+}
+
+// GetOutboxMessage retrieves a queued notification by ID
+func (d *Database) GetOutboxMessage(id string) (*structs.OutboxMessage, error) {
+	var message structs.OutboxMessage
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OutboxBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("outbox message not found: %s", id)
+		}
+		return json.Unmarshal(data, &message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// GetOutboxMessages retrieves queued notifications with the given status, oldest first;
+// an empty status returns every message
+func (d *Database) GetOutboxMessages(status string) ([]*structs.OutboxMessage, error) {
+	var messages []*structs.OutboxMessage
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OutboxBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var message structs.OutboxMessage
+			if err := json.Unmarshal(v, &message); err != nil {
+				return err
+			}
+			if status == "" || message.Status == status {
+				messages = append(messages, &message)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetAlertDeliveries retrieves the outbox messages of one alert, oldest first
+func (d *Database) GetAlertDeliveries(alertID string) ([]*structs.OutboxMessage, error) {
+	var messages []*structs.OutboxMessage
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OutboxBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var message structs.OutboxMessage
+			if err := json.Unmarshal(v, &message); err != nil {
+				return err
+			}
+			if message.AlertID == alertID {
+				messages = append(messages, &message)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeleteOutboxMessage removes a queued notification
+func (d *Database) DeleteOutboxMessage(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(OutboxBucket))
+		return b.Delete([]byte(id))
+	})
+}
+
+// cleanupOutbox removes delivered and dead messages created before cutoff; pending ones are kept
+func cleanupOutbox(tx *bolt.Tx, cutoff time.Time) (int, error) {
+	b := tx.Bucket([]byte(OutboxBucket))
+
+	var keysToDelete [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var message structs.OutboxMessage
+		if err := json.Unmarshal(v, &message); err != nil {
+			return nil
+		}
+		if message.Status != structs.OutboxPending && message.CreatedAt.Before(cutoff) {
+			keysToDelete = append(keysToDelete, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keysToDelete {
+		if err := b.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	return len(keysToDelete), nil
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// storedProject persists the API key hash that is hidden from API responses
+type storedProject struct {
+	*structs.Project
+	APIKeyHash string `json:"api_key_hash"`
+}
+
+// decodeProject decodes a stored project including its API key hash
+func decodeProject(data []byte) (*structs.Project, error) {
+	stored := storedProject{Project: &structs.Project{}}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	stored.Project.APIKeyHash = stored.APIKeyHash
+	return stored.Project, nil
+}
+
+// SaveProject saves or updates a project
+func (d *Database) SaveProject(project *structs.Project) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ProjectsBucket))
+
+		now := time.Now()
+		if project.CreatedAt.IsZero() {
+			project.CreatedAt = now
+		}
+		project.UpdatedAt = now
+
+		data, err := json.Marshal(storedProject{project, project.APIKeyHash})
+		if err != nil {
+			return fmt.Errorf("failed to marshal project: %w", err)
+		}
+
+		return b.Put([]byte(project.ID), data)
+	})
+}
+
+// GetProject retrieves a project by ID
+func (d *Database) GetProject(id string) (*structs.Project, error) {
+	var project *structs.Project
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ProjectsBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("project not found: %s", id)
+		}
+		var err error
+		project, err = decodeProject(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetAllProjects retrieves all projects
+func (d *Database) GetAllProjects() ([]*structs.Project, error) {
+	var projects []*structs.Project
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ProjectsBucket))
+		return b.ForEach(func(k, v []byte) error {
+			project, err := decodeProject(v)
+			if err != nil {
+				return err
+			}
+			projects = append(projects, project)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// GetProjectByAPIKey finds the project owning an API key
+func (d *Database) GetProjectByAPIKey(key string) (*structs.Project, error) {
+	projects, err := d.GetAllProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := utils.HashAPIKey(key)
+	for _, project := range projects {
+		if subtle.ConstantTimeCompare([]byte(project.APIKeyHash), []byte(hash)) == 1 {
+			return project, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid API key")
+}
+
+// DeleteProject removes a project
+func (d *Database) DeleteProject(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ProjectsBucket))
+		return b.Delete([]byte(id))
+	})
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SavePushSubscription saves or updates a Web Push subscription
+func (d *Database) SavePushSubscription(sub *structs.PushSubscription) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushBucket))
+
+		if sub.CreatedAt.IsZero() {
+			sub.CreatedAt = time.Now()
+		}
+
+		data, err := json.Marshal(sub)
+		if err != nil {
+			return fmt.Errorf("failed to marshal push subscription: %w", err)
+		}
+
+		return b.Put([]byte(sub.ID), data)
+	})
+}
+
+// GetAllPushSubscriptions retrieves all Web Push subscriptions
+func (d *Database) GetAllPushSubscriptions() ([]*structs.PushSubscription, error) {
+	var subs []*structs.PushSubscription
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var sub structs.PushSubscription
+			if err := json.Unmarshal(v, &sub); err != nil {
+				return err
+			}
+			subs = append(subs, &sub)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// DeletePushSubscription removes a Web Push subscription
+func (d *Database) DeletePushSubscription(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(PushBucket))
+		return b.Delete([]byte(id))
+	})
+}
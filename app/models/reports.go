@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveReport stores a generated report, replacing an earlier one with the same ID
+func (d *Database) SaveReport(report *structs.Report) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ReportsBucket))
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+
+		return b.Put([]byte(report.ID), data)
+	})
+}
+
+// GetReport retrieves a stored report by ID
+func (d *Database) GetReport(id string) (*structs.Report, error) {
+	var report structs.Report
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ReportsBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("report not found: %s", id)
+		}
+		return json.Unmarshal(data, &report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetAllReports retrieves every stored report, newest month first
+func (d *Database) GetAllReports() ([]*structs.Report, error) {
+	var reports []*structs.Report
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ReportsBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var report structs.Report
+			if err := json.Unmarshal(v, &report); err != nil {
+				return err
+			}
+			reports = append(reports, &report)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Month != reports[j].Month {
+			return reports[i].Month > reports[j].Month
+		}
+		return reports[i].Name < reports[j].Name
+	})
+	return reports, nil
+}
+
+// DeleteReport removes a stored report
+func (d *Database) DeleteReport(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ReportsBucket))
+		return b.Delete([]byte(id))
+	})
+}
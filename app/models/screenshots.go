@@ -0,0 +1,135 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// latestScreenshotPrefix marks the pointer to an endpoint's newest screenshot of a passing check
+const latestScreenshotPrefix = "latest:"
+
+// SaveScreenshot stores a browser check screenshot under the same key as its history record.
+// Screenshots of failed checks are kept for the history retention period; of passing checks
+// only the newest is kept, so an endpoint that stays healthy holds a single image.
+func (d *Database) SaveScreenshot(endpointID string, at time.Time, image []byte, failed bool) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ScreenshotsBucket))
+
+		key := []byte(fmt.Sprintf("%s:%d", endpointID, at.UnixNano()))
+		if err := b.Put(key, image); err != nil {
+			return err
+		}
+
+		pointer := []byte(latestScreenshotPrefix + endpointID)
+		if previous := b.Get(pointer); previous != nil {
+			if err := b.Delete(append([]byte(nil), previous...)); err != nil {
+				return err
+			}
+		}
+		if failed {
+			return b.Delete(pointer)
+		}
+		return b.Put(pointer, key)
+	})
+}
+
+// GetScreenshot retrieves the screenshot taken by an endpoint's check at the given time
+func (d *Database) GetScreenshot(endpointID string, at time.Time) ([]byte, error) {
+	var image []byte
+	err := d.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket([]byte(ScreenshotsBucket)).Get([]byte(fmt.Sprintf("%s:%d", endpointID, at.UnixNano())))
+		if data == nil {
+			return fmt.Errorf("screenshot not found")
+		}
+		image = append([]byte(nil), data...)
+		return nil
+	})
+	return image, err
+}
+
+// GetLatestScreenshot retrieves an endpoint's newest screenshot and when it was taken
+func (d *Database) GetLatestScreenshot(endpointID string) ([]byte, time.Time, error) {
+	var image []byte
+	var at time.Time
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(ScreenshotsBucket)).Cursor()
+
+		// ';' sorts right after ':', so the key before it is the endpoint's newest
+		prefix := []byte(endpointID + ":")
+		k, v := c.Seek([]byte(endpointID + ";"))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return fmt.Errorf("screenshot not found")
+		}
+
+		nanos, err := strconv.ParseInt(string(k[len(prefix):]), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid screenshot key %q", k)
+		}
+		at = time.Unix(0, nanos)
+		image = append([]byte(nil), v...)
+		return nil
+	})
+	return image, at, err
+}
+
+// deleteScreenshots removes every screenshot of an endpoint
+func deleteScreenshots(tx *bolt.Tx, endpointID string) error {
+	b := tx.Bucket([]byte(ScreenshotsBucket))
+	if err := b.Delete([]byte(latestScreenshotPrefix + endpointID)); err != nil {
+		return err
+	}
+
+	prefix := []byte(endpointID + ":")
+	c := b.Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanupScreenshots removes screenshots taken before cutoff, along with pointers to them
+func cleanupScreenshots(tx *bolt.Tx, cutoff time.Time) (int, error) {
+	b := tx.Bucket([]byte(ScreenshotsBucket))
+
+	var keysToDelete [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		key := string(k)
+		if strings.HasPrefix(key, latestScreenshotPrefix) {
+			key = string(v)
+		}
+		i := strings.LastIndex(key, ":")
+		if i < 0 {
+			return nil
+		}
+		nanos, err := strconv.ParseInt(key[i+1:], 10, 64)
+		if err != nil {
+			return nil
+		}
+		if time.Unix(0, nanos).Before(cutoff) {
+			keysToDelete = append(keysToDelete, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range keysToDelete {
+		if err := b.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	return len(keysToDelete), nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveService saves or updates a service
+func (d *Database) SaveService(service *structs.Service) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ServicesBucket))
+
+		now := time.Now()
+		if service.CreatedAt.IsZero() {
+			service.CreatedAt = now
+		}
+		service.UpdatedAt = now
+
+		if service.Policy == "" {
+			service.Policy = structs.PolicyAllHealthy
+		}
+
+		data, err := json.Marshal(service)
+		if err != nil {
+			return fmt.Errorf("failed to marshal service: %w", err)
+		}
+
+		return b.Put([]byte(service.ID), data)
+	})
+}
+
+// GetService retrieves a service by ID
+func (d *Database) GetService(id string) (*structs.Service, error) {
+	var service structs.Service
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ServicesBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("service not found: %s", id)
+		}
+		return json.Unmarshal(data, &service)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+// GetAllServices retrieves all services
+func (d *Database) GetAllServices() ([]*structs.Service, error) {
+	var services []*structs.Service
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ServicesBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var service structs.Service
+			if err := json.Unmarshal(v, &service); err != nil {
+				return err
+			}
+			services = append(services, &service)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// DeleteService removes a service
+func (d *Database) DeleteService(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(ServicesBucket))
+		return b.Delete([]byte(id))
+	})
+}
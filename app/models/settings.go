@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// TOTPSettingKey stores the admin TOTP enrollment in the settings bucket
+const TOTPSettingKey = "admin_totp"
+
+// SaveSetting stores a JSON-encoded value in the settings bucket
+func (d *Database) SaveSetting(key string, value interface{}) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(SettingsBucket))
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal setting %s: %w", key, err)
+		}
+
+		return b.Put([]byte(key), data)
+	})
+}
+
+// GetSetting decodes a value from the settings bucket, returning false if it is not set
+func (d *Database) GetSetting(key string, value interface{}) (bool, error) {
+	found := false
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(SettingsBucket))
+		data := b.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, value)
+	})
+	return found, err
+}
+
+// DeleteSetting removes a value from the settings bucket
+func (d *Database) DeleteSetting(key string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(SettingsBucket))
+		return b.Delete([]byte(key))
+	})
+}
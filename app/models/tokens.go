@@ -0,0 +1,90 @@
+package models
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// storedAPIToken persists the token hash that is hidden from API responses
+type storedAPIToken struct {
+	*structs.APIToken
+	TokenHash string `json:"token_hash"`
+}
+
+// decodeAPIToken decodes a stored token including its hash
+func decodeAPIToken(data []byte) (*structs.APIToken, error) {
+	stored := storedAPIToken{APIToken: &structs.APIToken{}}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	stored.APIToken.TokenHash = stored.TokenHash
+	return stored.APIToken, nil
+}
+
+// SaveAPIToken saves or updates an API token
+func (d *Database) SaveAPIToken(token *structs.APIToken) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TokensBucket))
+
+		if token.CreatedAt.IsZero() {
+			token.CreatedAt = time.Now()
+		}
+
+		data, err := json.Marshal(storedAPIToken{token, token.TokenHash})
+		if err != nil {
+			return fmt.Errorf("failed to marshal token: %w", err)
+		}
+
+		return b.Put([]byte(token.ID), data)
+	})
+}
+
+// GetAllAPITokens retrieves all API tokens
+func (d *Database) GetAllAPITokens() ([]*structs.APIToken, error) {
+	var tokens []*structs.APIToken
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TokensBucket))
+		return b.ForEach(func(k, v []byte) error {
+			token, err := decodeAPIToken(v)
+			if err != nil {
+				return err
+			}
+			tokens = append(tokens, token)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetAPITokenByValue finds the stored token matching a bearer token
+func (d *Database) GetAPITokenByValue(value string) (*structs.APIToken, error) {
+	tokens, err := d.GetAllAPITokens()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := utils.HashAPIKey(value)
+	for _, token := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token.TokenHash), []byte(hash)) == 1 {
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid API token")
+}
+
+// DeleteAPIToken revokes an API token
+func (d *Database) DeleteAPIToken(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TokensBucket))
+		return b.Delete([]byte(id))
+	})
+}
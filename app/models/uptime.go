@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// Sources an uptime window is computed from
+const (
+	UptimeSourceHistory = "history"
+	UptimeSourceDaily   = "daily_rollups"
+)
+
+// Uptime summarises an endpoint's availability, downtime and time to repair over a window
+type Uptime struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	Source          string    `json:"source"`
+	Checks          int       `json:"checks"`
+	HealthyChecks   int       `json:"healthy_checks"`
+	UnhealthyChecks int       `json:"unhealthy_checks"`
+	UptimePercent   *float64  `json:"uptime_percent"`
+	DowntimeSeconds float64   `json:"downtime_seconds"`
+	Incidents       int       `json:"incidents"`
+	Resolved        int       `json:"resolved_incidents"`
+	MTTRSeconds     *float64  `json:"mttr_seconds"`
+	// repairSeconds totals the time to repair of resolved incidents
+	repairSeconds float64
+}
+
+// finish derives the percentages and means once the counts are in
+func (u *Uptime) finish() {
+	if total := u.HealthyChecks + u.UnhealthyChecks; total > 0 {
+		uptime := float64(u.HealthyChecks) / float64(total) * 100
+		u.UptimePercent = &uptime
+	}
+	if u.Resolved > 0 {
+		mttr := u.repairSeconds / float64(u.Resolved)
+		u.MTTRSeconds = &mttr
+	}
+}
+
+// UptimeFromRecords computes uptime over [from, now) from raw history. Downtime runs from the
+// first unhealthy record of an incident to the next healthy one, or to now while it is open.
+func UptimeFromRecords(records []*structs.HealthCheckRecord, from, now time.Time) Uptime {
+	uptime := Uptime{From: from, To: now, Source: UptimeSourceHistory}
+	records = RecordsBetween(records, from, now)
+	uptime.Checks = CountChecks(records)
+	for _, record := range records {
+		switch structs.HealthStatus(record.Status) {
+		case structs.StatusHealthy:
+			uptime.HealthyChecks += record.Weight()
+		case structs.StatusUnhealthy:
+			uptime.UnhealthyChecks += record.Weight()
+		}
+	}
+
+	for _, incident := range DetectIncidents(records) {
+		uptime.Incidents++
+		if incident.End == nil {
+			uptime.DowntimeSeconds += now.Sub(incident.Start).Seconds()
+			continue
+		}
+		repair := incident.End.Sub(incident.Start).Seconds()
+		uptime.DowntimeSeconds += repair
+		uptime.Resolved++
+		uptime.repairSeconds += repair
+	}
+	uptime.finish()
+	return uptime
+}
+
+// UptimeFromDailyStats computes uptime from daily rollups starting at from, the start of a UTC day
+func UptimeFromDailyStats(days []*DailyStats, from, now time.Time) Uptime {
+	uptime := Uptime{From: from, To: now, Source: UptimeSourceDaily}
+	for _, day := range days {
+		uptime.Checks += day.Checks
+		uptime.HealthyChecks += day.Healthy
+		uptime.UnhealthyChecks += day.Unhealthy
+		uptime.DowntimeSeconds += day.DowntimeSeconds
+		uptime.Incidents += day.Incidents
+		uptime.Resolved += day.Resolved
+		uptime.repairSeconds += day.RepairSeconds
+	}
+	// An incident still open has been down since the last check, too
+	if len(days) > 0 {
+		if last := days[len(days)-1]; last.Down && now.After(last.LastCheck) {
+			uptime.DowntimeSeconds += now.Sub(last.LastCheck).Seconds()
+		}
+	}
+	uptime.finish()
+	return uptime
+}
+
+// GetUptime computes an endpoint's uptime over the window ending now. Windows within the
+// history retention are computed from raw history; longer ones from the daily rollups of
+// whole UTC days, the current day included.
+func (d *Database) GetUptime(endpointID string, window time.Duration, now time.Time) (Uptime, error) {
+	if window <= DataRetentionDays*24*time.Hour {
+		from := now.Add(-window)
+		records, err := d.GetHealthHistoryRange(endpointID, from, now)
+		if err != nil {
+			return Uptime{}, err
+		}
+		return UptimeFromRecords(records, from, now), nil
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	days := int((window + 24*time.Hour - 1) / (24 * time.Hour))
+	from := today.AddDate(0, 0, 1-days)
+	stats, err := d.GetDailyStats(endpointID, from, today.AddDate(0, 0, 1))
+	if err != nil {
+		return Uptime{}, err
+	}
+	return UptimeFromDailyStats(stats, from, now), nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveUser saves or updates a user
+func (d *Database) SaveUser(user *structs.User) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(UsersBucket))
+
+		now := time.Now()
+		if user.CreatedAt.IsZero() {
+			user.CreatedAt = now
+		}
+		user.UpdatedAt = now
+
+		data, err := json.Marshal(user)
+		if err != nil {
+			return fmt.Errorf("failed to marshal user: %w", err)
+		}
+
+		return b.Put([]byte(user.ID), data)
+	})
+}
+
+// GetUser retrieves a user by ID
+func (d *Database) GetUser(id string) (*structs.User, error) {
+	var user structs.User
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(UsersBucket))
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("user not found: %s", id)
+		}
+		return json.Unmarshal(data, &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetAllUsers retrieves all users
+func (d *Database) GetAllUsers() ([]*structs.User, error) {
+	var users []*structs.User
+	err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(UsersBucket))
+		return b.ForEach(func(k, v []byte) error {
+			var user structs.User
+			if err := json.Unmarshal(v, &user); err != nil {
+				return err
+			}
+			users = append(users, &user)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// DeleteUser removes a user
+func (d *Database) DeleteUser(id string) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(UsersBucket))
+		return b.Delete([]byte(id))
+	})
+}
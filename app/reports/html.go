@@ -0,0 +1,95 @@
+package reports
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": formatPercent,
+	"ms":      func(v float64) string { return fmt.Sprintf("%.0f ms", v) },
+	"join":    strings.Join,
+	"scope":   describeFilter,
+	"slaMet":  func(e structs.ReportEndpoint) bool { return e.SLAMet != nil && *e.SLAMet },
+	"slaMiss": func(e structs.ReportEndpoint) bool { return e.SLAMet != nil && !*e.SLAMet },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} availability report {{.Month}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2933; margin: 2em; }
+h1 { font-size: 1.5em; margin-bottom: 0.2em; }
+.meta { color: #616e7c; margin-bottom: 1.5em; }
+.summary { display: flex; gap: 2em; margin-bottom: 1.5em; }
+.summary div { font-size: 1.2em; }
+.summary span { display: block; font-size: 0.7em; color: #616e7c; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 6px 10px; border-bottom: 1px solid #e4e7eb; }
+td.num, th.num { text-align: right; }
+.met { color: #1e7b34; }
+.missed { color: #c62828; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Name}} &mdash; {{.Month}}</h1>
+<div class="meta">Scope: {{scope .Filter}} &middot; Generated {{.GeneratedAt.UTC.Format "2006-01-02 15:04 MST"}} &middot; Month boundaries in UTC</div>
+<div class="summary">
+<div>{{percent .Availability}}<span>Availability</span></div>
+<div>{{len .Endpoints}}<span>Endpoints</span></div>
+<div>{{.Checks}}<span>Checks</span></div>
+<div>{{.SLABreaches}}<span>SLA breaches</span></div>
+</div>
+<table>
+<tr><th>Endpoint</th><th>Project</th><th>Tags</th><th class="num">Checks</th><th class="num">Failed</th><th class="num">Availability</th><th class="num">Avg response</th><th class="num">SLA target</th><th>SLA</th></tr>
+{{range .Endpoints}}<tr>
+<td>{{.Name}}<br><small>{{.URL}}</small></td>
+<td>{{.ProjectID}}</td>
+<td>{{join .Tags ", "}}</td>
+<td class="num">{{.Checks}}</td>
+<td class="num">{{.Unhealthy}}</td>
+<td class="num">{{percent .Availability}}</td>
+<td class="num">{{ms .AvgResponseMs}}</td>
+<td class="num">{{if .SLATarget}}{{percent .SLATarget}}{{end}}</td>
+<td>{{if slaMet .}}<span class="met">met</span>{{else if slaMiss .}}<span class="missed">missed</span>{{end}}</td>
+</tr>
+{{else}}<tr><td colspan="9">No endpoints match this report.</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteHTML renders a report as a standalone HTML page
+func WriteHTML(w io.Writer, report *structs.Report) error {
+	return htmlTemplate.Execute(w, report)
+}
+
+// formatPercent formats an availability percentage, showing n/a when there was no data
+func formatPercent(v float64) string {
+	if v < 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.3f%%", v)
+}
+
+// describeFilter summarizes the tags and projects a report covers
+func describeFilter(filter structs.Subscription) string {
+	var parts []string
+	if len(filter.Projects) > 0 {
+		parts = append(parts, "projects "+strings.Join(filter.Projects, ", "))
+	}
+	if len(filter.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(filter.Tags, ", "))
+	}
+	if filter.MinPriority != "" {
+		parts = append(parts, "priority "+string(filter.MinPriority)+" and above")
+	}
+	if len(parts) == 0 {
+		return "all endpoints"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,145 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// A4 portrait in points
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+	pdfFontSize   = 8
+	pdfLineHeight = 11
+	// pdfLinesPerPage is how many text lines fit between the top and bottom margins
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+	// pdfLineWidth is how many monospaced characters fit between the side margins
+	pdfLineWidth = (pdfPageWidth - 2*pdfMargin) * 10 / (pdfFontSize * 6)
+)
+
+// RenderPDF renders a report as a plain-text PDF document
+func RenderPDF(report *structs.Report) []byte {
+	return writePDF(reportLines(report))
+}
+
+// reportLines lays a report out as monospaced text lines
+func reportLines(report *structs.Report) []string {
+	lines := []string{
+		fmt.Sprintf("%s - availability report %s", report.Name, report.Month),
+		"Scope: " + describeFilter(report.Filter),
+		"Generated " + report.GeneratedAt.UTC().Format("2006-01-02 15:04 MST") + ", month boundaries in UTC",
+		"",
+		fmt.Sprintf("Availability: %s   Endpoints: %d   Checks: %d   SLA breaches: %d",
+			formatPercent(report.Availability), len(report.Endpoints), report.Checks, report.SLABreaches),
+		"",
+		fmt.Sprintf("%-34s %8s %7s %9s %8s %8s %6s", "Endpoint", "Checks", "Failed", "Avail.", "Avg ms", "Target", "SLA"),
+		strings.Repeat("-", 86),
+	}
+
+	for _, ep := range report.Endpoints {
+		target, sla := "", ""
+		if ep.SLATarget > 0 {
+			target = fmt.Sprintf("%.2f%%", ep.SLATarget)
+		}
+		if ep.SLAMet != nil {
+			sla = "met"
+			if !*ep.SLAMet {
+				sla = "MISSED"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%-34s %8d %7d %9s %8.0f %8s %6s",
+			truncate(ep.Name, 34), ep.Checks, ep.Unhealthy, formatPercent(ep.Availability), ep.AvgResponseMs, target, sla))
+		lines = append(lines, "  "+truncate(ep.URL, pdfLineWidth-2))
+	}
+	if len(report.Endpoints) == 0 {
+		lines = append(lines, "No endpoints match this report.")
+	}
+	return lines
+}
+
+// writePDF builds a PDF of Courier text lines, paginated onto A4 pages
+func writePDF(lines []string) []byte {
+	var pages [][]string
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1-3 are the catalog, page tree and font; each page adds a page and a content stream
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	)
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) '\n", escapePDF(line))
+		}
+		fmt.Fprintf(&content, "ET\nBT /F1 %d Tf %d %d Td (Page %d of %d) Tj ET\n", pdfFontSize, pdfPageWidth-pdfMargin-80, pdfMargin/2, i+1, len(pages))
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// escapePDF escapes a line for a PDF string literal; characters outside Latin-1 become '?'
+func escapePDF(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r < 0x100:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "~"
+}
@@ -0,0 +1,70 @@
+package router
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// ipAllowlist limits routes that change settings to clients in the admin_allowed_cidrs
+type ipAllowlist struct {
+	allowed []*net.IPNet
+	proxies []*net.IPNet
+}
+
+// newIPAllowlist builds the allowlist from the config, or returns nil when none is configured
+func newIPAllowlist(config *structs.Config) *ipAllowlist {
+	if len(config.AdminAllowedCIDRs) == 0 {
+		return nil
+	}
+	// Both lists were validated when the config was loaded
+	allowed, _ := utils.ParseCIDRs(config.AdminAllowedCIDRs)
+	proxies, _ := utils.ParseCIDRs(config.TrustedProxies)
+	return &ipAllowlist{allowed: allowed, proxies: proxies}
+}
+
+// wrap rejects requests from clients outside the allowlist with 403
+func (a *ipAllowlist) wrap(next http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		ip := a.clientIP(req)
+		if ip == nil || !utils.ContainsIP(a.allowed, ip) {
+			logger.Infof("Refused %s %s from %s: address not in admin_allowed_cidrs", req.Method, req.URL.Path, ip)
+			http.Error(w, "Forbidden: this address may not change settings", http.StatusForbidden)
+			return
+		}
+		next(w, req)
+	}
+}
+
+// clientIP returns the client address. Behind a trusted proxy it is the last X-Forwarded-For
+// entry not added by a trusted proxy, since earlier entries can be forged by the client.
+func (a *ipAllowlist) clientIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !utils.ContainsIP(a.proxies, ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if hop == nil {
+			return nil
+		}
+		ip = hop
+		if !utils.ContainsIP(a.proxies, hop) {
+			break
+		}
+	}
+	return ip
+}
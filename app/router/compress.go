@@ -0,0 +1,175 @@
+package router
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// gzipMinSize is the smallest response worth compressing
+const gzipMinSize = 1024
+
+// gzipWriters reuses compressors across responses
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	},
+}
+
+// gzipResponseWriter buffers the start of a response and compresses it once it is large enough
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz      *gzip.Writer
+	buf     []byte
+	status  int
+	decided bool
+}
+
+// compress gzips responses for clients that accept it
+func compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(req) || req.Method == http.MethodHead || req.Header.Get("Range") != "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		next.ServeHTTP(gw, req)
+	})
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip
+func acceptsGzip(req *http.Request) bool {
+	for _, part := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// WriteHeader records the status; headers are sent once the encoding is decided
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write buffers until gzipMinSize bytes are seen, then streams compressed output
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < gzipMinSize {
+			return len(p), nil
+		}
+		w.decide(true)
+		if err := w.flushBuffer(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush sends buffered data so streaming handlers keep working
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		w.decide(len(w.buf) >= gzipMinSize)
+		w.flushBuffer()
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// decide chooses whether to compress and sends the headers
+func (w *gzipResponseWriter) decide(large bool) {
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	h := w.Header()
+	if large && compressible(w.status, h) {
+		// Sniff before compressing, otherwise net/http would detect gzip
+		if h.Get("Content-Type") == "" {
+			h.Set("Content-Type", http.DetectContentType(w.buf))
+		}
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		// Strong validators describe the uncompressed bytes
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		gz := gzipWriters.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.gz = gz
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// flushBuffer writes buffered bytes through the chosen encoding
+func (w *gzipResponseWriter) flushBuffer() error {
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.gz != nil {
+		_, err = w.gz.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// close finishes the response, sending small bodies uncompressed
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		if w.status == 0 && len(w.buf) == 0 {
+			return
+		}
+		w.decide(false)
+		w.flushBuffer()
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// compressible reports whether a response should be gzipped
+func compressible(status int, h http.Header) bool {
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	for _, prefix := range []string{"text/", "application/json", "application/javascript", "application/xml", "image/svg+xml"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/ashanmugaraja/cronzee/app/handler"
 	"github.com/ashanmugaraja/cronzee/app/models"
@@ -13,7 +14,11 @@ import (
 // Router handles HTTP routing
 type Router struct {
 	mux           *http.ServeMux
+	handler       http.Handler
 	healthHandler *handler.HealthHandler
+	allowlist     *ipAllowlist
+	dashboard     string
+	dashboardETag string
 }
 
 // NewRouter creates a new router
@@ -21,40 +26,139 @@ func NewRouter(monitor *worker.Monitor, db *models.Database, config *structs.Con
 	router := &Router{
 		mux:           http.NewServeMux(),
 		healthHandler: handler.NewHealthHandler(monitor, db, config),
+		allowlist:     newIPAllowlist(config),
 	}
 
+	router.dashboard, router.dashboardETag = renderDashboard(router.healthHandler.CSRFToken())
 	router.setupRoutes()
+	router.handler = compress(localizeTimes(standbyReadOnly(monitor, router.mux)))
 	return router
 }
 
 // setupRoutes configures all application routes
 func (r *Router) setupRoutes() {
+	// Bearer tokens are limited to the scope each route requires; write and admin routes
+	// are also limited to the admin_allowed_cidrs and need the CSRF token from browsers
+	read := func(h http.HandlerFunc) http.HandlerFunc {
+		return r.healthHandler.RequireScope(structs.ScopeReadStatus, h)
+	}
+	write := func(h http.HandlerFunc) http.HandlerFunc {
+		return r.allowlist.wrap(r.healthHandler.RequireCSRF(r.healthHandler.RequireScope(structs.ScopeWriteEndpoints, h)))
+	}
+	admin := func(h http.HandlerFunc) http.HandlerFunc {
+		return r.allowlist.wrap(r.healthHandler.RequireCSRF(r.healthHandler.RequireScope(structs.ScopeAdmin, h)))
+	}
+
 	// API endpoints matching original server.go
-	r.mux.HandleFunc("/api/status", r.healthHandler.GetStatus)
-	r.mux.HandleFunc("/api/endpoints", r.healthHandler.GetEndpoints)
-	r.mux.HandleFunc("/api/endpoints/add", r.healthHandler.AddEndpoint)
-	r.mux.HandleFunc("/api/endpoints/delete", r.healthHandler.DeleteEndpoint)
-	r.mux.HandleFunc("/api/endpoints/enable", r.healthHandler.EnableEndpoint)
-	r.mux.HandleFunc("/api/endpoints/disable", r.healthHandler.DisableEndpoint)
-	r.mux.HandleFunc("/api/endpoints/suppress", r.healthHandler.SuppressAlerts)
-	r.mux.HandleFunc("/api/endpoints/unsuppress", r.healthHandler.UnsuppressAlerts)
-	r.mux.HandleFunc("/api/history", r.healthHandler.GetHistory)
-	r.mux.HandleFunc("/api/endpoints/update", r.healthHandler.UpdateEndpoint)
-	r.mux.HandleFunc("/api/expiring-certs", r.healthHandler.GetExpiringCerts)
+	r.mux.HandleFunc("/api/status", read(r.healthHandler.GetStatus))
+	r.mux.HandleFunc("/api/summary", read(r.healthHandler.GetSummary))
+	r.mux.HandleFunc("/api/endpoints", read(r.healthHandler.GetEndpoints))
+	r.mux.HandleFunc("/api/endpoints/add", write(r.healthHandler.AddEndpoint))
+	r.mux.HandleFunc("/api/endpoints/bulk-add", write(r.healthHandler.BulkAddEndpoints))
+	r.mux.HandleFunc("/api/endpoints/delete", write(r.healthHandler.DeleteEndpoint))
+	r.mux.HandleFunc("/api/endpoints/enable", write(r.healthHandler.EnableEndpoint))
+	r.mux.HandleFunc("/api/endpoints/disable", write(r.healthHandler.DisableEndpoint))
+	r.mux.HandleFunc("/api/endpoints/suppress", write(r.healthHandler.SuppressAlerts))
+	r.mux.HandleFunc("/api/endpoints/unsuppress", write(r.healthHandler.UnsuppressAlerts))
+	r.mux.HandleFunc("/api/endpoints/pause-health", write(r.healthHandler.PauseHealthChecks))
+	r.mux.HandleFunc("/api/endpoints/resume-health", write(r.healthHandler.ResumeHealthChecks))
+	r.mux.HandleFunc("/api/endpoints/pause-ssl", write(r.healthHandler.PauseSSLChecks))
+	r.mux.HandleFunc("/api/endpoints/resume-ssl", write(r.healthHandler.ResumeSSLChecks))
+	r.mux.HandleFunc("/api/history", read(r.healthHandler.GetHistory))
+	r.mux.HandleFunc("/api/screenshot", read(r.healthHandler.GetScreenshot))
+	r.mux.HandleFunc("/api/charts", read(r.healthHandler.GetCharts))
+	r.mux.HandleFunc("/api/endpoints/update", write(r.healthHandler.UpdateEndpoint))
+	r.mux.HandleFunc("/api/endpoints/clone", write(r.healthHandler.CloneEndpoint))
+	r.mux.HandleFunc("/api/endpoints/archived", read(r.healthHandler.GetArchivedEndpoints))
+	r.mux.HandleFunc("/api/endpoints/restore", write(r.healthHandler.RestoreEndpoint))
+	r.mux.HandleFunc("/api/endpoints/purge", write(r.healthHandler.PurgeEndpoint))
+	r.mux.HandleFunc("/api/import", write(r.healthHandler.ImportEndpoints))
+	r.mux.HandleFunc("/api/export/blackbox", read(r.healthHandler.ExportBlackbox))
+	r.mux.HandleFunc("/api/expiring-certs", read(r.healthHandler.GetExpiringCerts))
 	r.mux.HandleFunc("/api/config", r.healthHandler.GetConfig)
 	r.mux.HandleFunc("/api/verify-passkey", r.healthHandler.VerifyPasskey)
-	r.mux.HandleFunc("/api/endpoints/enable-health", r.healthHandler.EnableHealthMonitoring)
+	r.mux.HandleFunc("/api/endpoints/enable-health", write(r.healthHandler.EnableHealthMonitoring))
+	r.mux.HandleFunc("/api/endpoints/", byMethod(read(r.healthHandler.GetEndpointDetail), map[string]http.HandlerFunc{
+		http.MethodPatch: write(r.healthHandler.PatchEndpoint),
+	}))
+
+	r.mux.HandleFunc("/api/services", read(r.healthHandler.GetServices))
+	r.mux.HandleFunc("/api/services/add", write(r.healthHandler.AddService))
+	r.mux.HandleFunc("/api/services/delete", write(r.healthHandler.DeleteService))
+
+	r.mux.HandleFunc("/api/actions/callback", r.healthHandler.ActionCallback)
+	r.mux.HandleFunc("/api/hooks/deploy", write(r.healthHandler.DeployHook))
+	r.mux.HandleFunc("/api/deployments", read(r.healthHandler.GetDeployments))
+	r.mux.HandleFunc("/api/deployments/add", write(r.healthHandler.AddDeployment))
+
+	r.mux.HandleFunc("/api/projects", admin(r.healthHandler.GetProjects))
+	r.mux.HandleFunc("/api/projects/add", admin(r.healthHandler.AddProject))
+	r.mux.HandleFunc("/api/projects/delete", admin(r.healthHandler.DeleteProject))
+
+	r.mux.HandleFunc("/api/users", admin(r.healthHandler.GetUsers))
+	r.mux.HandleFunc("/api/users/add", admin(r.healthHandler.AddUser))
+	r.mux.HandleFunc("/api/users/delete", admin(r.healthHandler.DeleteUser))
+
+	r.mux.HandleFunc("/api/oncall", read(r.healthHandler.GetOnCallSchedules))
+	r.mux.HandleFunc("/api/oncall/add", admin(r.healthHandler.AddOnCallSchedule))
+	r.mux.HandleFunc("/api/oncall/delete", admin(r.healthHandler.DeleteOnCallSchedule))
+
+	r.mux.HandleFunc("/api/blackouts", read(r.healthHandler.GetBlackouts))
+	r.mux.HandleFunc("/api/blackouts/import", admin(r.healthHandler.ImportBlackouts))
+	r.mux.HandleFunc("/api/blackouts/delete", admin(r.healthHandler.DeleteBlackouts))
+
+	r.mux.HandleFunc("/api/admin/totp/enroll", admin(r.healthHandler.EnrollTOTP))
+	r.mux.HandleFunc("/api/admin/totp/confirm", admin(r.healthHandler.ConfirmTOTP))
+	r.mux.HandleFunc("/api/admin/totp/disable", admin(r.healthHandler.DisableTOTP))
+	r.mux.HandleFunc("/api/admin/db/health", admin(r.healthHandler.GetDBHealth))
+	r.mux.HandleFunc("/api/admin/reload", admin(r.healthHandler.ReloadEndpoints))
+	r.mux.HandleFunc("/api/alerts/silence", write(r.healthHandler.SilenceAlerts))
+	r.mux.HandleFunc("/api/alerts/outbox", admin(r.healthHandler.GetOutbox))
+	r.mux.HandleFunc("/api/alerts/outbox/retry", admin(r.healthHandler.RetryOutboxMessage))
+	r.mux.HandleFunc("/api/alerts/outbox/delete", admin(r.healthHandler.DeleteOutboxMessage))
+	r.mux.HandleFunc("/api/alerts/", read(r.healthHandler.GetAlertDeliveries))
+	r.mux.HandleFunc("/api/ha/status", read(r.healthHandler.GetHAStatus))
+	r.mux.HandleFunc("/api/scheduler", read(r.healthHandler.GetScheduler))
+	r.mux.HandleFunc("/api/self", read(r.healthHandler.GetSelfStatus))
+	r.mux.HandleFunc("/api/reports", read(r.healthHandler.GetReports))
+	r.mux.HandleFunc("/api/reports/generate", write(r.healthHandler.GenerateReport))
+	r.mux.HandleFunc("/api/reports/delete", admin(r.healthHandler.DeleteReport))
+
+	r.mux.HandleFunc("/api/tokens", admin(r.healthHandler.GetTokens))
+	r.mux.HandleFunc("/api/tokens/add", admin(r.healthHandler.AddToken))
+	r.mux.HandleFunc("/api/tokens/delete", admin(r.healthHandler.DeleteToken))
 
 	// ✅ NEW: Manual SSL recheck
-	r.mux.HandleFunc("/api/ssl/recheck", r.healthHandler.ReRunSSLCheck)
+	r.mux.HandleFunc("/api/ssl/recheck", write(r.healthHandler.ReRunSSLCheck))
+	r.mux.HandleFunc("/api/ssl/summary/send", admin(r.healthHandler.SendSSLSummary))
+	r.mux.HandleFunc("/api/digests/send", admin(r.healthHandler.SendDigest))
+	r.mux.HandleFunc("/api/ssl/calendar.ics", read(r.healthHandler.GetSSLCalendar))
+	r.mux.HandleFunc("/api/tls", read(r.healthHandler.GetTLSReport))
+	r.mux.HandleFunc("/api/uptime", read(r.healthHandler.GetUptime))
+	r.mux.HandleFunc("/api/push/vapid-key", read(r.healthHandler.GetVAPIDKey))
+	r.mux.HandleFunc("/api/push/subscribe", write(r.healthHandler.SubscribePush))
+	r.mux.HandleFunc("/api/push/unsubscribe", write(r.healthHandler.UnsubscribePush))
+	r.mux.HandleFunc("/metrics", read(r.healthHandler.GetMetrics))
 
 	// Static files
-	r.mux.HandleFunc("/static/app.js", r.serveJS)
+	r.mux.HandleFunc("/static/", r.serveStatic)
+	r.mux.HandleFunc("/sw.js", r.serveServiceWorker)
 
 	// Root endpoint serves the dashboard
 	r.mux.HandleFunc("/", r.serveDashboard)
 }
 
+// byMethod routes requests with the listed methods to their own handlers and the rest to fallback
+func byMethod(fallback http.HandlerFunc, handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if h, ok := handlers[req.Method]; ok {
+			h(w, req)
+			return
+		}
+		fallback(w, req)
+	}
+}
+
 // serveDashboard serves the main dashboard HTML
 func (r *Router) serveDashboard(w http.ResponseWriter, req *http.Request) {
 	if req.URL.Path != "/" {
@@ -62,17 +166,26 @@ func (r *Router) serveDashboard(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// The page names hashed asset URLs, so it must be revalidated to pick up new assets
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(views.DashboardHTML))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("ETag", r.dashboardETag)
+	if strings.TrimPrefix(req.Header.Get("If-None-Match"), "W/") == r.dashboardETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write([]byte(r.dashboard))
 }
 
-// serveJS serves the JavaScript file
-func (r *Router) serveJS(w http.ResponseWriter, req *http.Request) {
+// serveServiceWorker serves the push service worker from the root so it can control the dashboard
+func (r *Router) serveServiceWorker(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Content-Type", "application/javascript")
-	w.Write([]byte(views.AppJS))
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Service-Worker-Allowed", "/")
+	w.Write([]byte(views.ServiceWorkerJS))
 }
 
 // ServeHTTP implements http.Handler interface
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.mux.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
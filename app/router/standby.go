@@ -0,0 +1,35 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/ashanmugaraja/cronzee/app/worker"
+)
+
+// leaderHeader names the current HA leader on responses refused by a standby
+const leaderHeader = "X-SiteWatch-Leader"
+
+// standbyReadOnly rejects changes on an HA standby or a read-only instance, which serve reads
+// from their own database
+func standbyReadOnly(monitor *worker.Monitor, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, req)
+			return
+		}
+		if monitor.ReadOnly() {
+			http.Error(w, "This instance is read-only", http.StatusServiceUnavailable)
+			return
+		}
+		if monitor.IsActive() {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		if holder := monitor.LeaderStatus().Holder; holder != "" {
+			w.Header().Set(leaderHeader, holder)
+		}
+		http.Error(w, "This instance is an HA standby and is read-only; send changes to the leader", http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/views"
+)
+
+// immutableCache lets browsers keep content-hashed assets for a year
+const immutableCache = "public, max-age=31536000, immutable"
+
+// renderDashboard fills the CSRF token into the dashboard page and returns it with its ETag
+func renderDashboard(csrfToken string) (string, string) {
+	html := strings.Replace(views.DashboardHTML, views.CSRFPlaceholder, csrfToken, 1)
+	sum := sha256.Sum256([]byte(html))
+	return html, `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func init() {
+	// Types missing from some systems' mime tables
+	mime.AddExtensionType(".woff2", "font/woff2")
+	mime.AddExtensionType(".woff", "font/woff")
+	mime.AddExtensionType(".ico", "image/x-icon")
+	mime.AddExtensionType(".svg", "image/svg+xml")
+}
+
+// serveStatic serves embedded assets; requests carrying the current ?v= hash are cached permanently
+func (r *Router) serveStatic(w http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, "/static/")
+	hash := views.AssetHash(name)
+	if hash == "" {
+		http.NotFound(w, req)
+		return
+	}
+
+	data, err := fs.ReadFile(views.Static, name)
+	if err != nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	if req.URL.Query().Get("v") == hash {
+		w.Header().Set("Cache-Control", immutableCache)
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	w.Header().Set("ETag", `"`+hash+`"`)
+
+	http.ServeContent(w, req, name, time.Time{}, bytes.NewReader(data))
+}
@@ -0,0 +1,141 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// timezoneHeader lets clients choose the zone for timestamps in API responses
+const timezoneHeader = "Accept-Timezone"
+
+// bufferedResponse captures a handler's response so it can be rewritten
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// Header returns the underlying response headers
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+// Write buffers the body
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// WriteHeader records the status code
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+// localizeTimes converts RFC 3339 timestamps in JSON API responses to the zone named by ?tz= or Accept-Timezone
+func localizeTimes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.URL.Path, "/api/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Add("Vary", timezoneHeader)
+
+		name := req.URL.Query().Get("tz")
+		// An unescaped "+05:30" arrives as " 05:30"
+		if strings.HasPrefix(name, " ") {
+			name = "+" + strings.TrimSpace(name)
+		}
+		if name == "" {
+			name = req.Header.Get(timezoneHeader)
+		}
+		if name == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		loc, err := utils.LoadTimezone(name)
+		if err != nil {
+			http.Error(w, "Invalid timezone: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		buf := &bufferedResponse{header: w.Header()}
+		next.ServeHTTP(buf, req)
+		if buf.status == 0 {
+			buf.status = http.StatusOK
+		}
+
+		body := buf.body.Bytes()
+		if buf.status == http.StatusOK && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			if converted, err := convertTimes(body, loc); err == nil {
+				body = converted
+				w.Header().Del("Content-Length")
+			}
+		}
+
+		w.Header().Set("Content-Timezone", loc.String())
+		w.WriteHeader(buf.status)
+		w.Write(body)
+	})
+}
+
+// convertTimes rewrites every timestamp string in a JSON document into loc
+func convertTimes(body []byte, loc *time.Location) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.NewEncoder(&out).Encode(convertValue(doc, loc)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// convertValue walks a decoded JSON value converting timestamps
+func convertValue(value interface{}, loc *time.Location) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertValue(item, loc)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertValue(item, loc)
+		}
+	case string:
+		return convertTimestamp(v, loc)
+	}
+	return value
+}
+
+// convertTimestamp converts s if it is an RFC 3339 timestamp, keeping its precision
+func convertTimestamp(s string, loc *time.Location) string {
+	// Cheap shape check before parsing: "2006-01-02T15:04:05..."
+	if len(s) < 20 || s[4] != '-' || s[10] != 'T' {
+		return s
+	}
+	if _, err := strconv.Atoi(s[:4]); err != nil {
+		return s
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || t.IsZero() {
+		return s
+	}
+	if strings.Contains(s[19:], ".") {
+		return t.In(loc).Format(time.RFC3339Nano)
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -3,6 +3,7 @@ package structs
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -35,13 +36,208 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 
 // Config represents the application configuration
 type Config struct {
-	Server               ServerConfig `json:"server"`
-	CheckInterval        Duration     `json:"check_interval"`
-	SSLExpiryWarningDays int          `json:"ssl_expiry_warning_days"`
-	SSLSummaryTime       string       `json:"ssl_summary_time"`
-	AdminPasskey         string       `json:"admin_passkey"`
-	Endpoints            []Endpoint   `json:"endpoints"`
-	Alerting             Alerting     `json:"alerting"`
+	Server                  ServerConfig      `json:"server"`
+	PublicURL               string            `json:"public_url"`
+	ReadOnly                bool              `json:"read_only"`
+	ActionSigningKey        string            `json:"action_signing_key"`
+	WebPushSubject          string            `json:"web_push_subject"`
+	CheckInterval           Duration          `json:"check_interval"`
+	WatchdogGrace           Duration          `json:"watchdog_grace"`
+	NewEndpointGrace        Duration          `json:"new_endpoint_grace"`
+	ShutdownTimeout         Duration          `json:"shutdown_timeout"`
+	MaxChecksPerSecond      float64           `json:"max_checks_per_second"`
+	SLABurnRateThreshold    float64           `json:"sla_burn_rate_threshold"`
+	SLABurnRateWindow       Duration          `json:"sla_burn_rate_window"`
+	SSLExpiryWarningDays    int               `json:"ssl_expiry_warning_days"`
+	SSLSummaryTime          string            `json:"ssl_summary_time"`
+	SSLSummarySchedule      string            `json:"ssl_summary_schedule"`
+	SSLSummaryWeekday       string            `json:"ssl_summary_weekday"`
+	Digests                 []DigestConfig    `json:"digests"`
+	Reports                 []ReportConfig    `json:"reports"`
+	SSLCalendarReminders    []int             `json:"ssl_calendar_reminders"`
+	RecentResults           int               `json:"recent_results"`
+	Timezone                string            `json:"timezone"`
+	MessageCatalogs         map[string]string `json:"message_catalogs"`
+	AdminPasskey            string            `json:"admin_passkey"`
+	AdminAllowedCIDRs       []string          `json:"admin_allowed_cidrs"`
+	TrustedProxies          []string          `json:"trusted_proxies"`
+	UserAgent               string            `json:"user_agent"`
+	DefaultHeaders          map[string]string `json:"default_headers"`
+	AllowDuplicateURLs      bool              `json:"allow_duplicate_urls"`
+	DefaultTimeout          Duration          `json:"default_timeout"`
+	DefaultInterval         Duration          `json:"default_interval"`
+	DefaultExpectedStatus   int               `json:"default_expected_status"`
+	DefaultFailureThreshold int               `json:"default_failure_threshold"`
+	DefaultSuccessThreshold int               `json:"default_success_threshold"`
+	Endpoints               []Endpoint        `json:"endpoints"`
+	Alerting                Alerting          `json:"alerting"`
+	AlertHTTP               AlertHTTPConfig   `json:"alert_http"`
+	MQTT                    MQTTConfig        `json:"mqtt"`
+	EventStream             EventStreamConfig `json:"event_stream"`
+	Firehose                FirehoseConfig    `json:"firehose"`
+	HA                      HAConfig          `json:"ha"`
+	Browser                 BrowserConfig     `json:"browser"`
+	SelfMonitoring          SelfMonitoring    `json:"self_monitoring"`
+	OTLP                    OTLPConfig        `json:"otlp"`
+	Heartbeat               HeartbeatConfig   `json:"heartbeat"`
+	Archive                 ArchiveConfig     `json:"archive"`
+}
+
+// EndpointDefaults are the check settings of endpoints that leave them unset
+type EndpointDefaults struct {
+	Timeout          time.Duration
+	CheckInterval    time.Duration
+	ExpectedStatus   int
+	FailureThreshold int
+	SuccessThreshold int
+}
+
+// BuiltinEndpointDefaults apply when the config file sets no defaults of its own
+var BuiltinEndpointDefaults = EndpointDefaults{
+	Timeout:          10 * time.Second,
+	CheckInterval:    30 * time.Second,
+	ExpectedStatus:   200,
+	FailureThreshold: 3,
+	SuccessThreshold: 2,
+}
+
+// EndpointDefaults returns the configured default check settings
+func (c *Config) EndpointDefaults() EndpointDefaults {
+	return EndpointDefaults{
+		Timeout:          c.DefaultTimeout.Duration,
+		CheckInterval:    c.DefaultInterval.Duration,
+		ExpectedStatus:   c.DefaultExpectedStatus,
+		FailureThreshold: c.DefaultFailureThreshold,
+		SuccessThreshold: c.DefaultSuccessThreshold,
+	}
+}
+
+// Apply fills the unset check settings of an endpoint. Negative endpoints get no expected
+// status, since without one they must not answer at all.
+func (d EndpointDefaults) Apply(endpoint *StoredEndpoint) {
+	if endpoint.Timeout == 0 {
+		endpoint.Timeout = d.Timeout
+	}
+	if endpoint.CheckInterval == 0 {
+		endpoint.CheckInterval = d.CheckInterval
+	}
+	if endpoint.ExpectedStatus == 0 && endpoint.CheckType != CheckNegative {
+		endpoint.ExpectedStatus = d.ExpectedStatus
+	}
+	if endpoint.FailureThreshold == 0 {
+		endpoint.FailureThreshold = d.FailureThreshold
+	}
+	if endpoint.SuccessThreshold == 0 {
+		endpoint.SuccessThreshold = d.SuccessThreshold
+	}
+}
+
+// BrowserConfig configures the headless Chrome used by browser checks
+type BrowserConfig struct {
+	ExecPath  string `json:"exec_path"`
+	NoSandbox bool   `json:"no_sandbox"`
+}
+
+// HAConfig configures leader election between redundant instances sharing a lock file
+type HAConfig struct {
+	Enabled       bool     `json:"enabled"`
+	NodeID        string   `json:"node_id"`
+	LockFile      string   `json:"lock_file"`
+	LeaseDuration Duration `json:"lease_duration"`
+	// SyncFile is where the leader publishes its endpoints for the standby (default: lock_file + ".endpoints.json")
+	SyncFile string `json:"sync_file"`
+}
+
+// AlertHTTPConfig configures the HTTP client that delivers webhook, Slack, Teams and push alerts
+type AlertHTTPConfig struct {
+	Timeout Duration `json:"timeout"`
+	Proxy   string   `json:"proxy"`
+	CAFile  string   `json:"ca_file"`
+}
+
+// FirehoseConfig posts every check result to a webhook in batches
+type FirehoseConfig struct {
+	Enabled       bool              `json:"enabled"`
+	URL           string            `json:"url"`
+	Headers       map[string]string `json:"headers"`
+	BatchSize     int               `json:"batch_size"`
+	FlushInterval Duration          `json:"flush_interval"`
+}
+
+// SelfMonitoring alerts when SiteWatch itself is unhealthy
+type SelfMonitoring struct {
+	Enabled             bool     `json:"enabled"`
+	Interval            Duration `json:"interval"`
+	DBWriteErrors       int      `json:"db_write_errors"`
+	DeliveryFailureRate float64  `json:"delivery_failure_rate"`
+	MinDeliveries       int      `json:"min_deliveries"`
+	OverdueChecks       int      `json:"overdue_checks"`
+}
+
+// HeartbeatConfig pings dead-man's-switch URLs while the scheduler is running
+type HeartbeatConfig struct {
+	Enabled  bool     `json:"enabled"`
+	URLs     []string `json:"urls"`
+	Interval Duration `json:"interval"`
+	Timeout  Duration `json:"timeout"`
+}
+
+// Archive storage providers, all spoken to over the S3 API
+const (
+	ArchiveS3    = "s3"
+	ArchiveGCS   = "gcs"
+	ArchiveMinIO = "minio"
+)
+
+// ArchiveConfig writes check history to object storage for keeping beyond the database retention
+type ArchiveConfig struct {
+	Enabled         bool   `json:"enabled"`
+	Provider        string `json:"provider"`
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	PathStyle       bool   `json:"path_style"`
+}
+
+// OTLPConfig exports alert events to an OpenTelemetry collector over OTLP/HTTP
+type OTLPConfig struct {
+	Enabled        bool              `json:"enabled"`
+	Endpoint       string            `json:"endpoint"`
+	Headers        map[string]string `json:"headers"`
+	ServiceName    string            `json:"service_name"`
+	MetricInterval Duration          `json:"metric_interval"`
+}
+
+// Event stream drivers
+const (
+	EventStreamNATS  = "nats"
+	EventStreamKafka = "kafka"
+)
+
+// EventStreamConfig configures streaming check results and state transitions to NATS or Kafka
+type EventStreamConfig struct {
+	Enabled     bool   `json:"enabled"`
+	Driver      string `json:"driver"`
+	URL         string `json:"url"`
+	TopicPrefix string `json:"topic_prefix"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	Token       string `json:"token"`
+}
+
+// MQTTConfig configures publishing status changes and check results to an MQTT broker
+type MQTTConfig struct {
+	Enabled       bool     `json:"enabled"`
+	Broker        string   `json:"broker"`
+	ClientID      string   `json:"client_id"`
+	Username      string   `json:"username"`
+	Password      string   `json:"password"`
+	TopicPrefix   string   `json:"topic_prefix"`
+	PublishChecks bool     `json:"publish_checks"`
+	KeepAlive     Duration `json:"keep_alive"`
 }
 
 // ServerConfig represents web server configuration
@@ -50,16 +246,57 @@ type ServerConfig struct {
 	Port    int  `json:"port"`
 }
 
+// MaxRetries caps an endpoint's retries, so a dead endpoint can't hold its check for long
+const MaxRetries = 5
+
 // Endpoint represents a monitored endpoint
 type Endpoint struct {
-	Name             string            `json:"name"`
-	URL              string            `json:"url"`
-	Method           string            `json:"method"`
-	Timeout          Duration          `json:"timeout"`
-	ExpectedStatus   int               `json:"expected_status"`
-	Headers          map[string]string `json:"headers"`
-	FailureThreshold int               `json:"failure_threshold"`
-	SuccessThreshold int               `json:"success_threshold"`
+	Name               string            `json:"name"`
+	URL                string            `json:"url"`
+	Method             string            `json:"method"`
+	Timeout            Duration          `json:"timeout"`
+	ExpectedStatus     int               `json:"expected_status"`
+	Headers            map[string]string `json:"headers"`
+	FailureThreshold   int               `json:"failure_threshold"`
+	NetworkThreshold   int               `json:"network_failure_threshold"`
+	AppThreshold       int               `json:"application_failure_threshold"`
+	SuccessThreshold   int               `json:"success_threshold"`
+	FailureDuration    Duration          `json:"failure_duration"`
+	SuccessDuration    Duration          `json:"success_duration"`
+	ResolveTo          string            `json:"resolve_to"`
+	HostHeader         string            `json:"host_header"`
+	DisableKeepAlive   bool              `json:"disable_keep_alive"`
+	MaxIdleConns       int               `json:"max_idle_conns"`
+	UseCookies         bool              `json:"use_cookies"`
+	BackoffEnabled     bool              `json:"backoff_enabled"`
+	BackoffAfter       Duration          `json:"backoff_after"`
+	BackoffMaxInterval Duration          `json:"backoff_max_interval"`
+	Retries            int               `json:"retries"`
+	RetryDelay         Duration          `json:"retry_delay"`
+	Priority           Priority          `json:"priority"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify"`
+	CertFingerprint    string            `json:"cert_fingerprint"`
+	Tags               []string          `json:"tags"`
+	SLATarget          float64           `json:"sla_target"`
+	ProjectID          string            `json:"project_id"`
+	Description        string            `json:"description"`
+	Owner              string            `json:"owner"`
+	RunbookURL         string            `json:"runbook_url"`
+	Labels             map[string]string `json:"labels"`
+	RateLimitMode      RateLimitMode     `json:"rate_limit_mode"`
+	Schedule           string            `json:"schedule"`
+	CheckType          CheckType         `json:"check_type"`
+	WaitSelector       string            `json:"wait_selector"`
+	Journey            []JourneyStep     `json:"journey"`
+	ContentType        string            `json:"expected_content_type"`
+	SuccessExpression  string            `json:"success_expression"`
+	BodyContains       string            `json:"body_contains"`
+	BodyNotContains    string            `json:"body_not_contains"`
+	BodyRegex          string            `json:"body_regex"`
+	AlternateHosts     []string          `json:"alternate_hostnames"`
+	CallbackURL        string            `json:"callback_url"`
+	HistorySample      int               `json:"history_sample_every"`
+	JourneyFile        string            `json:"journey_file"`
 }
 
 // Alerting represents alerting configuration
@@ -74,35 +311,387 @@ type Alerting struct {
 	SlackEnabled            bool              `json:"slack_enabled"`
 	SlackWebhook            string            `json:"slack_webhook"`
 	CustomFields            map[string]string `json:"custom_fields"`
+	EscalationMinPriority   string            `json:"escalation_min_priority"`
+	TeamsActions            bool              `json:"teams_actions"`
+	WebPushEnabled          bool              `json:"web_push_enabled"`
+	WebhookFormat           string            `json:"webhook_format"`
+	WebhookVersion          int               `json:"webhook_version"`
+	SyslogEnabled           bool              `json:"syslog_enabled"`
+	Syslog                  SyslogConfig      `json:"syslog"`
+	Language                string            `json:"language"`
+	ChannelLanguages        map[string]string `json:"channel_languages"`
+	ChannelMinSeverity      map[string]string `json:"channel_min_severity"`
+}
+
+// Alert channels that can be given their own message language
+const (
+	ChannelWebhook = "webhook"
+	ChannelSlack   = "slack"
+	ChannelEmail   = "email"
+	ChannelTeams   = "teams"
+	ChannelSyslog  = "syslog"
+	ChannelPush    = "push"
+)
+
+// ChannelCallback delivers an endpoint's status changes to its own callback_url. It is not an
+// alert channel, so it has no language or severity settings.
+const ChannelCallback = "callback"
+
+// SyslogConfig represents an RFC 5424 syslog destination
+type SyslogConfig struct {
+	Network  string `json:"network"`
+	Address  string `json:"address"`
+	Facility string `json:"facility"`
+	AppName  string `json:"app_name"`
+}
+
+// ValidChannel reports whether channel names an alert channel
+func ValidChannel(channel string) bool {
+	switch channel {
+	case ChannelWebhook, ChannelSlack, ChannelEmail, ChannelTeams, ChannelSyslog, ChannelPush:
+		return true
+	}
+	return false
+}
+
+// ValidateSeverities checks channel_min_severity names known channels and severities
+func (a *Alerting) ValidateSeverities() error {
+	for channel, severity := range a.ChannelMinSeverity {
+		if !ValidChannel(channel) {
+			return fmt.Errorf("invalid channel_min_severity channel %q: must be webhook, slack, email, teams, syslog or push", channel)
+		}
+		if !Severity(severity).Valid() {
+			return fmt.Errorf("invalid channel_min_severity for %s: %q must be critical, warning or info", channel, severity)
+		}
+	}
+	return nil
+}
+
+// Webhook payload presets
+const (
+	WebhookFormatDefault      = "default"
+	WebhookFormatAlertmanager = "alertmanager"
+)
+
+// Versions of the default webhook payload schema; 0 means the alerting config's version
+const (
+	WebhookVersion1 = 1
+	WebhookVersion2 = 2
+)
+
+// ValidWebhookVersion reports whether v selects a known webhook schema or leaves it unset
+func ValidWebhookVersion(v int) bool {
+	return v >= 0 && v <= WebhookVersion2
+}
+
+// CheckEvent describes the result of a single health check
+type CheckEvent struct {
+	EndpointID     string    `json:"endpoint_id"`
+	Name           string    `json:"name"`
+	URL            string    `json:"url"`
+	ProjectID      string    `json:"project_id,omitempty"`
+	Status         string    `json:"status"`
+	Passed         bool      `json:"passed"`
+	ResponseTimeMs int64     `json:"response_time_ms"`
+	Error          string    `json:"error,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// StatusEvent describes an endpoint moving from one health status to another
+type StatusEvent struct {
+	EndpointID     string    `json:"endpoint_id"`
+	Name           string    `json:"name"`
+	URL            string    `json:"url"`
+	ProjectID      string    `json:"project_id,omitempty"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// DigestConfig schedules an email digest of monitor health for a recipient group
+type DigestConfig struct {
+	Name       string       `json:"name"`
+	Recipients []string     `json:"recipients"`
+	Schedule   string       `json:"schedule"`
+	Time       string       `json:"time"`
+	Weekday    string       `json:"weekday"`
+	Timezone   string       `json:"timezone"`
+	Language   string       `json:"language"`
+	Filter     Subscription `json:"filter"`
+}
+
+// ReportConfig schedules a monthly availability report for the endpoints matching its filter
+type ReportConfig struct {
+	Name   string       `json:"name"`
+	Filter Subscription `json:"filter"`
 }
 
 // EmailConfig represents email configuration
 type EmailConfig struct {
-	SMTPHost string   `json:"smtp_host"`
-	SMTPPort int      `json:"smtp_port"`
-	From     string   `json:"from"`
-	To       []string `json:"to"`
-	Username string   `json:"username"`
-	Password string   `json:"password"`
+	SMTPHost    string   `json:"smtp_host"`
+	SMTPPort    int      `json:"smtp_port"`
+	From        string   `json:"from"`
+	To          []string `json:"to"`
+	Username    string   `json:"username"`
+	Password    string   `json:"password"`
+	BatchWindow Duration `json:"batch_window"`
 }
 
 // StoredEndpoint represents an endpoint stored in the database
 type StoredEndpoint struct {
-	ID               string            `json:"id"`
-	Name             string            `json:"name"`
-	URL              string            `json:"url"`
-	Method           string            `json:"method"`
-	Timeout          time.Duration     `json:"timeout"`
-	CheckInterval    time.Duration     `json:"check_interval"`
-	ExpectedStatus   int               `json:"expected_status"`
-	Headers          map[string]string `json:"headers"`
-	FailureThreshold int               `json:"failure_threshold"`
-	SuccessThreshold int               `json:"success_threshold"`
-	Enabled          bool              `json:"enabled"`
-	AlertsSuppressed bool              `json:"alerts_suppressed"`
-	MonitorHealth    bool              `json:"monitor_health"`
-	CreatedAt        time.Time         `json:"created_at"`
-	UpdatedAt        time.Time         `json:"updated_at"`
+	ID                 string            `json:"id"`
+	LegacyID           string            `json:"legacy_id,omitempty"` // Name-and-URL ID used before IDs became UUIDs
+	Name               string            `json:"name"`
+	URL                string            `json:"url"`
+	Method             string            `json:"method"`
+	Timeout            time.Duration     `json:"timeout"`
+	CheckInterval      time.Duration     `json:"check_interval"`
+	ExpectedStatus     int               `json:"expected_status"`
+	Headers            map[string]string `json:"headers"`
+	FailureThreshold   int               `json:"failure_threshold"`
+	NetworkThreshold   int               `json:"network_failure_threshold"`
+	AppThreshold       int               `json:"application_failure_threshold"`
+	SuccessThreshold   int               `json:"success_threshold"`
+	FailureDuration    time.Duration     `json:"failure_duration"`
+	SuccessDuration    time.Duration     `json:"success_duration"`
+	ResolveTo          string            `json:"resolve_to"`
+	HostHeader         string            `json:"host_header"`
+	DisableKeepAlive   bool              `json:"disable_keep_alive"`
+	MaxIdleConns       int               `json:"max_idle_conns"`
+	UseCookies         bool              `json:"use_cookies"`
+	BackoffEnabled     bool              `json:"backoff_enabled"`
+	BackoffAfter       time.Duration     `json:"backoff_after"`
+	BackoffMaxInterval time.Duration     `json:"backoff_max_interval"`
+	Retries            int               `json:"retries"`
+	RetryDelay         time.Duration     `json:"retry_delay"`
+	Priority           Priority          `json:"priority"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify"`
+	CertFingerprint    string            `json:"cert_fingerprint"`
+	Tags               []string          `json:"tags"`
+	SLATarget          float64           `json:"sla_target"`
+	ProjectID          string            `json:"project_id"`
+	Description        string            `json:"description"`
+	Owner              string            `json:"owner"`
+	RunbookURL         string            `json:"runbook_url"`
+	Labels             map[string]string `json:"labels"`
+	RateLimitMode      RateLimitMode     `json:"rate_limit_mode"`
+	Schedule           string            `json:"schedule"`
+	CheckType          CheckType         `json:"check_type"`
+	WaitSelector       string            `json:"wait_selector"`
+	Journey            []JourneyStep     `json:"journey"`
+	ContentType        string            `json:"expected_content_type"`
+	SuccessExpression  string            `json:"success_expression"`
+	BodyContains       string            `json:"body_contains"`
+	BodyNotContains    string            `json:"body_not_contains"`
+	BodyRegex          string            `json:"body_regex"`
+	AlternateHosts     []string          `json:"alternate_hostnames"`
+	CallbackURL        string            `json:"callback_url"`
+	HistorySample      int               `json:"history_sample_every"`
+	Enabled            bool              `json:"enabled"`
+	AlertsSuppressed   bool              `json:"alerts_suppressed"`
+	SuppressedUntil    time.Time         `json:"suppressed_until"` // End of a snooze; zero while suppressed means until lifted
+	MonitorHealth      bool              `json:"monitor_health"`
+	HealthPaused       bool              `json:"health_paused"` // Health checks stopped for now, certificate tracking goes on
+	SSLPaused          bool              `json:"ssl_paused"`    // Certificate tracking stopped for now
+	Archived           bool              `json:"archived"`
+	ArchivedAt         time.Time         `json:"archived_at"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// Service rollup policies
+const (
+	PolicyAllHealthy = "all_healthy"
+	PolicyQuorum     = "quorum"
+	PolicyWeighted   = "weighted"
+)
+
+// Service groups endpoints into one customer-facing unit with a rollup policy
+type Service struct {
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	EndpointIDs      []string           `json:"endpoint_ids"`
+	Policy           string             `json:"policy"`
+	Quorum           int                `json:"quorum"`
+	Weights          map[string]float64 `json:"weights"`
+	WeightThreshold  float64            `json:"weight_threshold"`
+	AlertsSuppressed bool               `json:"alerts_suppressed"`
+	ProjectID        string             `json:"project_id"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// Project is a workspace with its own endpoints, alerting and API key
+type Project struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	APIKeyHash string    `json:"-"`
+	Alerting   *Alerting `json:"alerting,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// User is a person who receives alerts on their own contact channels
+type User struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Email          string       `json:"email"`
+	SlackWebhook   string       `json:"slack_webhook"`
+	WebhookURL     string       `json:"webhook_url"`
+	WebhookVersion int          `json:"webhook_version,omitempty"` // Payload schema for webhook_url; 0 follows the alerting config
+	Language       string       `json:"language,omitempty"`
+	Subscriptions  Subscription `json:"subscriptions"`
+	Disabled       bool         `json:"disabled"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// OnCallSchedule rotates alert delivery through users, handing off once a week
+type OnCallSchedule struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Participants []string     `json:"participants"`
+	HandoffDay   string       `json:"handoff_day"`
+	HandoffTime  string       `json:"handoff_time"`
+	Timezone     string       `json:"timezone"`
+	Start        time.Time    `json:"start"`
+	Filter       Subscription `json:"filter"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// Blackout actions applied to alerts while a blackout period is active
+const (
+	BlackoutSuppress  = "suppress"
+	BlackoutDowngrade = "downgrade"
+)
+
+// BlackoutCalendar is an imported calendar of company-wide periods with reduced alerting
+type BlackoutCalendar struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Action    string           `json:"action"`
+	Periods   []BlackoutPeriod `json:"periods"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// BlackoutPeriod is one event of a blackout calendar, such as a holiday or release freeze
+type BlackoutPeriod struct {
+	UID     string    `json:"uid,omitempty"`
+	Summary string    `json:"summary"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// ActivePeriod returns the period covering t, if any
+func (c *BlackoutCalendar) ActivePeriod(t time.Time) (BlackoutPeriod, bool) {
+	for _, period := range c.Periods {
+		if !t.Before(period.Start) && t.Before(period.End) {
+			return period, true
+		}
+	}
+	return BlackoutPeriod{}, false
+}
+
+// API token scopes, from least to most privileged
+const (
+	ScopeReadStatus     = "read:status"
+	ScopeWriteEndpoints = "write:endpoints"
+	ScopeAdmin          = "admin"
+)
+
+// APIToken is a bearer token limited to a set of scopes
+type APIToken struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	TokenHash string     `json:"-"`
+	Scopes    []string   `json:"scopes"`
+	ProjectID string     `json:"project_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Expired reports whether the token is past its expiry
+func (t *APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && now.After(*t.ExpiresAt)
+}
+
+// HasScope reports whether the token grants a scope; admin grants everything
+// and write:endpoints includes read:status
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		switch {
+		case s == scope, s == ScopeAdmin:
+			return true
+		case s == ScopeWriteEndpoints && scope == ScopeReadStatus:
+			return true
+		}
+	}
+	return false
+}
+
+// PushSubscription is a browser Web Push subscription with an optional filter
+type PushSubscription struct {
+	ID        string       `json:"id"`
+	Endpoint  string       `json:"endpoint"`
+	Keys      PushKeys     `json:"keys"`
+	Filter    Subscription `json:"filter"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// PushKeys are the subscription's encryption keys as sent by the browser
+type PushKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// TOTPSettings holds the admin's TOTP enrollment
+type TOTPSettings struct {
+	Secret     string    `json:"secret"`
+	Enabled    bool      `json:"enabled"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+// Subscription selects which endpoints a user is notified about.
+// An empty subscription matches every endpoint.
+type Subscription struct {
+	Tags        []string `json:"tags"`
+	Projects    []string `json:"projects"`
+	MinPriority Priority `json:"min_priority"`
+}
+
+// Matches reports whether an endpoint falls under the subscription
+func (s Subscription) Matches(endpoint Endpoint) bool {
+	if s.MinPriority != "" && endpoint.Priority.Rank() > s.MinPriority.Rank() {
+		return false
+	}
+	if len(s.Tags) == 0 && len(s.Projects) == 0 {
+		return true
+	}
+	for _, project := range s.Projects {
+		if project == endpoint.ProjectID {
+			return true
+		}
+	}
+	for _, tag := range s.Tags {
+		if endpoint.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServiceStatus is the rolled-up health of a service
+type ServiceStatus struct {
+	Service      *Service     `json:"service"`
+	Status       HealthStatus `json:"status"`
+	Healthy      int          `json:"healthy"`
+	Unhealthy    int          `json:"unhealthy"`
+	Unknown      int          `json:"unknown"`
+	Total        int          `json:"total"`
+	HealthyScore float64      `json:"healthy_score"`
 }
 
 // HealthCheckRecord represents a single health check result stored in history
@@ -113,6 +702,45 @@ type HealthCheckRecord struct {
 	ResponseTime time.Duration `json:"response_time"`
 	StatusCode   int           `json:"status_code"`
 	Error        string        `json:"error,omitempty"`
+	FailureKind  FailureKind   `json:"failure_kind,omitempty"`
+	RateLimited  bool          `json:"rate_limited,omitempty"`
+	Screenshot   bool          `json:"screenshot,omitempty"`
+	Steps        []StepResult  `json:"steps,omitempty"`
+	Sampled      int           `json:"sampled,omitempty"` // Checks the record stands for when history is sampled
+}
+
+// Weight returns the number of checks the record stands for
+func (r *HealthCheckRecord) Weight() int {
+	if r.Sampled > 1 {
+		return r.Sampled
+	}
+	return 1
+}
+
+// Deployment marks a release so it can be correlated with check history
+type Deployment struct {
+	ID          string    `json:"id"`
+	Service     string    `json:"service,omitempty"`
+	Tag         string    `json:"tag,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	EndpointIDs []string  `json:"endpoint_ids"`
+	Passed      *bool     `json:"passed,omitempty"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Affects reports whether the deployment applies to an endpoint; a marker
+// without endpoints applies to every endpoint in its project
+func (d *Deployment) Affects(endpoint Endpoint, id string) bool {
+	if len(d.EndpointIDs) == 0 {
+		return d.ProjectID == "" || d.ProjectID == endpoint.ProjectID
+	}
+	for _, endpointID := range d.EndpointIDs {
+		if endpointID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // HealthStatus represents the health status of an endpoint
@@ -124,6 +752,176 @@ const (
 	StatusUnknown   HealthStatus = "unknown"
 )
 
+// Priority ranks endpoints for scheduling, alert severity and escalation
+type Priority string
+
+const (
+	PriorityCritical Priority = "critical"
+	PriorityHigh     Priority = "high"
+	PriorityNormal   Priority = "normal"
+	PriorityLow      Priority = "low"
+)
+
+// Valid reports whether the priority is one of the known tiers
+func (p Priority) Valid() bool {
+	switch p {
+	case PriorityCritical, PriorityHigh, PriorityNormal, PriorityLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rank returns the sort order of the priority, lower is more urgent
+func (p Priority) Rank() int {
+	switch p {
+	case PriorityCritical:
+		return 0
+	case PriorityHigh:
+		return 1
+	case PriorityLow:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// Severity classifies an alert for routing, derived from endpoint priority and failure type
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// Valid reports whether the severity is one of the known levels
+func (s Severity) Valid() bool {
+	return s == SeverityCritical || s == SeverityWarning || s == SeverityInfo
+}
+
+// Rank returns the sort order of the severity, lower is more urgent
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// RateLimitMode decides how a 429 Too Many Requests response is treated
+type RateLimitMode string
+
+const (
+	RateLimitDegraded RateLimitMode = "degraded"
+	RateLimitFailure  RateLimitMode = "failure"
+)
+
+// Valid reports whether the mode is one of the known rate limit modes
+func (r RateLimitMode) Valid() bool {
+	return r == RateLimitDegraded || r == RateLimitFailure
+}
+
+// CheckType selects how an endpoint is checked
+type CheckType string
+
+const (
+	CheckHTTP     CheckType = "http"     // Plain HTTP request, the default
+	CheckBrowser  CheckType = "browser"  // Page load in headless Chrome with a screenshot
+	CheckNegative CheckType = "negative" // Healthy while nothing answers, or only with expected_status
+)
+
+// Valid reports whether the type is empty (HTTP) or one of the known check types
+func (c CheckType) Valid() bool {
+	return c == "" || c == CheckHTTP || c == CheckBrowser || c == CheckNegative
+}
+
+// Journey step actions
+const (
+	StepNavigate   = "navigate"    // Load url, or the endpoint URL
+	StepClick      = "click"       // Click the element matching selector
+	StepType       = "type"        // Type value into the element matching selector
+	StepWait       = "wait"        // Wait for the element matching selector to be visible
+	StepAssertText = "assert_text" // Require the text of the element matching selector to contain text
+)
+
+// JourneyStep is one action of a scripted browser journey
+type JourneyStep struct {
+	Action   string `json:"action" yaml:"action"`
+	Name     string `json:"name,omitempty" yaml:"name"`
+	URL      string `json:"url,omitempty" yaml:"url"`
+	Selector string `json:"selector,omitempty" yaml:"selector"`
+	Value    string `json:"value,omitempty" yaml:"value"`
+	Text     string `json:"text,omitempty" yaml:"text"`
+}
+
+// Label names the step in results and errors
+func (s JourneyStep) Label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Action == StepNavigate && s.URL != "":
+		return s.Action + " " + s.URL
+	case s.Selector != "":
+		return s.Action + " " + s.Selector
+	}
+	return s.Action
+}
+
+// ValidateJourney checks that every step has a known action and the fields it needs
+func ValidateJourney(steps []JourneyStep) error {
+	for i, step := range steps {
+		switch step.Action {
+		case StepNavigate:
+		case StepClick, StepWait:
+			if step.Selector == "" {
+				return fmt.Errorf("journey step %d: %s needs a selector", i+1, step.Action)
+			}
+		case StepType:
+			if step.Selector == "" {
+				return fmt.Errorf("journey step %d: type needs a selector", i+1)
+			}
+		case StepAssertText:
+			if step.Text == "" {
+				return fmt.Errorf("journey step %d: assert_text needs text", i+1)
+			}
+		default:
+			return fmt.Errorf("journey step %d: unknown action %q: must be navigate, click, type, wait or assert_text", i+1, step.Action)
+		}
+	}
+	return nil
+}
+
+// StepResult is the outcome and timing of one journey step in a check
+type StepResult struct {
+	Step     string        `json:"step"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// FailureKind separates failures to reach an endpoint from bad answers it gave
+type FailureKind string
+
+const (
+	FailureNetwork     FailureKind = "network"     // Timeout, refused connection, DNS or TLS handshake error
+	FailureApplication FailureKind = "application" // Unexpected status code, body or certificate
+)
+
+// FailureThresholdFor returns the consecutive failures of a kind needed to mark the endpoint unhealthy
+func (e Endpoint) FailureThresholdFor(kind FailureKind) int {
+	switch {
+	case kind == FailureNetwork && e.NetworkThreshold > 0:
+		return e.NetworkThreshold
+	case kind == FailureApplication && e.AppThreshold > 0:
+		return e.AppThreshold
+	default:
+		return e.FailureThreshold
+	}
+}
+
 // EndpointState tracks the state of a monitored endpoint
 type EndpointState struct {
 	Endpoint             Endpoint
@@ -133,30 +931,309 @@ type EndpointState struct {
 	LastStatusChange     time.Time
 	ConsecutiveFailures  int
 	ConsecutiveSuccesses int
+	ConsecutiveNetwork   int       // Network failures since the last success
+	ConsecutiveApp       int       // Application failures since the last success
+	FailingSince         time.Time // First failed check since the last success
+	HealthySince         time.Time // First successful check since the last failure
 	ResponseTime         time.Duration
 	LastError            string
+	LastFailureKind      FailureKind // Kind of the last failure, empty after a success
 	Enabled              bool
 	AlertsSuppressed     bool
+	SuppressedUntil      time.Time // When a snooze lifts the suppression; zero for a permanent one
 	MonitorHealth        bool
+	HealthPaused         bool
+	SSLPaused            bool
 	ID                   string
 	CheckInterval        time.Duration
 	NextCheck            time.Time
 	SSLCertExpiry        time.Time
 	SSLExpiringSoon      bool
+	SSLUncoveredHosts    []string // Hostnames the certificate's SANs do not cover
+	SSLError             string   // Why the last SSL check could not read the certificate
+	TLSVersion           uint16   // Protocol version negotiated by the last SSL check
+	TLSCipher            uint16   // Cipher suite negotiated by the last SSL check
+	TLSLegacy            uint16   // TLS 1.0 or 1.1 version the server still accepts, 0 if neither
 	DaysToExpiry         int
-	LastSSLCheck         time.Time // Track when SSL was last validated (for daily check)
+	LastSSLCheck         time.Time     // Track when SSL was last validated (for daily check)
+	BackoffInterval      time.Duration // Stretched interval while persistently failing (0 = normal)
+	FailureAlertSent     bool          // Failure alert already sent for the current incident
+	SLAMonth             string        // Month (YYYY-MM) the SLA counters cover
+	SLATotalChecks       int           // Healthy plus unhealthy checks this month
+	SLAHealthyChecks     int           // Healthy checks this month
+	SLABreachAlerted     bool          // SLA breach alert sent for this month
+	BurnRateAlerted      bool          // Error-budget burn alert currently active
+	Acknowledged         bool          // Current incident acknowledged; left out of repeat grouped alerts
+	RateLimited          bool          // Last check was answered with 429 Too Many Requests
+	RateLimitedUntil     time.Time     // No checks before this time, from the Retry-After header
+	RateLimitCount       int           // 429 responses seen since the endpoint was added
+	LastRateLimited      time.Time     // When the last 429 response was received
+	ChecksRun            int           // Health checks started since the endpoint was added
+	MissedChecks         int           // Scheduled health checks that never ran, e.g. while the process stalled
+	LastMissedCheck      time.Time     // When missed checks were last detected
+	CreatedAt            time.Time     // When the endpoint was added
+}
+
+// ChecksHealth reports whether the endpoint's health is checked, rather than only its certificate
+func (e *EndpointState) ChecksHealth() bool {
+	return e.MonitorHealth && !e.HealthPaused
+}
+
+// InGracePeriod reports whether a new endpoint's failures should still go unalerted: it
+// was added less than grace ago and has not passed a check yet
+func (e *EndpointState) InGracePeriod(grace time.Duration, now time.Time) bool {
+	return grace > 0 && e.LastSuccess.IsZero() && now.Before(e.CreatedAt.Add(grace))
 }
 
 // ToEndpoint converts StoredEndpoint to Endpoint for monitoring
 func (s *StoredEndpoint) ToEndpoint() Endpoint {
 	return Endpoint{
-		Name:             s.Name,
-		URL:              s.URL,
-		Method:           s.Method,
-		Timeout:          Duration{Duration: s.Timeout},
-		ExpectedStatus:   s.ExpectedStatus,
-		Headers:          s.Headers,
-		FailureThreshold: s.FailureThreshold,
-		SuccessThreshold: s.SuccessThreshold,
+		Name:               s.Name,
+		URL:                s.URL,
+		Method:             s.Method,
+		Timeout:            Duration{Duration: s.Timeout},
+		ExpectedStatus:     s.ExpectedStatus,
+		Headers:            s.Headers,
+		FailureThreshold:   s.FailureThreshold,
+		NetworkThreshold:   s.NetworkThreshold,
+		AppThreshold:       s.AppThreshold,
+		SuccessThreshold:   s.SuccessThreshold,
+		FailureDuration:    Duration{Duration: s.FailureDuration},
+		SuccessDuration:    Duration{Duration: s.SuccessDuration},
+		ResolveTo:          s.ResolveTo,
+		HostHeader:         s.HostHeader,
+		DisableKeepAlive:   s.DisableKeepAlive,
+		MaxIdleConns:       s.MaxIdleConns,
+		UseCookies:         s.UseCookies,
+		BackoffEnabled:     s.BackoffEnabled,
+		BackoffAfter:       Duration{Duration: s.BackoffAfter},
+		BackoffMaxInterval: Duration{Duration: s.BackoffMaxInterval},
+		Retries:            s.Retries,
+		RetryDelay:         Duration{Duration: s.RetryDelay},
+		Priority:           s.Priority,
+		InsecureSkipVerify: s.InsecureSkipVerify,
+		CertFingerprint:    s.CertFingerprint,
+		Tags:               s.Tags,
+		SLATarget:          s.SLATarget,
+		ProjectID:          s.ProjectID,
+		Description:        s.Description,
+		Owner:              s.Owner,
+		RunbookURL:         s.RunbookURL,
+		Labels:             s.Labels,
+		RateLimitMode:      s.RateLimitMode,
+		Schedule:           s.Schedule,
+		CheckType:          s.CheckType,
+		WaitSelector:       s.WaitSelector,
+		Journey:            s.Journey,
+		ContentType:        s.ContentType,
+		SuccessExpression:  s.SuccessExpression,
+		BodyContains:       s.BodyContains,
+		BodyNotContains:    s.BodyNotContains,
+		BodyRegex:          s.BodyRegex,
+		AlternateHosts:     s.AlternateHosts,
+		CallbackURL:        s.CallbackURL,
+		HistorySample:      s.HistorySample,
+	}
+}
+
+// HasTag reports whether the endpoint carries the given tag (case-insensitive)
+func (e Endpoint) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// EndpointStateSnapshot is the persisted part of an EndpointState, restored on restart
+type EndpointStateSnapshot struct {
+	Status               HealthStatus  `json:"status"`
+	LastCheck            time.Time     `json:"last_check"`
+	LastSuccess          time.Time     `json:"last_success"`
+	LastStatusChange     time.Time     `json:"last_status_change"`
+	ConsecutiveFailures  int           `json:"consecutive_failures"`
+	ConsecutiveSuccesses int           `json:"consecutive_successes"`
+	ConsecutiveNetwork   int           `json:"consecutive_network_failures"`
+	ConsecutiveApp       int           `json:"consecutive_application_failures"`
+	FailingSince         time.Time     `json:"failing_since"`
+	HealthySince         time.Time     `json:"healthy_since"`
+	ResponseTime         time.Duration `json:"response_time"`
+	LastError            string        `json:"last_error"`
+	LastFailureKind      FailureKind   `json:"last_failure_kind"`
+	SSLCertExpiry        time.Time     `json:"ssl_cert_expiry"`
+	SSLExpiringSoon      bool          `json:"ssl_expiring_soon"`
+	SSLUncoveredHosts    []string      `json:"ssl_uncovered_hosts"`
+	SSLError             string        `json:"ssl_error"`
+	TLSVersion           uint16        `json:"tls_version"`
+	TLSCipher            uint16        `json:"tls_cipher"`
+	TLSLegacy            uint16        `json:"tls_legacy"`
+	DaysToExpiry         int           `json:"days_to_expiry"`
+	LastSSLCheck         time.Time     `json:"last_ssl_check"`
+	BackoffInterval      time.Duration `json:"backoff_interval"`
+	FailureAlertSent     bool          `json:"failure_alert_sent"`
+	SLAMonth             string        `json:"sla_month"`
+	SLATotalChecks       int           `json:"sla_total_checks"`
+	SLAHealthyChecks     int           `json:"sla_healthy_checks"`
+	SLABreachAlerted     bool          `json:"sla_breach_alerted"`
+	BurnRateAlerted      bool          `json:"burn_rate_alerted"`
+	Acknowledged         bool          `json:"acknowledged"`
+	RateLimited          bool          `json:"rate_limited"`
+	RateLimitedUntil     time.Time     `json:"rate_limited_until"`
+	RateLimitCount       int           `json:"rate_limit_count"`
+	LastRateLimited      time.Time     `json:"last_rate_limited"`
+	ChecksRun            int           `json:"checks_run"`
+	MissedChecks         int           `json:"missed_checks"`
+	LastMissedCheck      time.Time     `json:"last_missed_check"`
+	SavedAt              time.Time     `json:"saved_at"`
+}
+
+// Snapshot captures the persistable fields of the endpoint state
+func (e *EndpointState) Snapshot() *EndpointStateSnapshot {
+	return &EndpointStateSnapshot{
+		Status:               e.Status,
+		LastCheck:            e.LastCheck,
+		LastSuccess:          e.LastSuccess,
+		LastStatusChange:     e.LastStatusChange,
+		ConsecutiveFailures:  e.ConsecutiveFailures,
+		ConsecutiveSuccesses: e.ConsecutiveSuccesses,
+		ConsecutiveNetwork:   e.ConsecutiveNetwork,
+		ConsecutiveApp:       e.ConsecutiveApp,
+		FailingSince:         e.FailingSince,
+		HealthySince:         e.HealthySince,
+		ResponseTime:         e.ResponseTime,
+		LastError:            e.LastError,
+		LastFailureKind:      e.LastFailureKind,
+		SSLCertExpiry:        e.SSLCertExpiry,
+		SSLExpiringSoon:      e.SSLExpiringSoon,
+		SSLUncoveredHosts:    e.SSLUncoveredHosts,
+		SSLError:             e.SSLError,
+		TLSVersion:           e.TLSVersion,
+		TLSCipher:            e.TLSCipher,
+		TLSLegacy:            e.TLSLegacy,
+		DaysToExpiry:         e.DaysToExpiry,
+		LastSSLCheck:         e.LastSSLCheck,
+		BackoffInterval:      e.BackoffInterval,
+		FailureAlertSent:     e.FailureAlertSent,
+		SLAMonth:             e.SLAMonth,
+		SLATotalChecks:       e.SLATotalChecks,
+		SLAHealthyChecks:     e.SLAHealthyChecks,
+		SLABreachAlerted:     e.SLABreachAlerted,
+		BurnRateAlerted:      e.BurnRateAlerted,
+		Acknowledged:         e.Acknowledged,
+		RateLimited:          e.RateLimited,
+		RateLimitedUntil:     e.RateLimitedUntil,
+		RateLimitCount:       e.RateLimitCount,
+		LastRateLimited:      e.LastRateLimited,
+		ChecksRun:            e.ChecksRun,
+		MissedChecks:         e.MissedChecks,
+		LastMissedCheck:      e.LastMissedCheck,
+		SavedAt:              time.Now(),
 	}
 }
+
+// Restore applies a persisted snapshot to the endpoint state
+func (e *EndpointState) Restore(snapshot *EndpointStateSnapshot) {
+	e.Status = snapshot.Status
+	e.LastCheck = snapshot.LastCheck
+	e.LastSuccess = snapshot.LastSuccess
+	e.LastStatusChange = snapshot.LastStatusChange
+	e.ConsecutiveFailures = snapshot.ConsecutiveFailures
+	e.ConsecutiveSuccesses = snapshot.ConsecutiveSuccesses
+	e.ConsecutiveNetwork = snapshot.ConsecutiveNetwork
+	e.ConsecutiveApp = snapshot.ConsecutiveApp
+	e.FailingSince = snapshot.FailingSince
+	e.HealthySince = snapshot.HealthySince
+	e.ResponseTime = snapshot.ResponseTime
+	e.LastError = snapshot.LastError
+	e.LastFailureKind = snapshot.LastFailureKind
+	e.SSLCertExpiry = snapshot.SSLCertExpiry
+	e.SSLExpiringSoon = snapshot.SSLExpiringSoon
+	e.SSLUncoveredHosts = snapshot.SSLUncoveredHosts
+	e.SSLError = snapshot.SSLError
+	e.TLSVersion = snapshot.TLSVersion
+	e.TLSCipher = snapshot.TLSCipher
+	e.TLSLegacy = snapshot.TLSLegacy
+	e.DaysToExpiry = snapshot.DaysToExpiry
+	e.LastSSLCheck = snapshot.LastSSLCheck
+	e.BackoffInterval = snapshot.BackoffInterval
+	e.FailureAlertSent = snapshot.FailureAlertSent
+	e.SLAMonth = snapshot.SLAMonth
+	e.SLATotalChecks = snapshot.SLATotalChecks
+	e.SLAHealthyChecks = snapshot.SLAHealthyChecks
+	e.SLABreachAlerted = snapshot.SLABreachAlerted
+	e.BurnRateAlerted = snapshot.BurnRateAlerted
+	e.Acknowledged = snapshot.Acknowledged
+	e.RateLimited = snapshot.RateLimited
+	e.RateLimitedUntil = snapshot.RateLimitedUntil
+	e.RateLimitCount = snapshot.RateLimitCount
+	e.LastRateLimited = snapshot.LastRateLimited
+	e.ChecksRun = snapshot.ChecksRun
+	e.MissedChecks = snapshot.MissedChecks
+	e.LastMissedCheck = snapshot.LastMissedCheck
+}
+
+// Outbox message statuses
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxDead      = "dead"
+)
+
+// OutboxMessage is a notification persisted until it is delivered or retries run out
+type OutboxMessage struct {
+	ID          string            `json:"id"`
+	AlertID     string            `json:"alert_id,omitempty"`
+	ProjectID   string            `json:"project_id,omitempty"`
+	Channel     string            `json:"channel"`
+	Description string            `json:"description"`
+	URL         string            `json:"url,omitempty"`
+	Recipients  []string          `json:"recipients,omitempty"`
+	Subject     string            `json:"subject,omitempty"`
+	Body        string            `json:"body"`
+	Status      string            `json:"status"`
+	Attempts    int               `json:"attempts"`
+	LastError   string            `json:"last_error,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	NextAttempt time.Time         `json:"next_attempt"`
+	DeliveredAt *time.Time        `json:"delivered_at,omitempty"`
+	History     []DeliveryAttempt `json:"history,omitempty"`
+}
+
+// DeliveryAttempt records one try at delivering an outbox message
+type DeliveryAttempt struct {
+	Time       time.Time `json:"time"`
+	StatusCode int       `json:"status_code,omitempty"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Report is a stored monthly availability report
+type Report struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Month        string           `json:"month"`
+	Filter       Subscription     `json:"filter"`
+	GeneratedAt  time.Time        `json:"generated_at"`
+	Checks       int              `json:"checks"`
+	Availability float64          `json:"availability"`
+	SLABreaches  int              `json:"sla_breaches"`
+	Endpoints    []ReportEndpoint `json:"endpoints"`
+}
+
+// ReportEndpoint is one endpoint's availability for a report month.
+// Availability is -1 when the endpoint had no healthy or unhealthy checks.
+type ReportEndpoint struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	URL           string   `json:"url"`
+	ProjectID     string   `json:"project_id,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Checks        int      `json:"checks"`
+	Healthy       int      `json:"healthy"`
+	Unhealthy     int      `json:"unhealthy"`
+	Availability  float64  `json:"availability"`
+	AvgResponseMs float64  `json:"avg_response_ms"`
+	SLATarget     float64  `json:"sla_target,omitempty"`
+	SLAMet        *bool    `json:"sla_met,omitempty"`
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateAPIKey returns a random API key with the given prefix
+func GenerateAPIKey(prefix string) (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return prefix + hex.EncodeToString(buf), nil
+}
+
+// HashAPIKey returns the SHA-256 hash of an API key for storage
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
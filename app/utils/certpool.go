@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadCABundle returns the system roots plus the PEM certificates in path
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// ValidateContentType checks an expected content type is a media type such as
+// application/json, text/* or text/html; charset=utf-8
+func ValidateContentType(expected string) error {
+	mediaType, _, err := mime.ParseMediaType(expected)
+	if err != nil || !strings.Contains(mediaType, "/") || strings.HasPrefix(mediaType, "*") {
+		return fmt.Errorf("invalid content type %q: must be a media type like application/json", expected)
+	}
+	return nil
+}
+
+// MatchContentType checks a response's Content-Type header against an expected media type.
+// The subtype may be *, and a charset is only compared when the expected type names one.
+func MatchContentType(got, expected string) error {
+	wantType, wantParams, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return fmt.Errorf("invalid expected content type %q", expected)
+	}
+	if got == "" {
+		return fmt.Errorf("missing Content-Type, expected %s", expected)
+	}
+
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err != nil {
+		return fmt.Errorf("unexpected content type: got %q, expected %s", got, expected)
+	}
+
+	if prefix, ok := strings.CutSuffix(wantType, "/*"); ok {
+		if !strings.HasPrefix(gotType, prefix+"/") {
+			return fmt.Errorf("unexpected content type: got %s, expected %s", got, expected)
+		}
+	} else if gotType != wantType {
+		return fmt.Errorf("unexpected content type: got %s, expected %s", got, expected)
+	}
+
+	if charset, ok := wantParams["charset"]; ok && !strings.EqualFold(gotParams["charset"], charset) {
+		return fmt.Errorf("unexpected charset: got %s, expected %s", got, expected)
+	}
+	return nil
+}
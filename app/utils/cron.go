@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard 5-field cron expression (minute hour day-of-month month day-of-week)
+type CronSchedule struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	anyDay   bool
+	anyDow   bool
+	anyHour  bool
+}
+
+// ParseCron parses a 5-field cron expression supporting *, lists, ranges and steps
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	var schedule CronSchedule
+	var err error
+
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+
+	// Both 0 and 7 mean Sunday
+	if schedule.weekdays[7] {
+		schedule.weekdays[0] = true
+	}
+
+	schedule.anyDay = fields[2] == "*"
+	schedule.anyDow = fields[4] == "*"
+	schedule.anyHour = len(schedule.hours) == 24
+
+	return &schedule, nil
+}
+
+// parseCronField expands a single cron field into the set of matching values
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", part[idx+1:])
+			}
+			part = part[:idx]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			lo, hi = v, v
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value out of range %d-%d: %q", min, max, part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// matchesDay applies cron's rule that day-of-month and day-of-week are OR'd when both are restricted
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	dom := c.days[t.Day()]
+	dow := c.weekdays[int(t.Weekday())]
+
+	switch {
+	case c.anyDay && c.anyDow:
+		return true
+	case c.anyDay:
+		return dow
+	case c.anyDow:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first matching time strictly after t, in t's location. Like cron, schedules
+// that run every hour follow elapsed time across DST changes, while fixed hours follow the wall
+// clock: a time skipped when clocks go forward runs after the jump, and a repeated time runs once.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	if c.anyHour {
+		return c.next(t)
+	}
+
+	// Search the wall clock in UTC, where every minute exists exactly once
+	loc := t.Location()
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	for {
+		wall = c.next(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		next := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, loc)
+		if next.Hour() != wall.Hour() || next.Minute() != wall.Minute() {
+			// The clocks skipped this time; read it with the offset from before the jump
+			_, offset := next.Add(-12 * time.Hour).Zone()
+			next = wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		}
+		// A repeated wall-clock time already ran at its first occurrence
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// next returns the first matching time strictly after t, stepping through t's location
+func (c *CronSchedule) next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+
+	// Search up to five years ahead to cover expressions like Feb 29
+	limit := next.AddDate(5, 0, 0)
+	for next.Before(limit) {
+		if !c.months[int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"1-x * * * *",
+		"a * * * *",
+	}
+	for _, expr := range tests {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(s string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04 MST", s, newYork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from string
+		want string
+	}{
+		{"every minute", "* * * * *", "2026-10-01 12:00 EDT", "2026-10-01 12:01 EDT"},
+		{"strictly after", "0 12 * * *", "2026-10-01 12:00 EDT", "2026-10-02 12:00 EDT"},
+		{"step within range", "*/15 9-17 * * 1-5", "2026-10-02 17:50 EDT", "2026-10-05 09:00 EDT"},
+		{"list", "0 6,18 * * *", "2026-10-01 07:00 EDT", "2026-10-01 18:00 EDT"},
+		{"day of month or weekday", "0 9 13 * 5", "2026-10-01 00:00 EDT", "2026-10-02 09:00 EDT"},
+		{"day of month only", "0 9 13 * *", "2026-10-01 00:00 EDT", "2026-10-13 09:00 EDT"},
+		{"sunday as 7", "0 0 * * 7", "2026-10-01 00:00 EDT", "2026-10-04 00:00 EDT"},
+		{"month rollover", "0 0 1 1 *", "2026-10-01 00:00 EDT", "2027-01-01 00:00 EST"},
+		{"leap day", "0 0 29 2 *", "2026-03-01 00:00 EST", "2028-02-29 00:00 EST"},
+
+		// Clocks go forward at 02:00 on 2026-03-08 and back at 02:00 on 2026-11-01
+		{"skipped hour runs after the jump", "30 2 * * *", "2026-03-08 01:00 EST", "2026-03-08 03:30 EDT"},
+		{"hour after the jump", "0 3 * * *", "2026-03-08 01:00 EST", "2026-03-08 03:00 EDT"},
+		{"hourly across the jump forward", "0 * * * *", "2026-03-08 01:30 EST", "2026-03-08 03:00 EDT"},
+		{"repeated hour runs at the first", "30 1 * * *", "2026-11-01 00:00 EDT", "2026-11-01 01:30 EDT"},
+		{"repeated hour runs once", "30 1 * * *", "2026-11-01 01:30 EDT", "2026-11-02 01:30 EST"},
+		{"repeated hour runs once from the second pass", "30 1 * * *", "2026-11-01 01:10 EST", "2026-11-02 01:30 EST"},
+		{"hourly across the jump back", "0 * * * *", "2026-11-01 01:30 EDT", "2026-11-01 01:00 EST"},
+		{"every minute across the jump back", "* * * * *", "2026-11-01 01:59 EDT", "2026-11-01 01:00 EST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := schedule.Next(at(tt.from))
+			if want := at(tt.want); !got.Equal(want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got.Format("2006-01-02 15:04 MST"), tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextImpossible(t *testing.T) {
+	schedule, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Next = %v, want zero for a date that never occurs", next)
+	}
+}
@@ -0,0 +1,706 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ExprEnv is what a success expression can see of a check's response
+type ExprEnv struct {
+	Status  int
+	Latency time.Duration
+	Headers http.Header
+	Body    []byte
+
+	json    interface{}
+	jsonErr error
+	parsed  bool
+}
+
+// Expr is a compiled success expression such as
+// `status in [200, 204] && latency < 800ms && json.status == "ok"`
+type Expr struct {
+	source string
+	root   exprNode
+	body   bool
+}
+
+// CompileExpr parses a success expression
+func CompileExpr(source string) (*Expr, error) {
+	p := &exprParser{source: source}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+	return &Expr{source: source, root: root, body: p.body}, nil
+}
+
+// String returns the expression source
+func (e *Expr) String() string { return e.source }
+
+// NeedsBody reports whether the expression reads the response body
+func (e *Expr) NeedsBody() bool { return e.body }
+
+// Eval evaluates the expression against a response and reports whether the check passed
+func (e *Expr) Eval(env *ExprEnv) (bool, error) {
+	value, err := e.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must be true or false, got %s", exprType(value))
+	}
+	return result, nil
+}
+
+// parsedJSON decodes the body on first use
+func (env *ExprEnv) parsedJSON() (interface{}, error) {
+	if !env.parsed {
+		env.parsed = true
+		if err := json.Unmarshal(env.Body, &env.json); err != nil {
+			env.jsonErr = fmt.Errorf("body is not valid JSON: %v", err)
+		}
+	}
+	return env.json, env.jsonErr
+}
+
+// Tokens
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokDuration
+	tokString
+	tokIdent
+	tokOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+	num  float64
+	dur  time.Duration
+	str  string
+}
+
+type exprParser struct {
+	source string
+	tokens []exprToken
+	next   int
+	body   bool
+}
+
+// exprOps lists operators longest first so "<=" is not read as "<"
+var exprOps = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",", "."}
+
+func (p *exprParser) tokenize() error {
+	src := p.source
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c >= '0' && c <= '9':
+			// A number followed by units, as in 800ms or 1h30m, is a duration
+			start, unit := i, false
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' || src[i] >= 'a' && src[i] <= 'z') {
+				unit = unit || src[i] >= 'a'
+				i++
+			}
+			text := src[start:i]
+			if unit {
+				d, err := time.ParseDuration(text)
+				if err != nil {
+					return fmt.Errorf("invalid duration %q at position %d", text, start+1)
+				}
+				p.tokens = append(p.tokens, exprToken{kind: tokDuration, text: text, pos: start, dur: d})
+				continue
+			}
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q at position %d", text, start+1)
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokNumber, text: text, pos: start, num: n})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			var b strings.Builder
+			for {
+				if i >= len(src) {
+					return fmt.Errorf("unterminated string at position %d", start+1)
+				}
+				if src[i] == byte(c) {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				b.WriteByte(src[i])
+				i++
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokString, text: src[start:i], pos: start, str: b.String()})
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] >= 'a' && src[i] <= 'z' || src[i] >= 'A' && src[i] <= 'Z' || src[i] >= '0' && src[i] <= '9') {
+				i++
+			}
+			p.tokens = append(p.tokens, exprToken{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			matched := false
+			for _, op := range exprOps {
+				if strings.HasPrefix(src[i:], op) {
+					p.tokens = append(p.tokens, exprToken{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return fmt.Errorf("unexpected %q at position %d", string(c), i+1)
+			}
+		}
+	}
+	p.tokens = append(p.tokens, exprToken{kind: tokEOF, text: "end of expression", pos: len(src)})
+	return nil
+}
+
+func (p *exprParser) peek() exprToken { return p.tokens[p.next] }
+
+func (p *exprParser) take() exprToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the given operator or keyword
+func (p *exprParser) accept(text string) bool {
+	tok := p.peek()
+	if (tok.kind == tokOp || tok.kind == tokIdent) && tok.text == text {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) expect(text string) error {
+	if !p.accept(text) {
+		tok := p.peek()
+		return fmt.Errorf("expected %q but found %q at position %d", text, tok.text, tok.pos+1)
+	}
+	return nil
+}
+
+// Grammar, loosest binding first:
+//
+//	or      = and { "||" and }
+//	and     = not { "&&" not }
+//	not     = "!" not | compare
+//	compare = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" | "contains" | "matches" ) operand ]
+//	operand = primary { "." name | "[" or "]" }
+//	primary = number | duration | string | true | false | null | list | name | name "(" or ")" | "(" or ")"
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicNode{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.accept("!") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *exprParser) parseCompare() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">", "in", "contains", "matches"} {
+		if !p.accept(op) {
+			continue
+		}
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		node := &compareNode{op: op, left: left, right: right}
+		if op == "matches" {
+			if lit, ok := right.(*literalNode); ok {
+				pattern, ok := lit.value.(string)
+				if !ok {
+					return nil, fmt.Errorf("matches needs a regular expression string")
+				}
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+				}
+				node.re = re
+			}
+		}
+		return node, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseOperand() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.accept("."):
+			tok := p.take()
+			if tok.kind != tokIdent {
+				return nil, fmt.Errorf("expected a field name at position %d", tok.pos+1)
+			}
+			node = &indexNode{target: node, index: &literalNode{value: tok.text}}
+		case p.accept("["):
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = &indexNode{target: node, index: index}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.take()
+	switch tok.kind {
+	case tokNumber:
+		return &literalNode{value: tok.num}, nil
+	case tokDuration:
+		return &literalNode{value: tok.dur}, nil
+	case tokString:
+		return &literalNode{value: tok.str}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "status", "latency", "headers":
+			return &varNode{name: tok.text}, nil
+		case "body", "json":
+			p.body = true
+			return &varNode{name: tok.text}, nil
+		case "len", "lower":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return &callNode{name: tok.text, arg: arg}, nil
+		}
+		return nil, fmt.Errorf("unknown name %q at position %d (use status, latency, headers, body or json)", tok.text, tok.pos+1)
+	case tokOp:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			list := &listNode{}
+			if p.accept("]") {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if p.accept("]") {
+					return list, nil
+				}
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+}
+
+// Evaluation
+
+type exprNode interface {
+	eval(env *ExprEnv) (interface{}, error)
+}
+
+type literalNode struct{ value interface{} }
+
+func (n *literalNode) eval(*ExprEnv) (interface{}, error) { return n.value, nil }
+
+type varNode struct{ name string }
+
+func (n *varNode) eval(env *ExprEnv) (interface{}, error) {
+	switch n.name {
+	case "status":
+		return float64(env.Status), nil
+	case "latency":
+		return env.Latency, nil
+	case "headers":
+		return env.Headers, nil
+	case "body":
+		return string(env.Body), nil
+	}
+	return env.parsedJSON()
+}
+
+type listNode struct{ items []exprNode }
+
+func (n *listNode) eval(env *ExprEnv) (interface{}, error) {
+	values := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		value, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// indexNode reads a field or element; anything missing reads as null
+type indexNode struct{ target, index exprNode }
+
+func (n *indexNode) eval(env *ExprEnv) (interface{}, error) {
+	target, err := n.target.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch t := target.(type) {
+	case http.Header:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("header names must be strings")
+		}
+		if _, present := t[http.CanonicalHeaderKey(key)]; !present {
+			return nil, nil
+		}
+		return t.Get(key), nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("object keys must be strings, got %s", exprType(index))
+		}
+		return t[key], nil
+	case []interface{}:
+		i, ok := index.(float64)
+		if !ok || i != float64(int(i)) {
+			return nil, fmt.Errorf("array indexes must be whole numbers, got %s", exprType(index))
+		}
+		if i < 0 || int(i) >= len(t) {
+			return nil, nil
+		}
+		return t[int(i)], nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("cannot index %s", exprType(target))
+}
+
+type callNode struct {
+	name string
+	arg  exprNode
+}
+
+func (n *callNode) eval(env *ExprEnv) (interface{}, error) {
+	arg, err := n.arg.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.name {
+	case "len":
+		switch v := arg.(type) {
+		case string:
+			return float64(len(v)), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		case nil:
+			return float64(0), nil
+		}
+		return nil, fmt.Errorf("len needs a string, array or object, got %s", exprType(arg))
+	default:
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("lower needs a string, got %s", exprType(arg))
+		}
+		return strings.ToLower(s), nil
+	}
+}
+
+type notNode struct{ inner exprNode }
+
+func (n *notNode) eval(env *ExprEnv) (interface{}, error) {
+	value, err := n.inner.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("! needs true or false, got %s", exprType(value))
+	}
+	return !b, nil
+}
+
+// logicNode is && or ||, evaluated left to right with short-circuiting
+type logicNode struct {
+	or          bool
+	left, right exprNode
+}
+
+func (n *logicNode) eval(env *ExprEnv) (interface{}, error) {
+	op := "&&"
+	if n.or {
+		op = "||"
+	}
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := left.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%s needs true or false, got %s", op, exprType(left))
+	}
+	if l == n.or {
+		return l, nil
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := right.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%s needs true or false, got %s", op, exprType(right))
+	}
+	return r, nil
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+	re          *regexp.Regexp
+}
+
+func (n *compareNode) eval(env *ExprEnv) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return exprEqual(left, right), nil
+	case "!=":
+		return !exprEqual(left, right), nil
+	case "in":
+		return exprContains(right, left)
+	case "contains":
+		return exprContains(left, right)
+	case "matches":
+		s, ok := left.(string)
+		if !ok {
+			return nil, fmt.Errorf("matches needs a string on the left, got %s", exprType(left))
+		}
+		re := n.re
+		if re == nil {
+			pattern, ok := right.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches needs a regular expression string")
+			}
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+			}
+		}
+		return re.MatchString(s), nil
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", exprType(left), exprType(right))
+		}
+		cmp = compareOrdered(l, r)
+	case time.Duration:
+		r, ok := right.(time.Duration)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s, write durations with a unit such as 800ms", exprType(left), exprType(right))
+		}
+		cmp = compareOrdered(l, r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %s with %s", exprType(left), exprType(right))
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return nil, fmt.Errorf("cannot compare %s with %s", exprType(left), exprType(right))
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func compareOrdered[T float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// exprEqual compares values of any type; values of different types are never equal
+func exprEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// exprContains reports whether a list holds an element, a string a substring, or an object a key
+func exprContains(container, item interface{}) (bool, error) {
+	switch c := container.(type) {
+	case []interface{}:
+		for _, element := range c {
+			if exprEqual(element, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		s, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("a string can only contain strings, got %s", exprType(item))
+		}
+		return strings.Contains(c, s), nil
+	case map[string]interface{}:
+		key, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("object keys are strings, got %s", exprType(item))
+		}
+		_, present := c[key]
+		return present, nil
+	case nil:
+		return false, nil
+	}
+	return false, fmt.Errorf("cannot look inside %s", exprType(container))
+}
+
+// exprType names a value's type for error messages
+func exprType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return "a number"
+	case time.Duration:
+		return "a duration"
+	case string:
+		return "a string"
+	case bool:
+		return "true/false"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}, http.Header:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExprEval(t *testing.T) {
+	env := func() *ExprEnv {
+		return &ExprEnv{
+			Status:  200,
+			Latency: 350 * time.Millisecond,
+			Headers: http.Header{"Content-Type": {"application/json"}},
+			Body:    []byte(`{"status":"ok","db":{"up":true},"items":[{"id":7}],"errors":[],"version":"v2.3.1"}`),
+		}
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		// && binds tighter than ||, and ! tighter than &&
+		{"true || false && false", true},
+		{"(true || false) && false", false},
+		{"false && false || true", true},
+		{"!false && false", false},
+		{"!(false && false)", true},
+		{"!true || true", true},
+		{"!!true", true},
+		// Comparisons bind tighter than logic, and ! applies to the whole comparison
+		{"status == 200 && latency < 1s", true},
+		{"status == 500 || latency < 1s", true},
+		{"!status == 500", true},
+
+		{"status in [200, 204]", true},
+		{"status in []", false},
+		{"status != 200", false},
+		{"status >= 200 && status <= 299", true},
+		{"latency > 350ms", false},
+		{"latency >= 0.35s", true},
+		{"latency < 1h30m", true},
+		{`headers["content-type"] == "application/json"`, true},
+		{`headers["X-Missing"] == null`, true},
+		{`json.status == "ok"`, true},
+		{`json.status == 'ok'`, true},
+		{"json.db.up", true},
+		{"json.items[0].id == 7", true},
+		{"json.items[1].id == null", true},
+		{"json.missing.deeper == null", true},
+		{"len(json.errors) == 0", true},
+		{"len(json.items) == 1 && len(json.missing) == 0", true},
+		{`lower(headers["Content-Type"]) contains "json"`, true},
+		{`json contains "db"`, true},
+		{`json.version matches "^v[0-9]+\\.[0-9]+"`, true},
+		{`body contains "\"up\":true"`, true},
+		{`"ok" in json.items`, false},
+		{`json.status == 1`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := CompileExpr(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := expr.Eval(env())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprShortCircuit(t *testing.T) {
+	env := &ExprEnv{Status: 503, Body: []byte("not json")}
+	for _, source := range []string{"status == 200 && json.ok", "status != 200 || json.ok"} {
+		expr, err := CompileExpr(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := expr.Eval(env); err != nil {
+			t.Errorf("%s: right side was evaluated: %v", source, err)
+		}
+	}
+}
+
+func TestExprNeedsBody(t *testing.T) {
+	tests := map[string]bool{
+		"status == 200":               false,
+		`headers["Etag"] != null`:     false,
+		`body contains "ok"`:          true,
+		"status == 200 && json.ok":    true,
+		`lower(body) matches "error"`: true,
+	}
+	for source, want := range tests {
+		expr, err := CompileExpr(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := expr.NeedsBody(); got != want {
+			t.Errorf("NeedsBody(%s) = %v, want %v", source, got, want)
+		}
+	}
+}
+
+func TestCompileExprErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"status ==",
+		"status == 200 200",
+		// Comparisons do not chain
+		"status == 200 == true",
+		"(status == 200",
+		"status == 200)",
+		"[200, 204",
+		"unknown == 1",
+		"len json",
+		`"unterminated`,
+		"latency < 8xs",
+		"status &",
+		"status matches \"(\"",
+	}
+	for _, source := range tests {
+		if _, err := CompileExpr(source); err == nil {
+			t.Errorf("CompileExpr(%q) succeeded, want an error", source)
+		}
+	}
+}
+
+func TestExprEvalErrors(t *testing.T) {
+	env := &ExprEnv{Status: 200, Latency: time.Second, Body: []byte("not json")}
+	tests := []string{
+		"status",
+		"latency < 800",
+		`status < "500"`,
+		"!status",
+		"status && true",
+		"json.ok",
+		"len(status) == 0",
+		"lower(status) == \"200\"",
+		"status contains 2",
+	}
+	for _, source := range tests {
+		expr, err := CompileExpr(source)
+		if err != nil {
+			t.Fatalf("CompileExpr(%q): %v", source, err)
+		}
+		if _, err := expr.Eval(env); err == nil {
+			t.Errorf("Eval(%q) succeeded, want an error", source)
+		}
+	}
+}
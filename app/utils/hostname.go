@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ValidateHostname checks a name is a bare hostname or IP address, without scheme, port or path
+func ValidateHostname(host string) error {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	name := strings.TrimSuffix(host, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("invalid hostname %q", host)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid hostname %q", host)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return fmt.Errorf("invalid hostname %q", host)
+			}
+		}
+	}
+	return nil
+}
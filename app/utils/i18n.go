@@ -0,0 +1,467 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is used when no language is configured and for keys missing from a catalog
+const DefaultLanguage = "en"
+
+// messageCatalogs maps a language to message templates with {placeholder} variables
+var messageCatalogs = map[string]map[string]string{
+	"en": {
+		"alert.failure.subject":             "[CRONZEE] Alert: {name} is DOWN",
+		"alert.failure.body":                "🔴 ALERT: Endpoint '{name}' is UNHEALTHY\n\nURL: {url}\nStatus: {status}\nConsecutive Failures: {failures}\nLast Error: {error}\nLast Check: {last_check}\nResponse Time: {response_time}",
+		"alert.recovery.subject":            "[CRONZEE] Recovery: {name} is UP",
+		"alert.recovery.body":               "✅ RECOVERY: Endpoint '{name}' is HEALTHY\n\nURL: {url}\nStatus: {status}\nDowntime: {downtime}\nResponse Time: {response_time}\nLast Check: {last_check}",
+		"alert.sla.subject":                 "[CRONZEE] SLA: {name}",
+		"alert.sla_burn.subject":            "[CRONZEE] Error budget burn: {name}",
+		"alert.sla.body":                    "📉 SLA: Endpoint '{name}'\n\nURL: {url}\nSLA Target: {target}%\n{detail}",
+		"alert.service_failure.subject":     "[CRONZEE] Service: {name} is DOWN",
+		"alert.service_failure.body":        "🔴 SERVICE: '{name}' is DOWN\n\nPolicy: {policy}\nHealthy Endpoints: {healthy}/{total}\nUnhealthy Endpoints: {unhealthy}\nHealthy Score: {score}%",
+		"alert.service_recovery.subject":    "[CRONZEE] Service: {name} is UP",
+		"alert.service_recovery.body":       "✅ SERVICE: '{name}' is UP\n\nPolicy: {policy}\nHealthy Endpoints: {healthy}/{total}\nUnhealthy Endpoints: {unhealthy}\nHealthy Score: {score}%",
+		"alert.self_failure.subject":        "[CRONZEE] SiteWatch: {check} is failing",
+		"alert.self_failure.body":           "🔴 SITEWATCH IS UNHEALTHY: {check}\n\n{detail}",
+		"alert.self_recovery.subject":       "[CRONZEE] SiteWatch: {check} recovered",
+		"alert.self_recovery.body":          "✅ SITEWATCH RECOVERED: {check}\n\n{detail}",
+		"alert.ssl_san.subject":             "[CRONZEE] Certificate does not cover: {name}",
+		"alert.ssl_san.body":                "🔐 CERTIFICATE HOSTNAME MISMATCH: {name}\n\nURL: {url}\nNot covered by the certificate: {hosts}\nBrowsers will reject the certificate for these hostnames.",
+		"alert.ssl_san_recovery.subject":    "[CRONZEE] Certificate hostnames fixed: {name}",
+		"alert.ssl_san_recovery.body":       "✅ CERTIFICATE HOSTNAMES COVERED: {name}\n\nURL: {url}\nNow covered: {hosts}",
+		"alert.tls_legacy.subject":          "[CRONZEE] Legacy TLS accepted: {name}",
+		"alert.tls_legacy.body":             "🔓 LEGACY TLS ACCEPTED: {name}\n\nURL: {url}\nThe server still accepts {version}, which is deprecated and fails most compliance baselines.",
+		"alert.tls_legacy_recovery.subject": "[CRONZEE] Legacy TLS disabled: {name}",
+		"alert.tls_legacy_recovery.body":    "✅ LEGACY TLS DISABLED: {name}\n\nURL: {url}\nThe server no longer accepts {version}.",
+		"alert.tls_downgrade.subject":       "[CRONZEE] TLS downgrade: {name}",
+		"alert.tls_downgrade.body":          "⬇️ TLS DOWNGRADE: {name}\n\nURL: {url}\nNegotiated {to} ({cipher}), previously {from}.",
+		"self.database":                     "Database writes",
+		"self.database.detail":              "{count} database writes failed in the last {window}",
+		"self.alert_delivery":               "Alert delivery",
+		"self.alert_delivery.detail":        "{failed} of {total} alert deliveries failed ({rate}%) in the last {window}",
+		"self.scheduler":                    "Scheduler",
+		"self.scheduler.detail":             "{count} checks are overdue",
+		"alert.description":                 "Description: {description}",
+		"alert.owner":                       "Owner: {owner}",
+		"alert.runbook":                     "Runbook: {runbook}",
+		"alert.labels":                      "Labels: {labels}",
+		"field.labels":                      "Labels",
+		"field.description":                 "Description",
+		"field.owner":                       "Owner",
+		"field.runbook":                     "Runbook",
+		"teams.runbook":                     "Open Runbook",
+		"field.endpoint":                    "Endpoint",
+		"field.url":                         "URL",
+		"field.status":                      "Status",
+		"field.severity":                    "Severity",
+		"field.response_time":               "Response Time",
+		"field.error":                       "Error",
+		"field.site_name":                   "Site Name",
+		"field.last_success":                "Last Success",
+		"field.last_success_time":           "Last Success Time",
+		"field.down_for":                    "Down For",
+		"field.down_duration":               "Down Duration",
+		"field.failures":                    "Failures",
+		"field.failure_count":               "Failure Count",
+		"field.expiry_date":                 "Expiry Date",
+		"field.days_left":                   "Days Left",
+		"footer":                            "Cronzee Health Monitor",
+		"more_info":                         "🔗 For more info visit: {url}",
+		"teams.title":                       "📢 HEALTH MONITOR ALERT ({minutes} min)",
+		"teams.down":                        "🔴 DOWN",
+		"teams.acknowledge":                 "Acknowledge",
+		"teams.suppress":                    "Suppress Alerts",
+		"teams.history":                     "View History",
+		"ssl.title":                         "SSL EXPIRY NOTIFICATIONS",
+		"ssl.subject":                       "[CRONZEE] SSL expiry summary: {count} certificates",
+		"email.batch.subject":               "[CRONZEE] {count} alerts: {names}",
+		"email.batch.title":                 "{count} ALERTS FROM {from} TO {to}",
+		"email.batch.details":               "DETAILS",
+		"field.alert":                       "Alert",
+		"ssl.line":                          "{emoji} *{name}* ({url}) expires {date}, {days} days left",
+		"ssl.warning":                       "⚠️ Warning",
+		"ssl.critical":                      "🚨 Critical",
+		"ssl.expired_title":                 "EXPIRED CERTIFICATES ({count})",
+		"ssl.expired_line":                  "🛑 *{name}* ({url}) expired {date}, {days} days ago",
+		"ssl.failed_title":                  "CERTIFICATES THAT COULD NOT BE CHECKED ({count})",
+		"ssl.failed_line":                   "❓ *{name}* ({url}): {error}",
+		"field.days_ago":                    "Days Ago",
+		"digest.subject":                    "[CRONZEE] Digest {name}: {down} down, {incidents} incidents",
+		"digest.title":                      "MONITOR HEALTH DIGEST ({name})",
+		"digest.period":                     "Period: {from} to {to}",
+		"digest.endpoints":                  "Endpoints: {total} monitored, {down} down",
+		"digest.down_now":                   "DOWN NOW ({count})",
+		"digest.down_for":                   "down for",
+		"digest.incidents":                  "INCIDENTS ({count})",
+		"digest.more":                       "... and {count} more",
+		"digest.regressions":                "LATENCY REGRESSIONS (p95 vs previous period)",
+		"digest.certificates":               "UPCOMING CERTIFICATE EXPIRIES ({count})",
+		"digest.days":                       "({days} days)",
+		"digest.dashboard":                  "Dashboard: {url}",
+		"none":                              "None",
+	},
+	"de": {
+		"alert.failure.subject":             "[CRONZEE] Alarm: {name} ist AUSGEFALLEN",
+		"alert.failure.body":                "🔴 ALARM: Endpunkt '{name}' ist FEHLERHAFT\n\nURL: {url}\nStatus: {status}\nAufeinanderfolgende Fehler: {failures}\nLetzter Fehler: {error}\nLetzte Prüfung: {last_check}\nAntwortzeit: {response_time}",
+		"alert.recovery.subject":            "[CRONZEE] Wiederhergestellt: {name} ist ERREICHBAR",
+		"alert.recovery.body":               "✅ WIEDERHERGESTELLT: Endpunkt '{name}' ist FEHLERFREI\n\nURL: {url}\nStatus: {status}\nAusfallzeit: {downtime}\nAntwortzeit: {response_time}\nLetzte Prüfung: {last_check}",
+		"alert.sla.subject":                 "[CRONZEE] SLA: {name}",
+		"alert.sla_burn.subject":            "[CRONZEE] Fehlerbudget-Verbrauch: {name}",
+		"alert.sla.body":                    "📉 SLA: Endpunkt '{name}'\n\nURL: {url}\nSLA-Ziel: {target}%\n{detail}",
+		"alert.service_failure.subject":     "[CRONZEE] Dienst: {name} ist AUSGEFALLEN",
+		"alert.service_failure.body":        "🔴 DIENST: '{name}' ist AUSGEFALLEN\n\nRichtlinie: {policy}\nFehlerfreie Endpunkte: {healthy}/{total}\nFehlerhafte Endpunkte: {unhealthy}\nVerfügbarkeitswert: {score}%",
+		"alert.service_recovery.subject":    "[CRONZEE] Dienst: {name} ist ERREICHBAR",
+		"alert.service_recovery.body":       "✅ DIENST: '{name}' ist ERREICHBAR\n\nRichtlinie: {policy}\nFehlerfreie Endpunkte: {healthy}/{total}\nFehlerhafte Endpunkte: {unhealthy}\nVerfügbarkeitswert: {score}%",
+		"alert.self_failure.subject":        "[CRONZEE] SiteWatch: {check} gestört",
+		"alert.self_failure.body":           "🔴 SITEWATCH IST GESTÖRT: {check}\n\n{detail}",
+		"alert.self_recovery.subject":       "[CRONZEE] SiteWatch: {check} wiederhergestellt",
+		"alert.self_recovery.body":          "✅ SITEWATCH WIEDERHERGESTELLT: {check}\n\n{detail}",
+		"alert.ssl_san.subject":             "[CRONZEE] Zertifikat deckt Hostnamen nicht ab: {name}",
+		"alert.ssl_san.body":                "🔐 ZERTIFIKAT PASST NICHT ZUM HOSTNAMEN: {name}\n\nURL: {url}\nNicht vom Zertifikat abgedeckt: {hosts}\nBrowser lehnen das Zertifikat für diese Hostnamen ab.",
+		"alert.ssl_san_recovery.subject":    "[CRONZEE] Zertifikat-Hostnamen korrigiert: {name}",
+		"alert.ssl_san_recovery.body":       "✅ ZERTIFIKAT DECKT HOSTNAMEN AB: {name}\n\nURL: {url}\nJetzt abgedeckt: {hosts}",
+		"alert.tls_legacy.subject":          "[CRONZEE] Veraltetes TLS akzeptiert: {name}",
+		"alert.tls_legacy.body":             "🔓 VERALTETES TLS AKZEPTIERT: {name}\n\nURL: {url}\nDer Server akzeptiert noch {version}, das veraltet ist und die meisten Compliance-Vorgaben verletzt.",
+		"alert.tls_legacy_recovery.subject": "[CRONZEE] Veraltetes TLS deaktiviert: {name}",
+		"alert.tls_legacy_recovery.body":    "✅ VERALTETES TLS DEAKTIVIERT: {name}\n\nURL: {url}\nDer Server akzeptiert {version} nicht mehr.",
+		"alert.tls_downgrade.subject":       "[CRONZEE] TLS-Downgrade: {name}",
+		"alert.tls_downgrade.body":          "⬇️ TLS-DOWNGRADE: {name}\n\nURL: {url}\nAusgehandelt: {to} ({cipher}), zuvor {from}.",
+		"self.database":                     "Datenbank-Schreibvorgänge",
+		"self.database.detail":              "{count} Datenbank-Schreibvorgänge sind in den letzten {window} fehlgeschlagen",
+		"self.alert_delivery":               "Alarmzustellung",
+		"self.alert_delivery.detail":        "{failed} von {total} Alarmzustellungen sind in den letzten {window} fehlgeschlagen ({rate}%)",
+		"self.scheduler":                    "Zeitplaner",
+		"self.scheduler.detail":             "{count} Prüfungen sind überfällig",
+		"alert.description":                 "Beschreibung: {description}",
+		"alert.owner":                       "Verantwortlich: {owner}",
+		"alert.runbook":                     "Runbook: {runbook}",
+		"alert.labels":                      "Labels: {labels}",
+		"field.labels":                      "Labels",
+		"field.description":                 "Beschreibung",
+		"field.owner":                       "Verantwortlich",
+		"field.runbook":                     "Runbook",
+		"teams.runbook":                     "Runbook öffnen",
+		"field.endpoint":                    "Endpunkt",
+		"field.url":                         "URL",
+		"field.status":                      "Status",
+		"field.severity":                    "Schweregrad",
+		"field.response_time":               "Antwortzeit",
+		"field.error":                       "Fehler",
+		"field.site_name":                   "Seitenname",
+		"field.last_success":                "Letzter Erfolg",
+		"field.last_success_time":           "Letzter Erfolg",
+		"field.down_for":                    "Ausgefallen seit",
+		"field.down_duration":               "Ausfalldauer",
+		"field.failures":                    "Fehler",
+		"field.failure_count":               "Fehleranzahl",
+		"field.expiry_date":                 "Ablaufdatum",
+		"field.days_left":                   "Tage übrig",
+		"footer":                            "Cronzee Zustandsüberwachung",
+		"more_info":                         "🔗 Weitere Informationen: {url}",
+		"teams.title":                       "📢 ÜBERWACHUNGSALARM ({minutes} Min.)",
+		"teams.down":                        "🔴 AUSGEFALLEN",
+		"teams.acknowledge":                 "Bestätigen",
+		"teams.suppress":                    "Alarme unterdrücken",
+		"teams.history":                     "Verlauf anzeigen",
+		"ssl.title":                         "SSL-ABLAUFBENACHRICHTIGUNGEN",
+		"ssl.subject":                       "[CRONZEE] SSL-Ablaufübersicht: {count} Zertifikate",
+		"email.batch.subject":               "[CRONZEE] {count} Alarme: {names}",
+		"email.batch.title":                 "{count} ALARME VON {from} BIS {to}",
+		"email.batch.details":               "DETAILS",
+		"field.alert":                       "Alarm",
+		"ssl.line":                          "{emoji} *{name}* ({url}) läuft am {date} ab, noch {days} Tage",
+		"ssl.warning":                       "⚠️ Warnung",
+		"ssl.critical":                      "🚨 Kritisch",
+		"ssl.expired_title":                 "ABGELAUFENE ZERTIFIKATE ({count})",
+		"ssl.expired_line":                  "🛑 *{name}* ({url}) ist am {date} abgelaufen, vor {days} Tagen",
+		"ssl.failed_title":                  "NICHT PRÜFBARE ZERTIFIKATE ({count})",
+		"ssl.failed_line":                   "❓ *{name}* ({url}): {error}",
+		"field.days_ago":                    "Vor Tagen",
+		"digest.subject":                    "[CRONZEE] Zusammenfassung {name}: {down} ausgefallen, {incidents} Vorfälle",
+		"digest.title":                      "ZUSAMMENFASSUNG DER ÜBERWACHUNG ({name})",
+		"digest.period":                     "Zeitraum: {from} bis {to}",
+		"digest.endpoints":                  "Endpunkte: {total} überwacht, {down} ausgefallen",
+		"digest.down_now":                   "AKTUELL AUSGEFALLEN ({count})",
+		"digest.down_for":                   "ausgefallen seit",
+		"digest.incidents":                  "VORFÄLLE ({count})",
+		"digest.more":                       "... und {count} weitere",
+		"digest.regressions":                "LATENZ-VERSCHLECHTERUNGEN (p95 gegenüber Vorperiode)",
+		"digest.certificates":               "BALD ABLAUFENDE ZERTIFIKATE ({count})",
+		"digest.days":                       "({days} Tage)",
+		"digest.dashboard":                  "Dashboard: {url}",
+		"none":                              "Keine",
+	},
+	"es": {
+		"alert.failure.subject":             "[CRONZEE] Alerta: {name} está CAÍDO",
+		"alert.failure.body":                "🔴 ALERTA: El endpoint '{name}' NO ESTÁ OPERATIVO\n\nURL: {url}\nEstado: {status}\nFallos consecutivos: {failures}\nÚltimo error: {error}\nÚltima comprobación: {last_check}\nTiempo de respuesta: {response_time}",
+		"alert.recovery.subject":            "[CRONZEE] Recuperación: {name} está ACTIVO",
+		"alert.recovery.body":               "✅ RECUPERACIÓN: El endpoint '{name}' está OPERATIVO\n\nURL: {url}\nEstado: {status}\nTiempo caído: {downtime}\nTiempo de respuesta: {response_time}\nÚltima comprobación: {last_check}",
+		"alert.sla.subject":                 "[CRONZEE] SLA: {name}",
+		"alert.sla_burn.subject":            "[CRONZEE] Consumo del presupuesto de errores: {name}",
+		"alert.sla.body":                    "📉 SLA: Endpoint '{name}'\n\nURL: {url}\nObjetivo SLA: {target}%\n{detail}",
+		"alert.service_failure.subject":     "[CRONZEE] Servicio: {name} está CAÍDO",
+		"alert.service_failure.body":        "🔴 SERVICIO: '{name}' está CAÍDO\n\nPolítica: {policy}\nEndpoints operativos: {healthy}/{total}\nEndpoints con fallos: {unhealthy}\nPuntuación de salud: {score}%",
+		"alert.service_recovery.subject":    "[CRONZEE] Servicio: {name} está ACTIVO",
+		"alert.service_recovery.body":       "✅ SERVICIO: '{name}' está ACTIVO\n\nPolítica: {policy}\nEndpoints operativos: {healthy}/{total}\nEndpoints con fallos: {unhealthy}\nPuntuación de salud: {score}%",
+		"alert.self_failure.subject":        "[CRONZEE] SiteWatch: {check} está fallando",
+		"alert.self_failure.body":           "🔴 SITEWATCH NO ESTÁ SANO: {check}\n\n{detail}",
+		"alert.self_recovery.subject":       "[CRONZEE] SiteWatch: {check} recuperado",
+		"alert.self_recovery.body":          "✅ SITEWATCH RECUPERADO: {check}\n\n{detail}",
+		"alert.ssl_san.subject":             "[CRONZEE] El certificado no cubre: {name}",
+		"alert.ssl_san.body":                "🔐 EL CERTIFICADO NO COINCIDE CON EL HOST: {name}\n\nURL: {url}\nNo cubiertos por el certificado: {hosts}\nLos navegadores rechazarán el certificado para estos hosts.",
+		"alert.ssl_san_recovery.subject":    "[CRONZEE] Hosts del certificado corregidos: {name}",
+		"alert.ssl_san_recovery.body":       "✅ EL CERTIFICADO CUBRE LOS HOSTS: {name}\n\nURL: {url}\nAhora cubiertos: {hosts}",
+		"alert.tls_legacy.subject":          "[CRONZEE] TLS obsoleto aceptado: {name}",
+		"alert.tls_legacy.body":             "🔓 TLS OBSOLETO ACEPTADO: {name}\n\nURL: {url}\nEl servidor todavía acepta {version}, que está obsoleto y no cumple la mayoría de las normas de cumplimiento.",
+		"alert.tls_legacy_recovery.subject": "[CRONZEE] TLS obsoleto desactivado: {name}",
+		"alert.tls_legacy_recovery.body":    "✅ TLS OBSOLETO DESACTIVADO: {name}\n\nURL: {url}\nEl servidor ya no acepta {version}.",
+		"alert.tls_downgrade.subject":       "[CRONZEE] Degradación de TLS: {name}",
+		"alert.tls_downgrade.body":          "⬇️ DEGRADACIÓN DE TLS: {name}\n\nURL: {url}\nNegociado {to} ({cipher}), antes {from}.",
+		"self.database":                     "Escrituras en la base de datos",
+		"self.database.detail":              "{count} escrituras en la base de datos fallaron en los últimos {window}",
+		"self.alert_delivery":               "Entrega de alertas",
+		"self.alert_delivery.detail":        "{failed} de {total} entregas de alertas fallaron ({rate}%) en los últimos {window}",
+		"self.scheduler":                    "Planificador",
+		"self.scheduler.detail":             "{count} comprobaciones están atrasadas",
+		"alert.description":                 "Descripción: {description}",
+		"alert.owner":                       "Responsable: {owner}",
+		"alert.runbook":                     "Runbook: {runbook}",
+		"alert.labels":                      "Etiquetas: {labels}",
+		"field.labels":                      "Etiquetas",
+		"field.description":                 "Descripción",
+		"field.owner":                       "Responsable",
+		"field.runbook":                     "Runbook",
+		"teams.runbook":                     "Abrir runbook",
+		"field.endpoint":                    "Endpoint",
+		"field.url":                         "URL",
+		"field.status":                      "Estado",
+		"field.severity":                    "Severidad",
+		"field.response_time":               "Tiempo de respuesta",
+		"field.error":                       "Error",
+		"field.site_name":                   "Sitio",
+		"field.last_success":                "Último éxito",
+		"field.last_success_time":           "Hora del último éxito",
+		"field.down_for":                    "Caído durante",
+		"field.down_duration":               "Duración de la caída",
+		"field.failures":                    "Fallos",
+		"field.failure_count":               "Número de fallos",
+		"field.expiry_date":                 "Fecha de caducidad",
+		"field.days_left":                   "Días restantes",
+		"footer":                            "Monitor de salud Cronzee",
+		"more_info":                         "🔗 Más información en: {url}",
+		"teams.title":                       "📢 ALERTA DEL MONITOR DE SALUD ({minutes} min)",
+		"teams.down":                        "🔴 CAÍDO",
+		"teams.acknowledge":                 "Reconocer",
+		"teams.suppress":                    "Silenciar alertas",
+		"teams.history":                     "Ver historial",
+		"ssl.title":                         "AVISOS DE CADUCIDAD SSL",
+		"ssl.subject":                       "[CRONZEE] Resumen de caducidad SSL: {count} certificados",
+		"email.batch.subject":               "[CRONZEE] {count} alertas: {names}",
+		"email.batch.title":                 "{count} ALERTAS DE {from} A {to}",
+		"email.batch.details":               "DETALLES",
+		"field.alert":                       "Alerta",
+		"ssl.line":                          "{emoji} *{name}* ({url}) caduca el {date}, quedan {days} días",
+		"ssl.warning":                       "⚠️ Aviso",
+		"ssl.critical":                      "🚨 Crítico",
+		"ssl.expired_title":                 "CERTIFICADOS CADUCADOS ({count})",
+		"ssl.expired_line":                  "🛑 *{name}* ({url}) caducó el {date}, hace {days} días",
+		"ssl.failed_title":                  "CERTIFICADOS QUE NO SE PUDIERON COMPROBAR ({count})",
+		"ssl.failed_line":                   "❓ *{name}* ({url}): {error}",
+		"field.days_ago":                    "Hace Días",
+		"digest.subject":                    "[CRONZEE] Resumen {name}: {down} caídos, {incidents} incidentes",
+		"digest.title":                      "RESUMEN DE SALUD DEL MONITOR ({name})",
+		"digest.period":                     "Periodo: {from} a {to}",
+		"digest.endpoints":                  "Endpoints: {total} monitorizados, {down} caídos",
+		"digest.down_now":                   "CAÍDOS AHORA ({count})",
+		"digest.down_for":                   "caído durante",
+		"digest.incidents":                  "INCIDENTES ({count})",
+		"digest.more":                       "... y {count} más",
+		"digest.regressions":                "REGRESIONES DE LATENCIA (p95 frente al periodo anterior)",
+		"digest.certificates":               "PRÓXIMAS CADUCIDADES DE CERTIFICADOS ({count})",
+		"digest.days":                       "({days} días)",
+		"digest.dashboard":                  "Panel: {url}",
+		"none":                              "Ninguno",
+	},
+	"fr": {
+		"alert.failure.subject":             "[CRONZEE] Alerte : {name} est HORS SERVICE",
+		"alert.failure.body":                "🔴 ALERTE : le point de terminaison '{name}' est DÉFAILLANT\n\nURL : {url}\nÉtat : {status}\nÉchecs consécutifs : {failures}\nDernière erreur : {error}\nDernière vérification : {last_check}\nTemps de réponse : {response_time}",
+		"alert.recovery.subject":            "[CRONZEE] Rétablissement : {name} est EN SERVICE",
+		"alert.recovery.body":               "✅ RÉTABLISSEMENT : le point de terminaison '{name}' est OPÉRATIONNEL\n\nURL : {url}\nÉtat : {status}\nDurée d'indisponibilité : {downtime}\nTemps de réponse : {response_time}\nDernière vérification : {last_check}",
+		"alert.sla.subject":                 "[CRONZEE] SLA : {name}",
+		"alert.sla_burn.subject":            "[CRONZEE] Consommation du budget d'erreur : {name}",
+		"alert.sla.body":                    "📉 SLA : point de terminaison '{name}'\n\nURL : {url}\nObjectif SLA : {target} %\n{detail}",
+		"alert.service_failure.subject":     "[CRONZEE] Service : {name} est HORS SERVICE",
+		"alert.service_failure.body":        "🔴 SERVICE : '{name}' est HORS SERVICE\n\nPolitique : {policy}\nPoints de terminaison opérationnels : {healthy}/{total}\nPoints de terminaison défaillants : {unhealthy}\nScore de santé : {score} %",
+		"alert.service_recovery.subject":    "[CRONZEE] Service : {name} est EN SERVICE",
+		"alert.service_recovery.body":       "✅ SERVICE : '{name}' est EN SERVICE\n\nPolitique : {policy}\nPoints de terminaison opérationnels : {healthy}/{total}\nPoints de terminaison défaillants : {unhealthy}\nScore de santé : {score} %",
+		"alert.self_failure.subject":        "[CRONZEE] SiteWatch : {check} en échec",
+		"alert.self_failure.body":           "🔴 SITEWATCH EN DÉFAUT : {check}\n\n{detail}",
+		"alert.self_recovery.subject":       "[CRONZEE] SiteWatch : {check} rétabli",
+		"alert.self_recovery.body":          "✅ SITEWATCH RÉTABLI : {check}\n\n{detail}",
+		"alert.ssl_san.subject":             "[CRONZEE] Le certificat ne couvre pas : {name}",
+		"alert.ssl_san.body":                "🔐 CERTIFICAT NE CORRESPONDANT PAS À L'HÔTE : {name}\n\nURL : {url}\nNon couverts par le certificat : {hosts}\nLes navigateurs rejetteront le certificat pour ces noms d'hôte.",
+		"alert.ssl_san_recovery.subject":    "[CRONZEE] Noms d'hôte du certificat corrigés : {name}",
+		"alert.ssl_san_recovery.body":       "✅ LE CERTIFICAT COUVRE LES NOMS D'HÔTE : {name}\n\nURL : {url}\nDésormais couverts : {hosts}",
+		"alert.tls_legacy.subject":          "[CRONZEE] TLS obsolète accepté : {name}",
+		"alert.tls_legacy.body":             "🔓 TLS OBSOLÈTE ACCEPTÉ : {name}\n\nURL : {url}\nLe serveur accepte encore {version}, obsolète et refusé par la plupart des référentiels de conformité.",
+		"alert.tls_legacy_recovery.subject": "[CRONZEE] TLS obsolète désactivé : {name}",
+		"alert.tls_legacy_recovery.body":    "✅ TLS OBSOLÈTE DÉSACTIVÉ : {name}\n\nURL : {url}\nLe serveur n'accepte plus {version}.",
+		"alert.tls_downgrade.subject":       "[CRONZEE] Rétrogradation TLS : {name}",
+		"alert.tls_downgrade.body":          "⬇️ RÉTROGRADATION TLS : {name}\n\nURL : {url}\nNégocié {to} ({cipher}), auparavant {from}.",
+		"self.database":                     "Écritures en base de données",
+		"self.database.detail":              "{count} écritures en base de données ont échoué au cours des dernières {window}",
+		"self.alert_delivery":               "Envoi des alertes",
+		"self.alert_delivery.detail":        "{failed} envois d'alertes sur {total} ont échoué ({rate} %) au cours des dernières {window}",
+		"self.scheduler":                    "Planificateur",
+		"self.scheduler.detail":             "{count} vérifications sont en retard",
+		"alert.description":                 "Description : {description}",
+		"alert.owner":                       "Responsable : {owner}",
+		"alert.runbook":                     "Procédure : {runbook}",
+		"alert.labels":                      "Étiquettes : {labels}",
+		"field.labels":                      "Étiquettes",
+		"field.description":                 "Description",
+		"field.owner":                       "Responsable",
+		"field.runbook":                     "Procédure",
+		"teams.runbook":                     "Ouvrir la procédure",
+		"field.endpoint":                    "Point de terminaison",
+		"field.url":                         "URL",
+		"field.status":                      "État",
+		"field.severity":                    "Gravité",
+		"field.response_time":               "Temps de réponse",
+		"field.error":                       "Erreur",
+		"field.site_name":                   "Site",
+		"field.last_success":                "Dernier succès",
+		"field.last_success_time":           "Heure du dernier succès",
+		"field.down_for":                    "Hors service depuis",
+		"field.down_duration":               "Durée d'indisponibilité",
+		"field.failures":                    "Échecs",
+		"field.failure_count":               "Nombre d'échecs",
+		"field.expiry_date":                 "Date d'expiration",
+		"field.days_left":                   "Jours restants",
+		"footer":                            "Moniteur de santé Cronzee",
+		"more_info":                         "🔗 Plus d'informations : {url}",
+		"teams.title":                       "📢 ALERTE DU MONITEUR DE SANTÉ ({minutes} min)",
+		"teams.down":                        "🔴 HORS SERVICE",
+		"teams.acknowledge":                 "Acquitter",
+		"teams.suppress":                    "Suspendre les alertes",
+		"teams.history":                     "Voir l'historique",
+		"ssl.title":                         "NOTIFICATIONS D'EXPIRATION SSL",
+		"ssl.subject":                       "[CRONZEE] Résumé des expirations SSL : {count} certificats",
+		"email.batch.subject":               "[CRONZEE] {count} alertes : {names}",
+		"email.batch.title":                 "{count} ALERTES DE {from} À {to}",
+		"email.batch.details":               "DÉTAILS",
+		"field.alert":                       "Alerte",
+		"ssl.line":                          "{emoji} *{name}* ({url}) expire le {date}, encore {days} jours",
+		"ssl.warning":                       "⚠️ Avertissement",
+		"ssl.critical":                      "🚨 Critique",
+		"ssl.expired_title":                 "CERTIFICATS EXPIRÉS ({count})",
+		"ssl.expired_line":                  "🛑 *{name}* ({url}) a expiré le {date}, il y a {days} jours",
+		"ssl.failed_title":                  "CERTIFICATS IMPOSSIBLES À VÉRIFIER ({count})",
+		"ssl.failed_line":                   "❓ *{name}* ({url}) : {error}",
+		"field.days_ago":                    "Il y a (jours)",
+		"digest.subject":                    "[CRONZEE] Synthèse {name} : {down} hors service, {incidents} incidents",
+		"digest.title":                      "SYNTHÈSE DE SANTÉ DU MONITEUR ({name})",
+		"digest.period":                     "Période : du {from} au {to}",
+		"digest.endpoints":                  "Points de terminaison : {total} surveillés, {down} hors service",
+		"digest.down_now":                   "HORS SERVICE ACTUELLEMENT ({count})",
+		"digest.down_for":                   "hors service depuis",
+		"digest.incidents":                  "INCIDENTS ({count})",
+		"digest.more":                       "... et {count} de plus",
+		"digest.regressions":                "RÉGRESSIONS DE LATENCE (p95 par rapport à la période précédente)",
+		"digest.certificates":               "EXPIRATIONS DE CERTIFICATS À VENIR ({count})",
+		"digest.days":                       "({days} jours)",
+		"digest.dashboard":                  "Tableau de bord : {url}",
+		"none":                              "Aucun",
+	},
+}
+
+var catalogMu sync.RWMutex
+
+// LoadMessageCatalog reads a JSON object of message templates and merges it into a language
+func LoadMessageCatalog(lang, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read message catalog %s: %w", path, err)
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("failed to parse message catalog %s: %w", path, err)
+	}
+
+	for key := range messages {
+		if _, ok := messageCatalogs[DefaultLanguage][key]; !ok {
+			return fmt.Errorf("message catalog %s has unknown key %q", path, key)
+		}
+	}
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	catalog := messageCatalogs[lang]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		messageCatalogs[lang] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+	return nil
+}
+
+// HasLanguage reports whether a message catalog exists for lang
+func HasLanguage(lang string) bool {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	_, ok := messageCatalogs[lang]
+	return ok
+}
+
+// ValidateLanguage returns an error if lang is set but has no message catalog
+func ValidateLanguage(lang string) error {
+	if lang == "" || HasLanguage(lang) {
+		return nil
+	}
+	return fmt.Errorf("unknown language %q: must be one of %s or loaded from message_catalogs", lang, strings.Join(Languages(), ", "))
+}
+
+// Languages returns the languages with a message catalog
+func Languages() []string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	langs := make([]string, 0, len(messageCatalogs))
+	for lang := range messageCatalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Translate renders a message template in lang, substituting name/value pairs for {name}
+// placeholders. Keys missing from the catalog fall back to English.
+func Translate(lang, key string, pairs ...string) string {
+	catalogMu.RLock()
+	message, ok := messageCatalogs[lang][key]
+	if !ok {
+		message, ok = messageCatalogs[DefaultLanguage][key]
+	}
+	catalogMu.RUnlock()
+	if !ok {
+		return key
+	}
+
+	if len(pairs) == 0 {
+		return message
+	}
+	oldnew := make([]string, 0, len(pairs))
+	for i := 0; i+1 < len(pairs); i += 2 {
+		oldnew = append(oldnew, "{"+pairs[i]+"}", pairs[i+1])
+	}
+	return strings.NewReplacer(oldnew...).Replace(message)
+}
@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICSEvent is a single (possibly expanded recurring) event from an iCalendar file
+type ICSEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// icsMaxOccurrences caps how many instances one recurring event expands to
+const icsMaxOccurrences = 1000
+
+// icsProperty is one unfolded content line, e.g. DTSTART;TZID=Europe/Berlin:20240101T090000
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICS reads the VEVENTs of an iCalendar file. Floating and all-day times are read in loc.
+// Simple recurrences (FREQ with INTERVAL, COUNT and UNTIL) are expanded up to horizon;
+// recurrences using BY* rules only yield their first occurrence.
+func ParseICS(data string, loc *time.Location, horizon time.Time) ([]ICSEvent, error) {
+	if !strings.Contains(strings.ToUpper(data), "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar file: missing BEGIN:VCALENDAR")
+	}
+
+	lines := unfoldICS(data)
+
+	var events []ICSEvent
+	var current map[string]icsProperty
+	for _, line := range lines {
+		prop, ok := parseICSLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current = make(map[string]icsProperty)
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if current == nil {
+				continue
+			}
+			expanded, err := icsEvent(current, loc, horizon)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, expanded...)
+			current = nil
+		case current != nil:
+			// Keep the first occurrence of each property, nested VALARMs come later
+			if _, seen := current[prop.name]; !seen {
+				current[prop.name] = prop
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// unfoldICS joins folded content lines (continuations start with a space or tab)
+func unfoldICS(data string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICSLine splits a content line into name, parameters and value
+func parseICSLine(line string) (icsProperty, bool) {
+	colon := strings.Index(line, ":")
+	if colon <= 0 {
+		return icsProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, true
+}
+
+// icsEvent converts a VEVENT's properties into one event per occurrence
+func icsEvent(props map[string]icsProperty, loc *time.Location, horizon time.Time) ([]ICSEvent, error) {
+	startProp, ok := props["DTSTART"]
+	if !ok {
+		return nil, nil
+	}
+	start, allDay, err := parseICSTime(startProp, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART %q: %w", startProp.value, err)
+	}
+
+	// Without DTEND an all-day event lasts the day and a timed event is instantaneous
+	end := start
+	if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	if endProp, ok := props["DTEND"]; ok {
+		if end, _, err = parseICSTime(endProp, loc); err != nil {
+			return nil, fmt.Errorf("invalid DTEND %q: %w", endProp.value, err)
+		}
+	} else if durationProp, ok := props["DURATION"]; ok {
+		duration, err := parseICSDuration(durationProp.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DURATION %q: %w", durationProp.value, err)
+		}
+		end = start.Add(duration)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("event %q ends before it starts", props["SUMMARY"].value)
+	}
+
+	event := ICSEvent{
+		UID:     props["UID"].value,
+		Summary: icsUnescape(props["SUMMARY"].value),
+		Start:   start,
+		End:     end,
+	}
+
+	rule, ok := props["RRULE"]
+	if !ok {
+		return []ICSEvent{event}, nil
+	}
+	return expandICSRule(event, rule.value, loc, horizon)
+}
+
+// parseICSTime parses a DATE or DATE-TIME value, honouring TZID and the UTC "Z" suffix
+func parseICSTime(prop icsProperty, loc *time.Location) (time.Time, bool, error) {
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+
+	value := prop.value
+	switch {
+	case prop.params["VALUE"] == "DATE" || len(value) == 8:
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	default:
+		t, err := time.ParseInLocation("20060102T150405", value, loc)
+		return t, false, err
+	}
+}
+
+// parseICSDuration parses durations such as P1D, PT4H or P1DT12H30M
+func parseICSDuration(value string) (time.Duration, error) {
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimLeft(value, "+-")
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("must start with P")
+	}
+
+	var total time.Duration
+	var number string
+	inTime := false
+	for _, r := range value[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("missing number before %q", r)
+		}
+		number = ""
+
+		switch {
+		case r == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("unexpected %q", r)
+		}
+	}
+
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// expandICSRule expands a simple RRULE into occurrences starting before horizon
+func expandICSRule(event ICSEvent, rule string, loc *time.Location, horizon time.Time) ([]ICSEvent, error) {
+	parts := make(map[string]string)
+	for _, part := range strings.Split(rule, ";") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			parts[strings.ToUpper(key)] = value
+		}
+	}
+
+	// BY* rules pick specific days within the period, which plain stepping would get wrong
+	for key := range parts {
+		if strings.HasPrefix(key, "BY") {
+			return []ICSEvent{event}, nil
+		}
+	}
+
+	interval := 1
+	if value, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid RRULE INTERVAL %q", value)
+		}
+		interval = n
+	}
+
+	count := icsMaxOccurrences
+	if value, ok := parts["COUNT"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid RRULE COUNT %q", value)
+		}
+		count = min(n, icsMaxOccurrences)
+	}
+
+	until := horizon
+	if value, ok := parts["UNTIL"]; ok {
+		t, _, err := parseICSTime(icsProperty{value: value, params: map[string]string{}}, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE UNTIL %q", value)
+		}
+		if t.Before(until) {
+			until = t
+		}
+	}
+
+	// Monthly and yearly rules skip periods without the start's day, such as the 31st
+	// in a 30-day month or February 29 in other years
+	var step func(t time.Time, n int) time.Time
+	sameDay := false
+	switch strings.ToUpper(parts["FREQ"]) {
+	case "DAILY":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n*interval) }
+	case "WEEKLY":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n*interval) }
+	case "MONTHLY":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(0, n*interval, 0) }
+		sameDay = true
+	case "YEARLY":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(n*interval, 0, 0) }
+		sameDay = true
+	default:
+		return []ICSEvent{event}, nil
+	}
+
+	duration := event.End.Sub(event.Start)
+	var events []ICSEvent
+	for n := 0; len(events) < count; n++ {
+		start := step(event.Start, n)
+		if start.After(until) {
+			break
+		}
+		if sameDay && start.Day() != event.Start.Day() {
+			continue
+		}
+		occurrence := event
+		occurrence.Start = start
+		occurrence.End = start.Add(duration)
+		events = append(events, occurrence)
+	}
+	return events, nil
+}
+
+// icsUnescape reverses iCalendar text escaping
+func icsUnescape(s string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// icsCalendar wraps content lines in a calendar with CRLF line endings
+func icsCalendar(lines ...string) string {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	return strings.Join(append(all, "END:VCALENDAR"), "\r\n") + "\r\n"
+}
+
+func TestParseICS(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	utc := func(s string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	horizon := utc("2029-01-01 00:00")
+
+	type span struct{ start, end time.Time }
+	tests := []struct {
+		name   string
+		lines  []string
+		loc    *time.Location
+		want   []span
+		verify func(t *testing.T, events []ICSEvent)
+	}{
+		{
+			name:  "utc times",
+			lines: []string{"BEGIN:VEVENT", "UID:a", "DTSTART:20261020T220000Z", "DTEND:20261021T020000Z", "END:VEVENT"},
+			want:  []span{{utc("2026-10-20 22:00"), utc("2026-10-21 02:00")}},
+		},
+		{
+			name:  "tzid",
+			lines: []string{"BEGIN:VEVENT", "DTSTART;TZID=Europe/Berlin:20261020T220000", "DTEND;TZID=Europe/Berlin:20261021T020000", "END:VEVENT"},
+			want:  []span{{utc("2026-10-20 20:00"), utc("2026-10-21 00:00")}},
+		},
+		{
+			name:  "quoted tzid",
+			lines: []string{"BEGIN:VEVENT", `DTSTART;TZID="America/New_York":20261020T220000`, "DURATION:PT1H", "END:VEVENT"},
+			want:  []span{{utc("2026-10-21 02:00"), utc("2026-10-21 03:00")}},
+		},
+		{
+			name:  "floating time in the default location",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20261020T220000", "DURATION:PT30M", "END:VEVENT"},
+			loc:   newYork,
+			want:  []span{{utc("2026-10-21 02:00"), utc("2026-10-21 02:30")}},
+		},
+		{
+			name:  "unknown tzid falls back to the default location",
+			lines: []string{"BEGIN:VEVENT", "DTSTART;TZID=W. Europe Standard Time:20261020T220000", "DURATION:PT1H", "END:VEVENT"},
+			loc:   berlin,
+			want:  []span{{utc("2026-10-20 20:00"), utc("2026-10-20 21:00")}},
+		},
+		{
+			name:  "all day without end lasts the day",
+			lines: []string{"BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261224", "END:VEVENT"},
+			loc:   berlin,
+			want:  []span{{utc("2026-12-23 23:00"), utc("2026-12-24 23:00")}},
+		},
+		{
+			name:  "duration with days and time",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20261020T000000Z", "DURATION:P1DT12H30M", "END:VEVENT"},
+			want:  []span{{utc("2026-10-20 00:00"), utc("2026-10-21 12:30")}},
+		},
+		{
+			name: "weekly tzid recurrence keeps the wall clock across DST",
+			lines: []string{"BEGIN:VEVENT", "DTSTART;TZID=Europe/Berlin:20260318T090000", "DTEND;TZID=Europe/Berlin:20260318T100000",
+				"RRULE:FREQ=WEEKLY;COUNT=3", "END:VEVENT"},
+			want: []span{
+				{utc("2026-03-18 08:00"), utc("2026-03-18 09:00")},
+				{utc("2026-03-25 08:00"), utc("2026-03-25 09:00")},
+				{utc("2026-04-01 07:00"), utc("2026-04-01 08:00")},
+			},
+		},
+		{
+			name:  "daily interval until",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20261020T010000Z", "DURATION:PT1H", "RRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20261024T010000Z", "END:VEVENT"},
+			want: []span{
+				{utc("2026-10-20 01:00"), utc("2026-10-20 02:00")},
+				{utc("2026-10-22 01:00"), utc("2026-10-22 02:00")},
+				{utc("2026-10-24 01:00"), utc("2026-10-24 02:00")},
+			},
+		},
+		{
+			name:  "until as a date",
+			lines: []string{"BEGIN:VEVENT", "DTSTART;VALUE=DATE:20261020", "RRULE:FREQ=DAILY;UNTIL=20261021", "END:VEVENT"},
+			want: []span{
+				{utc("2026-10-20 00:00"), utc("2026-10-21 00:00")},
+				{utc("2026-10-21 00:00"), utc("2026-10-22 00:00")},
+			},
+		},
+		{
+			name:  "monthly skips months without the day",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20260131T020000Z", "DURATION:PT1H", "RRULE:FREQ=MONTHLY;COUNT=3", "END:VEVENT"},
+			want: []span{
+				{utc("2026-01-31 02:00"), utc("2026-01-31 03:00")},
+				{utc("2026-03-31 02:00"), utc("2026-03-31 03:00")},
+				{utc("2026-05-31 02:00"), utc("2026-05-31 03:00")},
+			},
+		},
+		{
+			name:  "yearly on a leap day",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20240229T020000Z", "DURATION:PT1H", "RRULE:FREQ=YEARLY;COUNT=2", "END:VEVENT"},
+			want: []span{
+				{utc("2024-02-29 02:00"), utc("2024-02-29 03:00")},
+				{utc("2028-02-29 02:00"), utc("2028-02-29 03:00")},
+			},
+		},
+		{
+			name:  "by rules yield the first occurrence",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20261020T020000Z", "DURATION:PT1H", "RRULE:FREQ=WEEKLY;BYDAY=TU,TH", "END:VEVENT"},
+			want:  []span{{utc("2026-10-20 02:00"), utc("2026-10-20 03:00")}},
+		},
+		{
+			name:  "recurrence stops at the horizon",
+			lines: []string{"BEGIN:VEVENT", "DTSTART:20280101T120000Z", "DURATION:PT1H", "RRULE:FREQ=MONTHLY", "END:VEVENT"},
+			verify: func(t *testing.T, events []ICSEvent) {
+				if len(events) != 12 {
+					t.Errorf("got %d occurrences before the horizon, want 12", len(events))
+				}
+			},
+		},
+		{
+			name: "folded and escaped summary",
+			lines: []string{"BEGIN:VEVENT", "UID:maint-1", `SUMMARY:Database maintenance\, phase 1`, ` \; see the runbook`, "DTSTART:20261020T020000Z",
+				"BEGIN:VALARM", "SUMMARY:Reminder", "END:VALARM", "END:VEVENT"},
+			verify: func(t *testing.T, events []ICSEvent) {
+				if len(events) != 1 || events[0].UID != "maint-1" || events[0].Summary != "Database maintenance, phase 1; see the runbook" {
+					t.Errorf("events = %+v", events)
+				}
+			},
+		},
+		{
+			name:  "event without a start is skipped",
+			lines: []string{"BEGIN:VEVENT", "SUMMARY:No start", "END:VEVENT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			events, err := ParseICS(icsCalendar(tt.lines...), loc, horizon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.verify != nil {
+				tt.verify(t, events)
+				return
+			}
+			if len(events) != len(tt.want) {
+				t.Fatalf("got %d events, want %d: %+v", len(events), len(tt.want), events)
+			}
+			for i, want := range tt.want {
+				if !events[i].Start.Equal(want.start) || !events[i].End.Equal(want.end) {
+					t.Errorf("event %d = %v to %v, want %v to %v", i, events[i].Start.UTC(), events[i].End.UTC(), want.start, want.end)
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSErrors(t *testing.T) {
+	horizon := time.Now().AddDate(1, 0, 0)
+	tests := map[string]string{
+		"not a calendar":        "BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"bad start":             icsCalendar("BEGIN:VEVENT", "DTSTART:2026-10-20", "END:VEVENT"),
+		"ends before it starts": icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "DTEND:20261020T010000Z", "END:VEVENT"),
+		"bad duration":          icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "DURATION:1H", "END:VEVENT"),
+		"bad duration unit":     icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "DURATION:P1H", "END:VEVENT"),
+		"zero interval":         icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "RRULE:FREQ=DAILY;INTERVAL=0", "END:VEVENT"),
+		"bad count":             icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "RRULE:FREQ=DAILY;COUNT=x", "END:VEVENT"),
+		"bad until":             icsCalendar("BEGIN:VEVENT", "DTSTART:20261020T020000Z", "RRULE:FREQ=DAILY;UNTIL=soon", "END:VEVENT"),
+	}
+	for name, data := range tests {
+		if _, err := ParseICS(data, time.UTC, horizon); err == nil {
+			t.Errorf("%s: ParseICS succeeded, want an error", name)
+		}
+	}
+}
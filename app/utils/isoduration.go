@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatISODuration formats d as an ISO 8601 duration such as PT1H30M or PT0.25S
+func FormatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteString("PT")
+
+	if hours := d / time.Hour; hours > 0 {
+		b.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		b.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
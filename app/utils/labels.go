@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// labelNamePattern matches Prometheus label names so labels can be exported unchanged
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateLabels checks label names are valid Prometheus label names and not reserved
+func ValidateLabels(labels map[string]string) error {
+	for name := range labels {
+		if !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("invalid label name %q: must match [a-zA-Z_][a-zA-Z0-9_]* and not start with __", name)
+		}
+	}
+	return nil
+}
+
+// FormatLabels renders labels as sorted key=value pairs
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, name := range SortedKeys(labels) {
+		pairs = append(pairs, name+"="+labels[name])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// SortedKeys returns the keys of a string map in sorted order
+func SortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
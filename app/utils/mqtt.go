@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttPingReq    = 0xC0
+	mqttDisconnect = 0xE0
+)
+
+// MQTTClient is a minimal MQTT 3.1.1 client that publishes at QoS 0
+type MQTTClient struct {
+	conn   net.Conn
+	mu     sync.Mutex
+	closed chan struct{}
+	err    error
+	once   sync.Once
+}
+
+// DialMQTT connects to a broker given as tcp://, mqtt://, ssl://, tls:// or mqtts:// host:port
+func DialMQTT(broker, clientID, username, password string, keepAlive, timeout time.Duration) (*MQTTClient, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker URL: %w", err)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		conn, err = dialer.Dial("tcp", hostWithPort(u, "1883"))
+	case "ssl", "tls", "mqtts":
+		conn, err = tls.DialWithDialer(dialer, "tcp", hostWithPort(u, "8883"), &tls.Config{ServerName: u.Hostname()})
+	default:
+		return nil, fmt.Errorf("unsupported broker scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Variable header: protocol name, level 4, flags, keep alive
+	flags := byte(0x02) // clean session
+	if username != "" {
+		flags |= 0x80
+	}
+	if password != "" {
+		flags |= 0x40
+	}
+	body := mqttString("MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(keepAlive/time.Second))
+	body = append(body, mqttString(clientID)...)
+	if username != "" {
+		body = append(body, mqttString(username)...)
+	}
+	if password != "" {
+		body = append(body, mqttString(password)...)
+	}
+
+	conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(mqttPacket(mqttConnect, body)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	header, payload, err := readMQTTPacket(reader)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNACK: %w", err)
+	}
+	if header&0xF0 != mqttConnAck || len(payload) != 2 {
+		conn.Close()
+		return nil, errors.New("unexpected response to CONNECT")
+	}
+	if payload[1] != 0 {
+		conn.Close()
+		return nil, fmt.Errorf("broker refused connection (code %d)", payload[1])
+	}
+	conn.SetDeadline(time.Time{})
+
+	client := &MQTTClient{conn: conn, closed: make(chan struct{})}
+	go client.readLoop(reader)
+	return client, nil
+}
+
+// Publish sends a QoS 0 message, optionally retained by the broker
+func (c *MQTTClient) Publish(topic string, payload []byte, retain bool) error {
+	header := byte(mqttPublish)
+	if retain {
+		header |= 0x01
+	}
+	return c.write(mqttPacket(header, append(mqttString(topic), payload...)))
+}
+
+// Ping sends a PINGREQ to keep the connection alive
+func (c *MQTTClient) Ping() error {
+	return c.write([]byte{mqttPingReq, 0})
+}
+
+// Done is closed when the connection drops
+func (c *MQTTClient) Done() <-chan struct{} {
+	return c.closed
+}
+
+// Err returns the error that closed the connection
+func (c *MQTTClient) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close sends DISCONNECT and closes the connection
+func (c *MQTTClient) Close() error {
+	c.write([]byte{mqttDisconnect, 0})
+	c.fail(errors.New("client closed"))
+	return nil
+}
+
+// write sends a packet, failing the connection on error
+func (c *MQTTClient) write(packet []byte) error {
+	select {
+	case <-c.closed:
+		return c.Err()
+	default:
+	}
+
+	c.mu.Lock()
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := c.conn.Write(packet)
+	c.mu.Unlock()
+	if err != nil {
+		c.fail(err)
+	}
+	return err
+}
+
+// readLoop discards broker responses (PINGRESP) until the connection fails
+func (c *MQTTClient) readLoop(reader *bufio.Reader) {
+	for {
+		if _, _, err := readMQTTPacket(reader); err != nil {
+			c.fail(err)
+			return
+		}
+	}
+}
+
+// fail records the first error and closes the connection
+func (c *MQTTClient) fail(err error) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		c.conn.Close()
+		close(c.closed)
+	})
+}
+
+// hostWithPort returns the URL host with a default port when none is given
+func hostWithPort(u *url.URL, port string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// mqttString encodes a length-prefixed UTF-8 string
+func mqttString(s string) []byte {
+	b := binary.BigEndian.AppendUint16(nil, uint16(len(s)))
+	return append(b, s...)
+}
+
+// mqttPacket frames a control packet with its remaining length
+func mqttPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+// readMQTTPacket reads one control packet
+func readMQTTPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7F) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header, payload, nil
+}
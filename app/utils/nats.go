@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSClient is a minimal NATS client that only publishes
+type NATSClient struct {
+	conn   net.Conn
+	mu     sync.Mutex
+	closed chan struct{}
+	err    error
+	once   sync.Once
+}
+
+// natsInfo is the subset of the server INFO message the client needs
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// DialNATS connects to nats://host:port or tls://host:port, authenticating with a user/password or token
+func DialNATS(server, name, user, password, token string, timeout time.Duration) (*NATSClient, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("unsupported NATS scheme %q", u.Scheme)
+	}
+	if u.User != nil && user == "" && token == "" {
+		user = u.User.Username()
+		password, _ = u.User.Password()
+	}
+
+	conn, err := net.DialTimeout("tcp", hostWithPort(u, "4222"), timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read INFO: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, errors.New("unexpected greeting from NATS server")
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimSpace(line[5:])), &info); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("invalid INFO: %w", err)
+	}
+
+	// Servers upgrade to TLS after the plaintext INFO
+	if u.Scheme == "tls" || info.TLSRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     name,
+		"lang":     "go",
+		"version":  "1.0",
+		"protocol": 1,
+	}
+	if token != "" {
+		options["auth_token"] = token
+	} else if user != "" {
+		options["user"] = user
+		options["pass"] = password
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// The server answers PING with PONG once CONNECT is accepted
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response: %w", err)
+	}
+	if strings.HasPrefix(line, "-ERR") {
+		conn.Close()
+		return nil, fmt.Errorf("NATS server rejected connection: %s", strings.TrimSpace(line[4:]))
+	}
+	if !strings.HasPrefix(line, "PONG") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected response to CONNECT: %s", strings.TrimSpace(line))
+	}
+	conn.SetDeadline(time.Time{})
+
+	client := &NATSClient{conn: conn, closed: make(chan struct{})}
+	go client.readLoop(reader)
+	return client, nil
+}
+
+// Publish sends a message to a subject
+func (c *NATSClient) Publish(subject string, payload []byte) error {
+	packet := make([]byte, 0, len(subject)+len(payload)+32)
+	packet = fmt.Appendf(packet, "PUB %s %d\r\n", subject, len(payload))
+	packet = append(packet, payload...)
+	packet = append(packet, '\r', '\n')
+	return c.write(packet)
+}
+
+// Done is closed when the connection drops
+func (c *NATSClient) Done() <-chan struct{} {
+	return c.closed
+}
+
+// Err returns the error that closed the connection
+func (c *NATSClient) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close closes the connection
+func (c *NATSClient) Close() error {
+	c.fail(errors.New("client closed"))
+	return nil
+}
+
+// write sends raw protocol bytes, failing the connection on error
+func (c *NATSClient) write(data []byte) error {
+	select {
+	case <-c.closed:
+		return c.Err()
+	default:
+	}
+
+	c.mu.Lock()
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := c.conn.Write(data)
+	c.mu.Unlock()
+	if err != nil {
+		c.fail(err)
+	}
+	return err
+}
+
+// readLoop answers server PINGs and stops on -ERR or a dropped connection
+func (c *NATSClient) readLoop(reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			c.write([]byte("PONG\r\n"))
+		case strings.HasPrefix(line, "-ERR"):
+			c.fail(fmt.Errorf("NATS server error: %s", strings.TrimSpace(line[4:])))
+			return
+		}
+	}
+}
+
+// fail records the first error and closes the connection
+func (c *NATSClient) fail(err error) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		c.conn.Close()
+		close(c.closed)
+	})
+}
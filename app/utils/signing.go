@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignToken returns a URL-safe token carrying payload that is valid until expires
+func SignToken(key []byte, payload string, expires time.Time) string {
+	body := payload + "|" + strconv.FormatInt(expires.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+
+	return base64.RawURLEncoding.EncodeToString([]byte(body)) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyToken checks a token's signature and expiry and returns its payload
+func VerifyToken(key []byte, token string, now time.Time) (string, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed token")
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("malformed token")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed token")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return "", fmt.Errorf("invalid token signature")
+	}
+
+	idx := strings.LastIndex(string(body), "|")
+	if idx < 0 {
+		return "", fmt.Errorf("malformed token")
+	}
+	expires, err := strconv.ParseInt(string(body[idx+1:]), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("malformed token")
+	}
+	if now.Unix() > expires {
+		return "", fmt.Errorf("token expired")
+	}
+
+	return string(body[:idx]), nil
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSnooze is the longest alerts can be muted for; longer mutes should be permanent suppressions
+const MaxSnooze = 30 * 24 * time.Hour
+
+// ParseSnooze returns when a snooze ends. duration is a Go duration that may start with
+// whole days ("2h", "1d12h"); until is a clock time ("09:00", its next occurrence in loc)
+// or an RFC 3339 timestamp. Exactly one of them must be set.
+func ParseSnooze(duration, until string, now time.Time, loc *time.Location) (time.Time, error) {
+	var end time.Time
+	switch {
+	case duration != "" && until != "":
+		return time.Time{}, fmt.Errorf("set either duration or until, not both")
+	case duration != "":
+		d, err := parseDays(duration)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q: %w", duration, err)
+		}
+		end = now.Add(d)
+	case until != "":
+		if t, err := time.Parse(time.RFC3339, until); err == nil {
+			end = t
+		} else if clock, err := time.Parse("15:04", until); err == nil {
+			local := now.In(loc)
+			end = time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+			if !end.After(now) {
+				end = time.Date(local.Year(), local.Month(), local.Day()+1, clock.Hour(), clock.Minute(), 0, 0, loc)
+			}
+		} else {
+			return time.Time{}, fmt.Errorf("invalid until %q: must be HH:MM or an RFC 3339 time", until)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("duration or until is required")
+	}
+
+	if !end.After(now) {
+		return time.Time{}, fmt.Errorf("snooze must end in the future")
+	}
+	if end.Sub(now) > MaxSnooze {
+		return time.Time{}, fmt.Errorf("snooze must not exceed %d days", int(MaxSnooze.Hours()/24))
+	}
+	return end, nil
+}
+
+// parseDays parses a Go duration with an optional leading number of days
+func parseDays(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.Index(s, "d"); i > 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[i+1:]
+		if s == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + d, nil
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// Syslog severities (RFC 5424 section 6.2.1)
+const (
+	SyslogCritical = 2
+	SyslogError    = 3
+	SyslogWarning  = 4
+	SyslogNotice   = 5
+	SyslogInfo     = 6
+)
+
+// syslogFacilities maps facility names to their RFC 5424 codes
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// SyslogFacility returns the code for a facility name
+func SyslogFacility(name string) (int, error) {
+	code, ok := syslogFacilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+	return code, nil
+}
+
+// SyslogMessage formats an RFC 5424 message with one structured data element
+func SyslogMessage(facility, severity int, appName, msgID, sdID string, params map[string]string, paramOrder []string, msg string) string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	sd := "-"
+	if sdID != "" {
+		var b strings.Builder
+		b.WriteString("[" + sdID)
+		for _, name := range paramOrder {
+			value, ok := params[name]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, " %s=\"%s\"", name, escapeSDValue(value))
+		}
+		b.WriteString("]")
+		sd = b.String()
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		facility*8+severity,
+		time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogField(hostname, 255),
+		syslogField(appName, 48),
+		os.Getpid(),
+		syslogField(msgID, 32),
+		sd,
+		msg,
+	)
+}
+
+// SendSyslog delivers a message over udp, tcp or tls; stream transports use octet-counting framing
+func SendSyslog(network, address, message string, timeout time.Duration) error {
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: timeout}
+
+	switch network {
+	case "udp":
+		conn, err = dialer.Dial("udp", address)
+	case "tcp":
+		conn, err = dialer.Dial("tcp", address)
+	case "tls":
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, nil)
+	default:
+		return fmt.Errorf("unsupported syslog network %q", network)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.SetWriteDeadline(time.Now().Add(timeout))
+	if network == "udp" {
+		_, err = conn.Write([]byte(message))
+	} else {
+		_, err = fmt.Fprintf(conn, "%d %s", len(message), message)
+	}
+	return err
+}
+
+// syslogField returns a header field as printable ASCII without spaces, or "-" when empty
+func syslogField(value string, max int) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r > 32 && r < 127 {
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if out == "" {
+		return "-"
+	}
+	if len(out) > max {
+		out = out[:max]
+	}
+	return out
+}
+
+// escapeSDValue escapes the characters RFC 5424 reserves in parameter values
+func escapeSDValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Bundle the zone database so named zones resolve on minimal hosts
+	_ "time/tzdata"
+)
+
+// DefaultTimezone is used for alerts and schedules when none is configured
+const DefaultTimezone = "Asia/Kolkata"
+
+// LoadTimezone resolves an IANA zone name such as "Europe/Berlin", "UTC", or a fixed offset such as "+05:30"
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("empty timezone")
+	}
+
+	if name[0] == '+' || name[0] == '-' {
+		offset := strings.ReplaceAll(name[1:], ":", "")
+		if len(offset) != 2 && len(offset) != 4 {
+			return nil, fmt.Errorf("invalid timezone offset %q", name)
+		}
+		hours, err := strconv.Atoi(offset[:2])
+		if err != nil || hours > 14 {
+			return nil, fmt.Errorf("invalid timezone offset %q", name)
+		}
+		minutes := 0
+		if len(offset) == 4 {
+			if minutes, err = strconv.Atoi(offset[2:]); err != nil || minutes > 59 {
+				return nil, fmt.Errorf("invalid timezone offset %q", name)
+			}
+		}
+		seconds := hours*3600 + minutes*60
+		if name[0] == '-' {
+			seconds = -seconds
+		}
+		return time.FixedZone("UTC"+name[:1]+offset[:2]+":"+fmt.Sprintf("%02d", minutes), seconds), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
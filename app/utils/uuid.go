@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+)
+
+// uuidNamespace is the namespace of name-based endpoint IDs
+var uuidNamespace = [16]byte{0x6f, 0x2c, 0x4e, 0x1a, 0x93, 0x57, 0x4b, 0x0d, 0x8e, 0x21, 0x5a, 0xc4, 0x70, 0x3f, 0xb9, 0x16}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("failed to generate UUID: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return formatUUID(b)
+}
+
+// NameUUID returns the name-based (version 5) UUID of name, the same on every call
+func NameUUID(name string) string {
+	h := sha1.New()
+	h.Write(uuidNamespace[:])
+	h.Write([]byte(name))
+	var b [16]byte
+	copy(b[:], h.Sum(nil))
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+	return formatUUID(b)
+}
+
+// IsUUID reports whether s is a UUID in canonical lowercase form
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// webPushRecordSize is the aes128gcm record size; payloads are sent as a single record
+const webPushRecordSize = 4096
+
+// decodeBase64URL decodes base64url with or without padding, as browsers vary
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// hmacSHA256 returns HMAC-SHA256(key, parts...)
+func hmacSHA256(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return mac.Sum(nil)
+}
+
+// EncryptWebPush encrypts a payload for a push subscription using the
+// aes128gcm content encoding (RFC 8291)
+func EncryptWebPush(p256dh, auth string, payload []byte) ([]byte, error) {
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encryptWebPush(p256dh, auth, payload, asPrivate, salt)
+}
+
+// encryptWebPush encrypts with the given ephemeral application server key and salt
+func encryptWebPush(p256dh, auth string, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+
+	curve := ecdh.P256()
+	uaPublic, err := curve.NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	// HKDF with a single 32-byte output block reduces to one HMAC per step
+	prkKey := hmacSHA256(authSecret, ecdhSecret)
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublicBytes...), asPublic...)
+	ikm := hmacSHA256(prkKey, keyInfo, []byte{1})
+
+	prk := hmacSHA256(salt, ikm)
+	cek := hmacSHA256(prk, []byte("Content-Encoding: aes128gcm\x00"), []byte{1})[:16]
+	nonce := hmacSHA256(prk, []byte("Content-Encoding: nonce\x00"), []byte{1})[:12]
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single, final record is terminated by the 0x02 delimiter
+	plaintext := append(append([]byte{}, payload...), 2)
+	if len(plaintext)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("payload too large for web push")
+	}
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return append(header, ciphertext...), nil
+}
+
+// VAPIDPublicKey returns the application server key in the base64url form browsers expect
+func VAPIDPublicKey(key *ecdsa.PrivateKey) (string, error) {
+	public, err := key.PublicKey.ECDH()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(public.Bytes()), nil
+}
+
+// VAPIDAuthorization returns the Authorization header value for a push service (RFC 8292)
+func VAPIDAuthorization(key *ecdsa.PrivateKey, audience, subject string, expires time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": audience,
+		"exp": expires.Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	// ES256 signatures are the fixed-width concatenation r || s
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	publicKey, err := VAPIDPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return "vapid t=" + token + ", k=" + publicKey, nil
+}
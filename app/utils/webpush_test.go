@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEncryptWebPushRFC8291 checks the encryption against the example in RFC 8291 Appendix A
+func TestEncryptWebPushRFC8291(t *testing.T) {
+	const (
+		plaintext    = "When I grow up, I want to be a watermelon"
+		asPrivateKey = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+		uaPublicKey  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+		authSecret   = "BTBZMqHH6r4Tts7J_aSIgg"
+		salt         = "DGv6ra1nlYgDCS1FRnbzlw"
+		want         = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	)
+
+	privateBytes, err := decodeBase64URL(asPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asPrivate, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saltBytes, err := decodeBase64URL(salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := encryptWebPush(uaPublicKey, authSecret, []byte(plaintext), asPrivate, saltBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded := base64.RawURLEncoding.EncodeToString(got); encoded != want {
+		t.Errorf("encrypted message =\n%s\nwant\n%s", encoded, want)
+	}
+}
+
+func TestEncryptWebPushErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		p256dh  string
+		auth    string
+		payload []byte
+	}{
+		{"bad base64 key", "not base64!", "BTBZMqHH6r4Tts7J_aSIgg", []byte("x")},
+		{"key not on curve", "BCVx", "BTBZMqHH6r4Tts7J_aSIgg", []byte("x")},
+		{"bad auth", "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "***", []byte("x")},
+		{"payload too large", "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4", "BTBZMqHH6r4Tts7J_aSIgg", make([]byte, webPushRecordSize)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EncryptWebPush(tt.p256dh, tt.auth, tt.payload); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Unix(1700000000, 0)
+
+	header, err := VAPIDAuthorization(key, "https://fcm.googleapis.com", "mailto:ops@example.com", expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rest, ok := strings.CutPrefix(header, "vapid t=")
+	if !ok {
+		t.Fatalf("header %q does not start with vapid t=", header)
+	}
+	token, publicKey, ok := strings.Cut(rest, ", k=")
+	if !ok {
+		t.Fatalf("header %q has no k= parameter", header)
+	}
+	wantKey, err := VAPIDPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicKey != wantKey {
+		t.Errorf("k = %s, want %s", publicKey, wantKey)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts, want 3", len(parts))
+	}
+
+	var jwtHeader map[string]string
+	decodeJSONPart(t, parts[0], &jwtHeader)
+	if jwtHeader["alg"] != "ES256" || jwtHeader["typ"] != "JWT" {
+		t.Errorf("JWT header = %v, want ES256 JWT", jwtHeader)
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	decodeJSONPart(t, parts[1], &claims)
+	if claims.Aud != "https://fcm.googleapis.com" || claims.Exp != expires.Unix() || claims.Sub != "mailto:ops@example.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != 64 {
+		t.Fatalf("signature is %d bytes, want 64", len(sig))
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		t.Error("signature does not verify with the VAPID public key")
+	}
+
+	// The k parameter must be the uncompressed point the signature verifies against
+	keyBytes, err := decodeBase64URL(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), keyBytes)
+	if x == nil || !ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest[:], r, s) {
+		t.Error("signature does not verify with the key in k")
+	}
+}
+
+// decodeJSONPart decodes a base64url JSON segment of a JWT
+func decodeJSONPart(t *testing.T, part string, v interface{}) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,9 +1,85 @@
 package views
 
-import _ "embed"
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"sort"
+	"strings"
+)
 
-//go:embed dashboard.html
+//go:embed dashboard.html sw.js static
+var files embed.FS
+
+// Static holds the assets served under /static/
+var Static fs.FS
+
+// DashboardHTML is the dashboard page with asset URLs pinned to their content hashes
 var DashboardHTML string
 
-//go:embed app.js
-var AppJS string
+// CSRFPlaceholder marks where the router fills the CSRF token into DashboardHTML
+const CSRFPlaceholder = "{{csrf_token}}"
+
+// ServiceWorkerJS is the push service worker served from the site root
+var ServiceWorkerJS string
+
+// assetHashes maps asset names to a short hash of their contents
+var assetHashes = map[string]string{}
+
+func init() {
+	var err error
+	if Static, err = fs.Sub(files, "static"); err != nil {
+		panic(err)
+	}
+
+	var names []string
+	err = fs.WalkDir(Static, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(Static, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		assetHashes[path] = hex.EncodeToString(sum[:6])
+		names = append(names, path)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Longest names first so no asset path is rewritten inside another
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	var pairs []string
+	for _, name := range names {
+		pairs = append(pairs, `"/static/`+name+`"`, `"`+AssetURL(name)+`"`)
+	}
+
+	html, err := files.ReadFile("dashboard.html")
+	if err != nil {
+		panic(err)
+	}
+	DashboardHTML = strings.NewReplacer(pairs...).Replace(string(html))
+
+	sw, err := files.ReadFile("sw.js")
+	if err != nil {
+		panic(err)
+	}
+	ServiceWorkerJS = string(sw)
+}
+
+// AssetHash returns the content hash of a static asset, or "" if it does not exist
+func AssetHash(name string) string {
+	return assetHashes[name]
+}
+
+// AssetURL returns the cache-busting URL of a static asset
+func AssetURL(name string) string {
+	if hash := assetHashes[name]; hash != "" {
+		return "/static/" + name + "?v=" + hash
+	}
+	return "/static/" + name
+}
@@ -5,6 +5,48 @@ let filterUnhealthy = false;
 let filterExpiringCerts = false;
 let appConfig = { ssl_expiry_warning_days: 30, has_passkey: false };
 
+// The server embeds a CSRF token in the page; write requests must echo it back
+const csrfToken = document.querySelector('meta[name="csrf-token"]').content;
+
+function jsonHeaders() {
+    return { 'Content-Type': 'application/json', 'X-CSRF-Token': csrfToken };
+}
+
+// Once projects exist and an admin passkey is set, reads across all projects need the passkey.
+// It is asked for once and kept for the browser session.
+let passkeyPrompt = null;
+
+async function readFetch(url) {
+    const stored = sessionStorage.getItem('adminPasskey');
+    const resp = await fetch(url, stored ? { headers: { 'X-Admin-Passkey': stored } } : {});
+    if (resp.status !== 401) return resp;
+
+    if (!passkeyPrompt) {
+        passkeyPrompt = Promise.resolve(window.prompt('Enter the admin passkey to view all projects'));
+    }
+    const passkey = await passkeyPrompt;
+    if (!passkey) return resp;
+
+    const retry = await fetch(url, { headers: { 'X-Admin-Passkey': passkey } });
+    if (retry.status === 401) {
+        sessionStorage.removeItem('adminPasskey');
+        passkeyPrompt = null;
+    } else {
+        sessionStorage.setItem('adminPasskey', passkey);
+    }
+    return retry;
+}
+
+// Rejected request bodies come back as JSON naming the field; other errors are plain text
+async function responseError(resp) {
+    const text = await resp.text();
+    try {
+        const body = JSON.parse(text);
+        if (body && body.message) return body.message;
+    } catch (e) {}
+    return text;
+}
+
 // Load config on startup
 async function loadConfig() {
     try {
@@ -26,6 +68,15 @@ function formatTime(timestamp) {
     return new Date(timestamp).toLocaleTimeString();
 }
 
+// Name tooltip with the owner, description and runbook responders need
+function endpointTooltip(endpoint) {
+    const lines = [endpoint.name];
+    if (endpoint.owner) lines.push('Owner: ' + endpoint.owner);
+    if (endpoint.description) lines.push(endpoint.description);
+    if (endpoint.runbook_url) lines.push('Runbook: ' + endpoint.runbook_url);
+    return lines.join('\n').replace(/"/g, '&quot;');
+}
+
 function formatInterval(ns) {
     if (!ns) return '30s';
     const seconds = ns / 1000000000;
@@ -138,7 +189,7 @@ function shouldShowEndpoint(endpoint) {
 
 async function loadHistoryChart(endpointId) {
     try {
-        const resp = await fetch('/api/history?id=' + endpointId);
+        const resp = await readFetch('/api/history?id=' + endpointId + '&limit=50');
         if (!resp.ok) return;
         const data = await resp.json();
         const chart = document.getElementById('chart-' + endpointId);
@@ -240,7 +291,7 @@ async function addEndpoint(e) {
     try {
         const resp = await fetch('/api/endpoints/add', {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify(data)
         });
         if (resp.ok) {
@@ -248,7 +299,7 @@ async function addEndpoint(e) {
             closeAddModal();
             updateDashboard();
         } else {
-            const err = await resp.text();
+            const err = await responseError(resp);
             showToast(err, 'error');
         }
     } catch (err) {
@@ -258,19 +309,19 @@ async function addEndpoint(e) {
 
 async function deleteEndpoint(id, name) {
     console.log('Delete endpoint called with id:', id, 'name:', name);
-    if (!confirm('Delete endpoint "' + name + '"?')) return;
+    if (!confirm('Archive endpoint "' + name + '"? Checks stop but its history is kept.')) return;
     try {
         console.log('Sending delete request for id:', id);
         const resp = await fetch('/api/endpoints/delete', {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify({ id: id })
         });
         console.log('Delete response status:', resp.status);
-        const text = await resp.text();
+        const text = await responseError(resp);
         console.log('Delete response body:', text);
         if (resp.ok) {
-            showToast('Endpoint deleted');
+            showToast('Endpoint archived');
             updateDashboard();
         } else {
             showToast('Failed to delete endpoint: ' + text, 'error');
@@ -286,7 +337,7 @@ async function toggleEndpoint(id, enable) {
     try {
         const resp = await fetch('/api/endpoints/' + action, {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify({ id: id })
         });
         if (resp.ok) {
@@ -305,7 +356,7 @@ async function toggleAlerts(id, suppress) {
     try {
         const resp = await fetch('/api/endpoints/' + action, {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify({ id: id })
         });
         if (resp.ok) {
@@ -322,8 +373,8 @@ async function toggleAlerts(id, suppress) {
 async function updateDashboard() {
     try {
         const [statusResp, endpointsResp] = await Promise.all([
-            fetch('/api/status'),
-            fetch('/api/endpoints')
+            readFetch('/api/status'),
+            readFetch('/api/endpoints')
         ]);
         const statusData = await statusResp.json();
         const endpointsDbData = await endpointsResp.json();
@@ -370,11 +421,11 @@ async function rerunSSLCheck() {
     try {
         const resp = await fetch('/api/ssl/recheck', {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' }
+            headers: jsonHeaders()
         });
 
         if (!resp.ok) {
-            const err = await resp.text();
+            const err = await responseError(resp);
             showToast('Failed to re-run SSL check: ' + err, 'error');
             return;
         }
@@ -395,6 +446,62 @@ async function rerunSSLCheck() {
     }
 }
 
+function urlBase64ToUint8Array(base64String) {
+    const padding = '='.repeat((4 - base64String.length % 4) % 4);
+    const base64 = (base64String + padding).replace(/-/g, '+').replace(/_/g, '/');
+    const raw = atob(base64);
+    return Uint8Array.from([...raw].map(c => c.charCodeAt(0)));
+}
+
+async function enablePushNotifications() {
+    if (!('serviceWorker' in navigator) || !('PushManager' in window)) {
+        showToast('Push notifications are not supported in this browser', 'error');
+        return;
+    }
+
+    try {
+        const permission = await Notification.requestPermission();
+        if (permission !== 'granted') {
+            showToast('Notification permission was not granted', 'error');
+            return;
+        }
+
+        const keyResp = await fetch('/api/push/vapid-key');
+        if (!keyResp.ok) {
+            showToast('Push notifications are not available: ' + await responseError(keyResp), 'error');
+            return;
+        }
+        const { public_key } = await keyResp.json();
+
+        const registration = await navigator.serviceWorker.register('/sw.js');
+        await navigator.serviceWorker.ready;
+
+        let subscription = await registration.pushManager.getSubscription();
+        if (!subscription) {
+            subscription = await registration.pushManager.subscribe({
+                userVisibleOnly: true,
+                applicationServerKey: urlBase64ToUint8Array(public_key)
+            });
+        }
+
+        const resp = await fetch('/api/push/subscribe', {
+            method: 'POST',
+            headers: jsonHeaders(),
+            body: JSON.stringify(subscription.toJSON())
+        });
+
+        if (!resp.ok) {
+            showToast('Failed to register for notifications: ' + await responseError(resp), 'error');
+            return;
+        }
+
+        showToast('Browser notifications enabled');
+    } catch (err) {
+        console.error(err);
+        showToast('Error enabling notifications', 'error');
+    }
+}
+
 function renderEndpoints() {
     const allEndpoints = endpointsData;
     let healthy = 0, unhealthy = 0, disabled = 0, total = 0, expiringCerts = 0, healthMonitored = 0, sslOnly = 0;;
@@ -519,7 +626,7 @@ function renderEndpoints() {
         row.innerHTML = `
             <div class="endpoint-status ${monitorHealth ? endpoint.status : 'ssl-only'}" title="${monitorHealth ? 'Health: ' + endpoint.status : 'SSL Only'}"></div>
             ${monitorBadge}
-            <div class="endpoint-name" title="${endpoint.name}">${endpoint.name}</div>
+            <div class="endpoint-name" title="${endpointTooltip(endpoint)}">${endpoint.name}</div>
             <div class="ssl-expiry ${sslClass}" title="SSL Certificate expires: ${sslExpiryDate}${endpoint.days_to_expiry ? ' (' + endpoint.days_to_expiry + ' days)' : ''}">
                 <span class="ssl-icon">${sslIcon}</span>
                 <span>SSL: ${sslInlineText}</span>
@@ -536,7 +643,7 @@ function renderEndpoints() {
                 ${monitorHealth ? '<button class="icon-btn edit" data-action="history" title="View Health History">📊</button>' : '<button class="icon-btn edit" data-action="enable-health" title="Enable Health Monitoring">🚦</button>'}
                 <button class="icon-btn edit" data-action="edit" title="Edit Endpoint Settings">✏️</button>
                 <button class="icon-btn ${isEnabled ? 'toggle-on' : 'toggle-off'}" data-action="${isEnabled ? 'disable' : 'enable'}" title="${isEnabled ? 'Disable Monitoring' : 'Enable Monitoring'}">${isEnabled ? '⏸️' : '▶️'}</button>
-                ${monitorHealth ? `<button class="icon-btn ${isSuppressed ? 'alert-on' : 'alert-off'}" data-action="${isSuppressed ? 'unsuppress' : 'suppress'}" title="${isSuppressed ? 'Enable Alerts' : 'Suppress Alerts'}">${isSuppressed ? '🔔' : '🔕'}</button>` : ''}
+                ${monitorHealth ? `<button class="icon-btn ${isSuppressed ? 'alert-on' : 'alert-off'}" data-action="${isSuppressed ? 'unsuppress' : 'suppress'}" title="${isSuppressed ? (endpoint.suppressed_until ? `Alerts snoozed until ${new Date(endpoint.suppressed_until).toLocaleString()}, click to enable` : 'Enable Alerts') : 'Suppress Alerts'}">${isSuppressed ? '🔔' : '🔕'}</button>` : ''}
                 <button class="icon-btn delete" data-action="delete" title="Delete Endpoint">🗑️</button>
             </div>
         `;
@@ -607,18 +714,18 @@ document.addEventListener('click', async function (e) {
     console.log('Button clicked:', action, id, name);
 
     if (action === 'delete') {
-        if (!confirm('Delete endpoint "' + name + '"?')) return;
+        if (!confirm('Archive endpoint "' + name + '"? Checks stop but its history is kept.')) return;
         try {
             const resp = await fetch('/api/endpoints/delete', {
                 method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
+                headers: jsonHeaders(),
                 body: JSON.stringify({ id: id })
             });
             if (resp.ok) {
-                showToast('Endpoint deleted');
+                showToast('Endpoint archived');
                 updateDashboard();
             } else {
-                const text = await resp.text();
+                const text = await responseError(resp);
                 showToast('Failed: ' + text, 'error');
             }
         } catch (err) {
@@ -628,7 +735,7 @@ document.addEventListener('click', async function (e) {
         try {
             const resp = await fetch('/api/endpoints/' + action, {
                 method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
+                headers: jsonHeaders(),
                 body: JSON.stringify({ id: id })
             });
             if (resp.ok) {
@@ -644,7 +751,7 @@ document.addEventListener('click', async function (e) {
         try {
             const resp = await fetch('/api/endpoints/' + action, {
                 method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
+                headers: jsonHeaders(),
                 body: JSON.stringify({ id: id })
             });
             if (resp.ok) {
@@ -692,7 +799,7 @@ async function enableHealthMonitoring(e) {
     try {
         const resp = await fetch('/api/endpoints/enable-health', {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify({
                 id: id,
                 passkey: passkey,
@@ -709,7 +816,7 @@ async function enableHealthMonitoring(e) {
             closeEnableHealthModal();
             updateDashboard();
         } else {
-            const text = await resp.text();
+            const text = await responseError(resp);
             if (resp.status === 401) {
                 showToast('Invalid passkey', 'error');
             } else {
@@ -747,7 +854,7 @@ async function updateEndpoint(e) {
     try {
         const resp = await fetch('/api/endpoints/update', {
             method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
+            headers: jsonHeaders(),
             body: JSON.stringify(data)
         });
         if (resp.ok) {
@@ -755,7 +862,7 @@ async function updateEndpoint(e) {
             closeEditModal();
             updateDashboard();
         } else {
-            const err = await resp.text();
+            const err = await responseError(resp);
             showToast(err, 'error');
         }
     } catch (err) {
@@ -768,39 +875,48 @@ async function openHistoryModal(id, name) {
     document.getElementById('historyModal').classList.add('active');
 
     try {
-        const resp = await fetch('/api/history?id=' + id);
+        // Buckets are aggregated server-side to roughly one per pixel column of the timeline
+        const width = document.getElementById('history-chart-large').clientWidth || 600;
+        const buckets = Math.max(20, Math.min(300, Math.floor(width / 3)));
+        const resp = await readFetch('/api/charts?id=' + encodeURIComponent(id) + '&range=24h&buckets=' + buckets);
         if (!resp.ok) return;
         const data = await resp.json();
-        const records = data.records || [];
 
         // Calculate stats
-        let healthy = 0, unhealthy = 0;
-        records.forEach(r => {
-            if (r.status === 'healthy') healthy++;
-            else if (r.status === 'unhealthy') unhealthy++;
+        let healthy = 0, unhealthy = 0, total = 0;
+        (data.buckets || []).forEach(b => {
+            healthy += b.healthy;
+            unhealthy += b.unhealthy;
+            total += b.checks;
         });
-        const total = records.length;
-        const uptime = total > 0 ? ((healthy / total) * 100).toFixed(1) : 0;
+        const uptime = data.availability >= 0 ? data.availability.toFixed(1) : 0;
 
         document.getElementById('hist-total').textContent = total;
         document.getElementById('hist-healthy').textContent = healthy;
         document.getElementById('hist-unhealthy').textContent = unhealthy;
         document.getElementById('hist-uptime').textContent = uptime + '%';
-        document.getElementById('hist-avg').textContent = data.avg_response_time_ms ? formatDuration(data.avg_response_time_ms) : '-';
+        document.getElementById('hist-avg').textContent = data.latency && data.latency.count ? formatDuration(data.latency.avg_ms) : '-';
 
         // Status timeline chart
         const chartEl = document.getElementById('history-chart-large');
         chartEl.innerHTML = '';
-        const displayRecords = records.slice(0, 2000).reverse();
+        const displayRecords = (data.buckets || []).map(b => ({
+            status: b.availability === null ? 'unknown' : b.availability === 100 ? 'healthy' : b.availability === 0 ? 'unhealthy' : 'degraded',
+            availability: b.availability,
+            unhealthy: b.unhealthy,
+            response_time: b.avg_ms ? b.avg_ms * 1000000 : 0,
+            timestamp: b.start
+        }));
         const tooltip = document.getElementById('chart-tooltip');
         displayRecords.forEach(r => {
             const bar = document.createElement('div');
             bar.style.cssText = 'flex:1;min-width:1px;max-width:3px;border-radius:1px 1px 0 0;cursor:pointer;';
-            bar.style.background = r.status === 'healthy' ? '#10b981' : r.status === 'unhealthy' ? '#ef4444' : '#9ca3af';
+            bar.style.background = r.status === 'healthy' ? '#10b981' : r.status === 'unhealthy' ? '#ef4444' : r.status === 'degraded' ? '#f59e0b' : '#9ca3af';
             bar.style.height = '100%';
             const respTime = r.response_time ? formatDuration(r.response_time / 1000000) : '-';
             bar.onmouseenter = function (e) {
-                tooltip.innerHTML = '<strong>' + r.status + '</strong><br>' + respTime + '<br>' + new Date(r.timestamp).toLocaleString();
+                const availability = r.availability === null ? '-' : r.availability.toFixed(1) + '%';
+                tooltip.innerHTML = '<strong>' + r.status + '</strong> (' + availability + ')<br>' + respTime + '<br>' + new Date(r.timestamp).toLocaleString();
                 tooltip.style.display = 'block';
                 tooltip.style.left = (e.clientX + 10) + 'px';
                 tooltip.style.top = (e.clientY - 60) + 'px';
@@ -898,7 +1014,7 @@ async function openHistoryModal(id, name) {
 
             // Draw dots for unhealthy points
             displayRecords.forEach((r, i) => {
-                if (r.status === 'unhealthy') {
+                if (r.unhealthy > 0) {
                     const x = padding + (i / (responseTimes.length - 1)) * chartWidth;
                     const time = r.response_time ? r.response_time / 1000000 : 0;
                     const y = 10 + chartHeight - (time / maxTime) * chartHeight;
@@ -941,7 +1057,7 @@ async function openExpiringCertsModal() {
     document.getElementById('expiringCertsModal').classList.add('active');
 
     try {
-        const resp = await fetch('/api/expiring-certs');
+        const resp = await readFetch('/api/expiring-certs');
         if (!resp.ok) {
             document.getElementById('expiring-certs-list').innerHTML = '<div style="color:#ef4444;padding:20px;text-align:center;">Failed to load expiring certificates</div>';
             return;
@@ -994,6 +1110,12 @@ document.addEventListener('DOMContentLoaded', async function () {
     // Load config first
     await loadConfig();
 
+    // A read-only instance refuses changes, so hide the controls that make them
+    if (appConfig.read_only) {
+        document.body.classList.add('read-only');
+        document.querySelector('.header p').textContent += ' (read-only)';
+    }
+
     // Set 'all' filter as active
     currentFilter = 'all';
     document.querySelectorAll('.stat-card').forEach(card => {
@@ -0,0 +1,33 @@
+// Service worker for Site Watch browser push notifications
+
+self.addEventListener('push', event => {
+    let data = { title: 'Site Watch', body: '' };
+    if (event.data) {
+        try {
+            data = event.data.json();
+        } catch (e) {
+            data.body = event.data.text();
+        }
+    }
+
+    event.waitUntil(self.registration.showNotification(data.title, {
+        body: data.body,
+        tag: data.tag,
+        renotify: true,
+        data: { url: '/' }
+    }));
+});
+
+self.addEventListener('notificationclick', event => {
+    event.notification.close();
+    const target = (event.notification.data && event.notification.data.url) || '/';
+
+    event.waitUntil(clients.matchAll({ type: 'window', includeUncontrolled: true }).then(windows => {
+        for (const win of windows) {
+            if ('focus' in win) {
+                return win.focus();
+            }
+        }
+        return clients.openWindow(target);
+    }));
+});
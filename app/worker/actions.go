@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// Alert actions operators can trigger from a Teams card
+const (
+	ActionAck      = "ack"
+	ActionSuppress = "suppress"
+)
+
+// actionTokenTTL is how long signed action links in alerts stay valid
+const actionTokenTTL = 24 * time.Hour
+
+// actionKeySetting stores the generated action signing key in the settings bucket
+const actionKeySetting = "action_signing_key"
+
+// SetActionLinks configures the base URL and key used to build signed action links
+func (a *Alerter) SetActionLinks(baseURL string, key []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.baseURL = baseURL
+	a.actionKey = key
+}
+
+// dashboardURL returns the dashboard address linked from alerts
+func (a *Alerter) dashboardURL() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.baseURL
+}
+
+// actionURL returns a signed callback URL for an action on an endpoint
+func (a *Alerter) actionURL(action, id string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	token := utils.SignToken(a.actionKey, action+":"+id, time.Now().Add(actionTokenTTL))
+	return a.baseURL + "/api/actions/callback?token=" + url.QueryEscape(token)
+}
+
+// historyURL returns the endpoint detail URL
+func (a *Alerter) historyURL(id string) string {
+	return a.dashboardURL() + "/api/endpoints/" + url.PathEscape(id)
+}
+
+// ParseActionToken verifies a signed action token and returns its action and endpoint ID
+func ParseActionToken(key []byte, token string) (string, string, error) {
+	payload, err := utils.VerifyToken(key, token, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+
+	action, id, found := strings.Cut(payload, ":")
+	if !found || id == "" {
+		return "", "", fmt.Errorf("malformed action token")
+	}
+	return action, id, nil
+}
+
+// teamsEndpointContainer renders one unhealthy endpoint with its action buttons
+func (a *Alerter) teamsEndpointContainer(lang string, state *structs.EndpointState, lastSuccess, downFor, responseTime string) map[string]interface{} {
+	facts := []map[string]string{
+		{"title": utils.Translate(lang, "field.last_success"), "value": lastSuccess},
+		{"title": utils.Translate(lang, "field.down_for"), "value": downFor},
+		{"title": utils.Translate(lang, "field.failures"), "value": fmt.Sprintf("%d", state.ConsecutiveFailures)},
+		{"title": utils.Translate(lang, "field.response_time"), "value": responseTime},
+	}
+	if state.Endpoint.Owner != "" {
+		facts = append(facts, map[string]string{"title": utils.Translate(lang, "field.owner"), "value": state.Endpoint.Owner})
+	}
+
+	actions := []map[string]interface{}{
+		{"type": "Action.Http", "title": utils.Translate(lang, "teams.acknowledge"), "method": "POST", "url": a.actionURL(ActionAck, state.ID)},
+		{"type": "Action.Http", "title": utils.Translate(lang, "teams.suppress"), "method": "POST", "url": a.actionURL(ActionSuppress, state.ID)},
+		{"type": "Action.OpenUrl", "title": utils.Translate(lang, "teams.history"), "url": a.historyURL(state.ID)},
+	}
+	if state.Endpoint.RunbookURL != "" {
+		actions = append(actions, map[string]interface{}{"type": "Action.OpenUrl", "title": utils.Translate(lang, "teams.runbook"), "url": state.Endpoint.RunbookURL})
+	}
+
+	return map[string]interface{}{
+		"type":      "Container",
+		"separator": true,
+		"items": []map[string]interface{}{
+			{
+				"type":   "TextBlock",
+				"text":   fmt.Sprintf("🔴 **%s** — %s", state.Endpoint.Name, state.Endpoint.URL),
+				"wrap":   true,
+				"weight": "Bolder",
+			},
+			{
+				"type":  "FactSet",
+				"facts": facts,
+			},
+			{
+				"type":    "ActionSet",
+				"actions": actions,
+			},
+		},
+	}
+}
+
+// teamsAdaptiveCard wraps card body elements in a Teams webhook message
+func teamsAdaptiveCard(body []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"msteams": map[string]string{"width": "Full"},
+					"body":    body,
+				},
+			},
+		},
+	}
+}
+
+// loadActionKey returns the configured action signing key, generating and storing one if unset
+func loadActionKey(config *structs.Config, db *models.Database) []byte {
+	if config.ActionSigningKey != "" {
+		return []byte(config.ActionSigningKey)
+	}
+
+	var key string
+	if found, err := db.GetSetting(actionKeySetting, &key); err == nil && found && key != "" {
+		return []byte(key)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		logger.Errorf("Failed to generate action signing key: %v", err)
+	}
+	key = hex.EncodeToString(buf)
+	if err := db.SaveSetting(actionKeySetting, key); err != nil {
+		logger.Errorf("Failed to save action signing key: %v", err)
+	}
+	return []byte(key)
+}
+
+// ActionKey returns the key used to sign alert action links
+func (m *Monitor) ActionKey() []byte {
+	return m.actionKey
+}
+
+// AcknowledgeEndpoint marks the current incident as acknowledged until the endpoint recovers
+func (m *Monitor) AcknowledgeEndpoint(id string) error {
+	m.mu.RLock()
+	state, ok := m.states[id]
+	m.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("endpoint not found: %s", id)
+	}
+
+	state.mu.Lock()
+	if state.Status != structs.StatusUnhealthy {
+		state.mu.Unlock()
+		return fmt.Errorf("endpoint %s is not down", id)
+	}
+	state.Acknowledged = true
+	m.saveStateSnapshot(state)
+	state.mu.Unlock()
+
+	logger.Infof("[%s] Incident acknowledged", id)
+	return nil
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// newAlertClient builds the HTTP client used to deliver alerts. Without a proxy it honours
+// HTTPS_PROXY and NO_PROXY; a CA bundle is trusted in addition to the system roots.
+func newAlertClient(config structs.AlertHTTPConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.Proxy != "" {
+		if proxy, err := url.Parse(config.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		} else {
+			logger.Errorf("WARNING: ignoring alert_http proxy: %v", err)
+		}
+	}
+
+	if config.CAFile != "" {
+		if pool, err := utils.LoadCABundle(config.CAFile); err == nil {
+			transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		} else {
+			logger.Errorf("WARNING: ignoring alert_http ca_file: %v", err)
+		}
+	}
+
+	timeout := config.Timeout.Duration
+	if timeout <= 0 {
+		timeout = outboxTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
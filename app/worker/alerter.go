@@ -1,13 +1,14 @@
 package worker
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/smtp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ashanmugaraja/cronzee/app/logger"
@@ -17,7 +18,22 @@ import (
 
 // Alerter handles sending alerts through various channels
 type Alerter struct {
-	config *structs.Alerting
+	config    *structs.Alerting
+	users     []*structs.User
+	onCall    []*structs.OnCallSchedule
+	blackouts []*structs.BlackoutCalendar
+	baseURL   string
+	actionKey []byte
+	push      *WebPusher
+	outbox    *Outbox
+	otlp      *OTLPExporter
+	projectID string
+	loc       *time.Location
+	batches   map[string]*emailBatch
+	mu        sync.RWMutex
+
+	// sending tracks alerts still being handed to their channels
+	sending sync.WaitGroup
 }
 
 // NewAlerter creates a new alerter
@@ -27,32 +43,190 @@ func NewAlerter(config *structs.Alerting) *Alerter {
 	}
 }
 
+// SetUsers replaces the users whose subscriptions receive alerts
+func (a *Alerter) SetUsers(users []*structs.User) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.users = users
+}
+
+// SetOnCallSchedules replaces the schedules whose current on-call user receives alerts
+func (a *Alerter) SetOnCallSchedules(schedules []*structs.OnCallSchedule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onCall = schedules
+}
+
+// SetBlackouts replaces the blackout calendars that suppress or downgrade alerts
+func (a *Alerter) SetBlackouts(calendars []*structs.BlackoutCalendar) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.blackouts = calendars
+}
+
+// blackout returns the blackout action in effect now, if any
+func (a *Alerter) blackout() (string, structs.BlackoutPeriod, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return activeBlackout(a.blackouts, time.Now())
+}
+
+// SetWebPusher enables browser push notifications when web_push_enabled is set
+func (a *Alerter) SetWebPusher(push *WebPusher) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.push = push
+}
+
+// SetOutbox routes notifications through a persistent outbox; projectID selects
+// this alerter's email settings when queued mail is delivered
+func (a *Alerter) SetOutbox(outbox *Outbox, projectID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.outbox = outbox
+	a.projectID = projectID
+}
+
+// SetOTLP exports every alert fired and resolved through an OTLP exporter
+func (a *Alerter) SetOTLP(exporter *OTLPExporter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.otlp = exporter
+}
+
+// SetLocation sets the timezone used for times in alert messages
+func (a *Alerter) SetLocation(loc *time.Location) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.loc = loc
+}
+
+// location returns the alert timezone, defaulting to UTC
+func (a *Alerter) location() *time.Location {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.loc == nil {
+		return time.UTC
+	}
+	return a.loc
+}
+
+// responders returns the subscribers of an endpoint plus whoever is on call for it
+func (a *Alerter) responders(endpoint structs.Endpoint) []*structs.User {
+	users := a.subscribers(endpoint)
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	now := time.Now()
+	for _, schedule := range a.onCall {
+		if !schedule.Filter.Matches(endpoint) {
+			continue
+		}
+		shift, ok := CurrentOnCall(schedule, now)
+		if !ok {
+			continue
+		}
+		for _, user := range a.users {
+			if user.ID == shift.UserID && !user.Disabled && !containsUser(users, user.ID) {
+				users = append(users, user)
+			}
+		}
+	}
+	return users
+}
+
+// containsUser reports whether users contains the user with id
+func containsUser(users []*structs.User, id string) bool {
+	for _, user := range users {
+		if user.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// language returns the message language configured for an alert channel
+func (a *Alerter) language(channel string) string {
+	if lang := a.config.ChannelLanguages[channel]; lang != "" {
+		return lang
+	}
+	if a.config.Language != "" {
+		return a.config.Language
+	}
+	return utils.DefaultLanguage
+}
+
+// userLanguage prefers a subscriber's own language over the channel language
+func (a *Alerter) userLanguage(user *structs.User, channel string) string {
+	if user.Language != "" {
+		return user.Language
+	}
+	return a.language(channel)
+}
+
+// alertText renders an alert's subject and message in a language
+type alertText func(lang string) (subject, message string)
+
+// withOwnership appends the endpoint's description, owner, runbook and labels to an alert message
+func withOwnership(text alertText, endpoint structs.Endpoint) alertText {
+	if endpoint.Description == "" && endpoint.Owner == "" && endpoint.RunbookURL == "" && len(endpoint.Labels) == 0 {
+		return text
+	}
+	return func(lang string) (string, string) {
+		subject, message := text(lang)
+		message += "\n"
+		if endpoint.Description != "" {
+			message += "\n" + utils.Translate(lang, "alert.description", "description", endpoint.Description)
+		}
+		if endpoint.Owner != "" {
+			message += "\n" + utils.Translate(lang, "alert.owner", "owner", endpoint.Owner)
+		}
+		if endpoint.RunbookURL != "" {
+			message += "\n" + utils.Translate(lang, "alert.runbook", "runbook", endpoint.RunbookURL)
+		}
+		if len(endpoint.Labels) > 0 {
+			message += "\n" + utils.Translate(lang, "alert.labels", "labels", utils.FormatLabels(endpoint.Labels))
+		}
+		return subject, message
+	}
+}
+
+// subscribers returns the enabled users subscribed to an endpoint
+func (a *Alerter) subscribers(endpoint structs.Endpoint) []*structs.User {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var matched []*structs.User
+	for _, user := range a.users {
+		if !user.Disabled && user.Subscriptions.Matches(endpoint) {
+			matched = append(matched, user)
+		}
+	}
+	return matched
+}
+
 // SendFailureAlert sends an alert when an endpoint becomes unhealthy
 func (a *Alerter) SendFailureAlert(endpoint structs.Endpoint, state *structs.EndpointState) {
 	if !a.config.Enabled {
 		return
 	}
 
-	message := fmt.Sprintf(
-		"🔴 ALERT: Endpoint '%s' is UNHEALTHY\n\n"+
-			"URL: %s\n"+
-			"Status: %s\n"+
-			"Consecutive Failures: %d\n"+
-			"Last Error: %s\n"+
-			"Last Check: %s\n"+
-			"Response Time: %v",
-		endpoint.Name,
-		endpoint.URL,
-		state.Status,
-		state.ConsecutiveFailures,
-		state.LastError,
-		state.LastCheck.Format(time.RFC3339),
-		state.ResponseTime,
-	)
-
-	subject := fmt.Sprintf("[CRONZEE] Alert: %s is DOWN", endpoint.Name)
+	lastCheck := state.LastCheck.In(a.location()).Format(time.RFC3339)
+	text := func(lang string) (string, string) {
+		vars := []string{
+			"name", endpoint.Name,
+			"url", endpoint.URL,
+			"status", string(state.Status),
+			"failures", strconv.Itoa(state.ConsecutiveFailures),
+			"error", state.LastError,
+			"last_check", lastCheck,
+			"response_time", state.ResponseTime.String(),
+		}
+		return utils.Translate(lang, "alert.failure.subject", vars...), utils.Translate(lang, "alert.failure.body", vars...)
+	}
 
-	a.sendAlert(subject, message, "failure", endpoint, state)
+	a.sendAlert(text, "failure", endpoint, state)
 }
 
 func (a *Alerter) SendGroupedTeamsHealthAlert(interval time.Duration, checkTime time.Time, unhealthyStates []*structs.EndpointState) {
@@ -62,30 +236,59 @@ func (a *Alerter) SendGroupedTeamsHealthAlert(interval time.Duration, checkTime
 	if !a.config.TeamsEnabled || a.config.TeamsWebhookHealthCheck == "" {
 		return
 	}
+	// Leave out endpoints whose failure is below the Teams channel_min_severity
+	var included []*structs.EndpointState
+	for _, state := range unhealthyStates {
+		if a.channelAllows(structs.ChannelTeams, alertSeverity("failure", state.Endpoint, state)) {
+			included = append(included, state)
+		}
+	}
+	unhealthyStates = included
 	if len(unhealthyStates) == 0 {
 		return
 	}
-
-	loc, err := time.LoadLocation("Asia/Kolkata")
-	if err != nil {
-		loc = time.FixedZone("IST", 5*60*60+30*60)
+	if action, period, ok := a.blackout(); ok && action == structs.BlackoutSuppress {
+		logger.Infof("Grouped Teams alert suppressed during blackout: %s", period.Summary)
+		return
 	}
 
-	nowIST := checkTime.In(loc)
+	loc := a.location()
+	nowLocal := checkTime.In(loc)
 
 	// Sort by longest down duration (descending)
 	sort.Slice(unhealthyStates, func(i, j int) bool {
 		return unhealthyStates[i].LastStatusChange.Before(unhealthyStates[j].LastStatusChange)
 	})
 
+	lang := a.language(structs.ChannelTeams)
+	title := utils.Translate(lang, "teams.title", "minutes", strconv.Itoa(int(interval.Minutes())))
+
 	var builder strings.Builder
 
-	builder.WriteString(
-		fmt.Sprintf("📢 HEALTH MONITOR ALERT (%d min) \n\n", int(interval.Minutes())),
-	)
-	builder.WriteString("| Site Name | URL | Status | Last Success Time | Down Duration | Failure Count | Response Time |\n")
+	builder.WriteString(title + " \n\n")
+	builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n",
+		utils.Translate(lang, "field.site_name"),
+		utils.Translate(lang, "field.url"),
+		utils.Translate(lang, "field.status"),
+		utils.Translate(lang, "field.last_success_time"),
+		utils.Translate(lang, "field.down_duration"),
+		utils.Translate(lang, "field.failure_count"),
+		utils.Translate(lang, "field.response_time"),
+	))
 	builder.WriteString("|---|---|---|---|---|---|---|\n")
 
+	// Actionable cards carry one container with ack/suppress/history buttons per endpoint
+	var cardBody []map[string]interface{}
+	if a.config.TeamsActions {
+		cardBody = append(cardBody, map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"wrap":   true,
+		})
+	}
+
 	for _, state := range unhealthyStates {
 		lastSuccess := "-"
 		if !state.LastSuccess.IsZero() {
@@ -94,7 +297,7 @@ func (a *Alerter) SendGroupedTeamsHealthAlert(interval time.Duration, checkTime
 
 		downFor := "-"
 		if !state.LastSuccess.IsZero() {
-			downFor = utils.FormatDurationDHm(nowIST.Sub(state.LastSuccess.In(loc)))
+			downFor = utils.FormatDurationDHm(nowLocal.Sub(state.LastSuccess.In(loc)))
 
 		}
 
@@ -104,23 +307,39 @@ func (a *Alerter) SendGroupedTeamsHealthAlert(interval time.Duration, checkTime
 			responseTime = fmt.Sprintf("%.2fms", responseMs)
 		}
 
+		// Link the site name to its runbook and show who owns it
+		siteName := state.Endpoint.Name
+		if state.Endpoint.RunbookURL != "" {
+			siteName = fmt.Sprintf("[%s](%s)", siteName, state.Endpoint.RunbookURL)
+		}
+		if state.Endpoint.Owner != "" {
+			siteName += " — " + state.Endpoint.Owner
+		}
+
 		builder.WriteString(fmt.Sprintf(
 			"| %s | %s | %s | %s | %s | %d | %s |\n",
-			state.Endpoint.Name,
+			siteName,
 			state.Endpoint.URL,
-			"🔴 DOWN",
+			utils.Translate(lang, "teams.down"),
 			lastSuccess,
 			downFor,
 			state.ConsecutiveFailures,
 			responseTime,
 		))
+
+		if a.config.TeamsActions {
+			cardBody = append(cardBody, a.teamsEndpointContainer(lang, state, lastSuccess, downFor, responseTime))
+		}
 	}
 
-	builder.WriteString("\n🔗 For more info visit: https://sitewatch.ezeebits.in\n")
+	builder.WriteString("\n" + utils.Translate(lang, "more_info", "url", a.dashboardURL()) + "\n")
 
 	payload := map[string]interface{}{
 		"text": builder.String(),
 	}
+	if a.config.TeamsActions {
+		payload = teamsAdaptiveCard(cardBody)
+	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
@@ -128,113 +347,321 @@ func (a *Alerter) SendGroupedTeamsHealthAlert(interval time.Duration, checkTime
 		return
 	}
 
-	resp, err := http.Post(
-		a.config.TeamsWebhookHealthCheck,
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		logger.Errorf("Teams grouped alert failed: %v", err)
+	a.queueHTTP(newAlertID(), structs.ChannelTeams, a.config.TeamsWebhookHealthCheck, jsonData,
+		fmt.Sprintf("Teams grouped alert (%d endpoints, interval=%s)", len(unhealthyStates), interval.String()))
+}
+
+// SendRecoveryAlert sends an alert when an endpoint recovers
+func (a *Alerter) SendRecoveryAlert(endpoint structs.Endpoint, state *structs.EndpointState) {
+	if !a.config.Enabled {
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		logger.Infof("Teams grouped alert sent (%d endpoints, interval=%s)", len(unhealthyStates), interval.String())
-	} else {
-		logger.Errorf("Teams webhook returned status %d", resp.StatusCode)
+	downtime := time.Since(state.LastStatusChange)
+	lastCheck := state.LastCheck.In(a.location()).Format(time.RFC3339)
+	text := func(lang string) (string, string) {
+		vars := []string{
+			"name", endpoint.Name,
+			"url", endpoint.URL,
+			"status", string(state.Status),
+			"downtime", downtime.Round(time.Second).String(),
+			"response_time", state.ResponseTime.String(),
+			"last_check", lastCheck,
+		}
+		return utils.Translate(lang, "alert.recovery.subject", vars...), utils.Translate(lang, "alert.recovery.body", vars...)
 	}
+
+	a.sendAlert(text, "recovery", endpoint, state)
 }
 
-// SendRecoveryAlert sends an alert when an endpoint recovers
-func (a *Alerter) SendRecoveryAlert(endpoint structs.Endpoint, state *structs.EndpointState) {
+// SendSLAAlert sends an SLA breach or error-budget burn alert
+func (a *Alerter) SendSLAAlert(endpoint structs.Endpoint, state *structs.EndpointState, alertType, detail string) {
 	if !a.config.Enabled {
 		return
 	}
 
-	downtime := time.Since(state.LastStatusChange)
-	message := fmt.Sprintf(
-		"✅ RECOVERY: Endpoint '%s' is HEALTHY\n\n"+
-			"URL: %s\n"+
-			"Status: %s\n"+
-			"Downtime: %v\n"+
-			"Response Time: %v\n"+
-			"Last Check: %s",
-		endpoint.Name,
-		endpoint.URL,
-		state.Status,
-		downtime.Round(time.Second),
-		state.ResponseTime,
-		state.LastCheck.Format(time.RFC3339),
-	)
+	subjectKey := "alert.sla.subject"
+	if alertType == "sla_burn_rate" {
+		subjectKey = "alert.sla_burn.subject"
+	}
+	text := func(lang string) (string, string) {
+		vars := []string{
+			"name", endpoint.Name,
+			"url", endpoint.URL,
+			"target", strconv.FormatFloat(endpoint.SLATarget, 'f', 3, 64),
+			"detail", detail,
+		}
+		return utils.Translate(lang, subjectKey, vars...), utils.Translate(lang, "alert.sla.body", vars...)
+	}
 
-	subject := fmt.Sprintf("[CRONZEE] Recovery: %s is UP", endpoint.Name)
+	a.sendAlert(text, alertType, endpoint, state)
+}
 
-	a.sendAlert(subject, message, "recovery", endpoint, state)
+// SendSANAlert sends an alert when an endpoint's certificate stops or starts covering its hostnames
+func (a *Alerter) SendSANAlert(endpoint structs.Endpoint, state *structs.EndpointState, alertType string, hosts []string) {
+	if !a.config.Enabled {
+		return
+	}
+
+	key := "alert.ssl_san"
+	if alertType == "ssl_san_recovery" {
+		key = "alert.ssl_san_recovery"
+	}
+	text := func(lang string) (string, string) {
+		vars := []string{
+			"name", endpoint.Name,
+			"url", endpoint.URL,
+			"hosts", strings.Join(hosts, ", "),
+		}
+		return utils.Translate(lang, key+".subject", vars...), utils.Translate(lang, key+".body", vars...)
+	}
+
+	a.sendAlert(text, alertType, endpoint, state)
 }
 
-// sendAlert sends alerts through configured channels
-func (a *Alerter) sendAlert(subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
-	if a.config.WebhookURL != "" {
-		go a.sendWebhookAlert(subject, message, alertType, endpoint, state)
+// SendTLSAlert sends an alert about an endpoint's TLS protocol; vars fill the message
+func (a *Alerter) SendTLSAlert(endpoint structs.Endpoint, state *structs.EndpointState, alertType string, vars ...string) {
+	if !a.config.Enabled {
+		return
 	}
 
-	if a.config.SlackEnabled && a.config.SlackWebhook != "" {
-		go a.sendSlackAlert(subject, message, alertType, endpoint, state)
+	key := "alert." + alertType
+	text := func(lang string) (string, string) {
+		all := append([]string{"name", endpoint.Name, "url", endpoint.URL}, vars...)
+		return utils.Translate(lang, key+".subject", all...), utils.Translate(lang, key+".body", all...)
 	}
 
-	if a.config.EmailEnabled {
-		go a.sendEmailAlert(subject, message)
+	a.sendAlert(text, alertType, endpoint, state)
+}
+
+// SendServiceAlert sends an alert when a service's rolled-up status changes
+func (a *Alerter) SendServiceAlert(status structs.ServiceStatus, alertType string) {
+	if !a.config.Enabled {
+		return
 	}
+
+	service := status.Service
+	key := "alert.service_failure"
+	if alertType == "service_recovery" {
+		key = "alert.service_recovery"
+	}
+	text := func(lang string) (string, string) {
+		vars := []string{
+			"name", service.Name,
+			"policy", string(service.Policy),
+			"healthy", strconv.Itoa(status.Healthy),
+			"total", strconv.Itoa(status.Total),
+			"unhealthy", strconv.Itoa(status.Unhealthy),
+			"score", strconv.FormatFloat(status.HealthyScore, 'f', 1, 64),
+		}
+		return utils.Translate(lang, key+".subject", vars...), utils.Translate(lang, key+".body", vars...)
+	}
+
+	// Services reuse the endpoint alert channels with a synthetic endpoint
+	endpoint := structs.Endpoint{Name: service.Name, Priority: structs.PriorityHigh, ProjectID: service.ProjectID}
+	state := &structs.EndpointState{
+		ID:        service.ID,
+		Endpoint:  endpoint,
+		Status:    status.Status,
+		LastCheck: time.Now(),
+	}
+
+	a.sendAlert(text, alertType, endpoint, state)
 }
 
-// sendWebhookAlert sends a generic webhook alert
-func (a *Alerter) sendWebhookAlert(subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
-	payload := map[string]interface{}{
-		"subject":    subject,
-		"message":    message,
-		"alert_type": alertType,
-		"endpoint": map[string]interface{}{
-			"name":   endpoint.Name,
-			"url":    endpoint.URL,
-			"method": endpoint.Method,
-		},
-		"state": map[string]interface{}{
-			"status":               string(state.Status),
-			"consecutive_failures": state.ConsecutiveFailures,
-			"last_error":           state.LastError,
-			"response_time_ms":     state.ResponseTime.Milliseconds(),
-			"last_check":           state.LastCheck.Format(time.RFC3339),
-		},
-		"timestamp": time.Now().Format(time.RFC3339),
+// SendSelfAlert sends an alert when one of SiteWatch's own health checks fails or recovers
+func (a *Alerter) SendSelfAlert(check string, vars []string, alertType string) {
+	if !a.config.Enabled {
+		return
+	}
+
+	key := "alert.self_failure"
+	if alertType == "self_recovery" {
+		key = "alert.self_recovery"
+	}
+	text := func(lang string) (string, string) {
+		name := utils.Translate(lang, "self."+check)
+		detail := utils.Translate(lang, "self."+check+".detail", vars...)
+		return utils.Translate(lang, key+".subject", "check", name), utils.Translate(lang, key+".body", "check", name, "detail", detail)
 	}
 
-	for key, value := range a.config.CustomFields {
-		payload[key] = value
+	// Like services, SiteWatch reports on itself through a synthetic endpoint
+	endpoint := structs.Endpoint{Name: "SiteWatch: " + check, Priority: structs.PriorityCritical}
+	status := structs.StatusUnhealthy
+	if alertType == "self_recovery" {
+		status = structs.StatusHealthy
+	}
+	state := &structs.EndpointState{
+		ID:        "sitewatch-" + check,
+		Endpoint:  endpoint,
+		Status:    status,
+		LastCheck: time.Now(),
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		logger.Errorf("Failed to marshal webhook payload: %v", err)
+	a.sendAlert(text, alertType, endpoint, state)
+}
+
+// sendAlert sends alerts through configured channels, each rendered in its configured language
+func (a *Alerter) sendAlert(text alertText, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
+	text = withOwnership(text, endpoint)
+
+	// Company-wide blackouts silence alerts or send them at the lowest priority
+	if action, period, ok := a.blackout(); ok {
+		if action == structs.BlackoutSuppress {
+			logger.Infof("[%s] %s alert suppressed during blackout: %s", endpoint.Name, alertType, period.Summary)
+			return
+		}
+		endpoint.Priority = structs.PriorityLow
+	}
+
+	// Every channel's delivery of this alert shares one ID
+	alertID := newAlertID()
+
+	// Each channel only takes alerts at or above its channel_min_severity
+	severity := alertSeverity(alertType, endpoint, state)
+
+	a.mu.RLock()
+	otlp := a.otlp
+	a.mu.RUnlock()
+	if otlp != nil {
+		subject, _ := text(a.language(""))
+		otlp.RecordAlert(alertEvent{
+			time:        time.Now(),
+			alertID:     alertID,
+			alertType:   alertType,
+			name:        alertmanagerName(alertType),
+			severity:    severity,
+			fingerprint: alertmanagerFingerprint(alertType, endpoint),
+			resolved:    strings.HasSuffix(alertType, "recovery"),
+			subject:     subject,
+			endpoint:    endpoint,
+			endpointID:  state.ID,
+		})
+	}
+	webhook := a.channelAllows(structs.ChannelWebhook, severity)
+	slack := a.channelAllows(structs.ChannelSlack, severity)
+	email := a.channelAllows(structs.ChannelEmail, severity)
+
+	if webhook && a.config.WebhookURL != "" {
+		subject, message := text(a.language(structs.ChannelWebhook))
+		a.async(func() {
+			a.sendWebhookAlert(alertID, a.config.WebhookURL, 0, subject, message, alertType, endpoint, state)
+		})
+	}
+
+	if slack && a.config.SlackEnabled && a.config.SlackWebhook != "" {
+		lang := a.language(structs.ChannelSlack)
+		subject, _ := text(lang)
+		a.async(func() { a.sendSlackAlert(alertID, a.config.SlackWebhook, lang, subject, alertType, endpoint, state) })
+	}
+
+	if a.channelAllows(structs.ChannelSyslog, severity) && a.config.SyslogEnabled && a.config.Syslog.Address != "" {
+		subject, _ := text(a.language(structs.ChannelSyslog))
+		a.async(func() { a.sendSyslogAlert(subject, alertType, endpoint, state) })
+	}
+
+	// Email recipients are grouped so each language gets one message
+	recipients := make(map[string][]string)
+	var seen []string
+	if email && a.config.EmailEnabled {
+		lang := a.language(structs.ChannelEmail)
+		recipients[lang] = append(recipients[lang], a.config.EmailConfig.To...)
+		seen = append(seen, a.config.EmailConfig.To...)
+	}
+
+	// Fan out to users subscribed to this endpoint's tags or project and to whoever is on call
+	for _, user := range a.responders(endpoint) {
+		user := user
+		if webhook && user.WebhookURL != "" {
+			subject, message := text(a.userLanguage(user, structs.ChannelWebhook))
+			a.async(func() {
+				a.sendWebhookAlert(alertID, user.WebhookURL, user.WebhookVersion, subject, message, alertType, endpoint, state)
+			})
+		}
+		if slack && user.SlackWebhook != "" {
+			lang := a.userLanguage(user, structs.ChannelSlack)
+			subject, _ := text(lang)
+			a.async(func() { a.sendSlackAlert(alertID, user.SlackWebhook, lang, subject, alertType, endpoint, state) })
+		}
+		if email && user.Email != "" && !containsString(seen, user.Email) {
+			lang := a.userLanguage(user, structs.ChannelEmail)
+			recipients[lang] = append(recipients[lang], user.Email)
+			seen = append(seen, user.Email)
+		}
+	}
+
+	for lang, to := range recipients {
+		lang, to := lang, to
+		subject, message := text(lang)
+		a.async(func() { a.queueEmail(alertID, lang, to, subject, message, endpoint, state) })
+	}
+
+	a.mu.RLock()
+	push := a.push
+	a.mu.RUnlock()
+	if a.channelAllows(structs.ChannelPush, severity) && a.config.WebPushEnabled && push != nil {
+		subject, _ := text(a.language(structs.ChannelPush))
+		body := endpoint.URL
+		if state.LastError != "" {
+			body += "\n" + state.LastError
+		}
+		urgent := alertType == "failure" || alertType == "service_failure" || alertType == "self_failure"
+		a.async(func() { push.Notify(endpoint, subject, body, urgent) })
+	}
+}
+
+// async hands an alert to a channel in the background, tracked so shutdown can wait for it
+func (a *Alerter) async(send func()) {
+	a.sending.Add(1)
+	go func() {
+		defer a.sending.Done()
+		send()
+	}()
+}
+
+// Drain waits for alerts still being handed to their channels, then sends held email batches
+func (a *Alerter) Drain() {
+	a.sending.Wait()
+	a.FlushEmailBatches()
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sendWebhookAlert sends a generic webhook alert in the receiver's schema version
+func (a *Alerter) sendWebhookAlert(alertID, url string, version int, subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
+	if a.config.WebhookFormat == structs.WebhookFormatAlertmanager {
+		a.postWebhook(alertID, url, a.alertmanagerPayload(subject, message, alertType, endpoint, state), endpoint)
 		return
 	}
 
-	resp, err := http.Post(a.config.WebhookURL, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		logger.Errorf("Failed to send webhook alert: %v", err)
+	if a.webhookVersion(version) == structs.WebhookVersion2 {
+		a.postWebhook(alertID, url, a.webhookPayloadV2(alertID, subject, message, alertType, endpoint, state), endpoint)
 		return
 	}
-	defer resp.Body.Close()
+	a.postWebhook(alertID, url, a.webhookPayloadV1(alertID, subject, message, alertType, endpoint, state), endpoint)
+}
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		logger.Infof("Webhook alert sent successfully for endpoint: %s", endpoint.Name)
-	} else {
-		logger.Errorf("Webhook alert failed with status code: %d", resp.StatusCode)
+// postWebhook posts a JSON alert payload to a webhook receiver
+func (a *Alerter) postWebhook(alertID, url string, payload interface{}, endpoint structs.Endpoint) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("Failed to marshal webhook payload: %v", err)
+		return
 	}
+
+	a.queueHTTP(alertID, structs.ChannelWebhook, url, jsonData, "Webhook alert for endpoint: "+endpoint.Name)
 }
 
 // sendSlackAlert sends an alert to Slack
-func (a *Alerter) sendSlackAlert(subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
+func (a *Alerter) sendSlackAlert(alertID, url, lang, subject, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
 	color := "danger"
 	emoji := "🔴"
 	if alertType == "recovery" {
@@ -248,25 +675,34 @@ func (a *Alerter) sendSlackAlert(subject, message, alertType string, endpoint st
 			{
 				"color": color,
 				"fields": []map[string]interface{}{
-					{"title": "Endpoint", "value": endpoint.Name, "short": true},
-					{"title": "URL", "value": endpoint.URL, "short": true},
-					{"title": "Status", "value": string(state.Status), "short": true},
-					{"title": "Response Time", "value": fmt.Sprintf("%v", state.ResponseTime), "short": true},
+					{"title": utils.Translate(lang, "field.endpoint"), "value": endpoint.Name, "short": true},
+					{"title": utils.Translate(lang, "field.url"), "value": endpoint.URL, "short": true},
+					{"title": utils.Translate(lang, "field.status"), "value": string(state.Status), "short": true},
+					{"title": utils.Translate(lang, "field.severity"), "value": string(alertSeverity(alertType, endpoint, state)), "short": true},
+					{"title": utils.Translate(lang, "field.response_time"), "value": fmt.Sprintf("%v", state.ResponseTime), "short": true},
 				},
-				"footer": "Cronzee Health Monitor",
+				"footer": utils.Translate(lang, "footer"),
 				"ts":     time.Now().Unix(),
 			},
 		},
 	}
 
-	if state.LastError != "" {
-		attachments := payload["attachments"].([]map[string]interface{})
+	attachments := payload["attachments"].([]map[string]interface{})
+	addField := func(title, value string, short bool) {
+		if value == "" {
+			return
+		}
 		attachments[0]["fields"] = append(attachments[0]["fields"].([]map[string]interface{}), map[string]interface{}{
-			"title": "Error",
-			"value": state.LastError,
-			"short": false,
+			"title": utils.Translate(lang, title),
+			"value": value,
+			"short": short,
 		})
 	}
+	addField("field.owner", endpoint.Owner, true)
+	addField("field.runbook", endpoint.RunbookURL, true)
+	addField("field.labels", utils.FormatLabels(endpoint.Labels), false)
+	addField("field.description", endpoint.Description, false)
+	addField("field.error", state.LastError, false)
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
@@ -274,25 +710,45 @@ func (a *Alerter) sendSlackAlert(subject, message, alertType string, endpoint st
 		return
 	}
 
-	resp, err := http.Post(a.config.SlackWebhook, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		logger.Errorf("Failed to send Slack alert: %v", err)
+	a.queueHTTP(alertID, structs.ChannelSlack, url, jsonData, "Slack alert for endpoint: "+endpoint.Name)
+}
+
+// sendEmailAlert queues an email alert to the given recipients
+func (a *Alerter) sendEmailAlert(alertID string, recipients []string, subject, message string) {
+	if a.config.EmailConfig.SMTPHost == "" {
+		logger.Error("Email SMTP host not configured")
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		logger.Infof("Slack alert sent successfully for endpoint: %s", endpoint.Name)
-	} else {
-		logger.Errorf("Slack alert failed with status code: %d", resp.StatusCode)
+	description := "Email alert to: " + strings.Join(recipients, ",")
+
+	a.mu.RLock()
+	outbox := a.outbox
+	a.mu.RUnlock()
+	if outbox == nil {
+		if err := a.deliverEmail(recipients, subject, message); err != nil {
+			logger.Errorf("%s failed: %v", description, err)
+			return
+		}
+		logger.Infof("%s sent successfully", description)
+		return
 	}
+
+	outbox.Enqueue(&structs.OutboxMessage{
+		AlertID:     alertID,
+		ProjectID:   a.projectID,
+		Channel:     structs.ChannelEmail,
+		Description: description,
+		Recipients:  recipients,
+		Subject:     subject,
+		Body:        message,
+	})
 }
 
-// sendEmailAlert sends an email alert
-func (a *Alerter) sendEmailAlert(subject, message string) {
+// deliverEmail sends an email through the configured SMTP server
+func (a *Alerter) deliverEmail(recipients []string, subject, message string) error {
 	if a.config.EmailConfig.SMTPHost == "" {
-		logger.Error("Email SMTP host not configured")
-		return
+		return fmt.Errorf("email SMTP host not configured")
 	}
 
 	auth := smtp.PlainAuth(
@@ -302,7 +758,7 @@ func (a *Alerter) sendEmailAlert(subject, message string) {
 		a.config.EmailConfig.SMTPHost,
 	)
 
-	to := strings.Join(a.config.EmailConfig.To, ",")
+	to := strings.Join(recipients, ",")
 
 	emailBody := fmt.Sprintf(
 		"From: %s\r\n"+
@@ -318,69 +774,297 @@ func (a *Alerter) sendEmailAlert(subject, message string) {
 
 	addr := fmt.Sprintf("%s:%d", a.config.EmailConfig.SMTPHost, a.config.EmailConfig.SMTPPort)
 
-	err := smtp.SendMail(
+	return smtp.SendMail(
 		addr,
 		auth,
 		a.config.EmailConfig.From,
-		a.config.EmailConfig.To,
+		recipients,
 		[]byte(emailBody),
 	)
+}
 
-	if err != nil {
-		logger.Errorf("Failed to send email alert: %v", err)
+// queueHTTP hands a JSON notification to the outbox, or posts it directly when there is none
+func (a *Alerter) queueHTTP(alertID, channel, url string, payload []byte, description string) {
+	a.mu.RLock()
+	outbox := a.outbox
+	a.mu.RUnlock()
+
+	if outbox == nil {
+		if _, _, err := postJSON(&http.Client{Timeout: outboxTimeout}, url, payload); err != nil {
+			logger.Errorf("%s failed: %v", description, err)
+			return
+		}
+		logger.Infof("%s sent successfully", description)
 		return
 	}
 
-	logger.Infof("Email alert sent successfully to: %s", to)
+	outbox.Enqueue(&structs.OutboxMessage{
+		AlertID:     alertID,
+		ProjectID:   a.projectID,
+		Channel:     channel,
+		Description: description,
+		URL:         url,
+		Body:        string(payload),
+	})
 }
 
 // SSLExpiryInfo holds information about an expiring SSL certificate
 type SSLExpiryInfo struct {
+	EndpointID   string
 	EndpointName string
 	URL          string
 	ExpiryDate   time.Time
 	DaysToExpiry int
+	ProjectID    string
+	Error        string // Why the certificate could not be checked
 }
 
-func (a *Alerter) SendSSLExpirySummary(expiringCerts []SSLExpiryInfo) {
-	if !a.config.TeamsEnabled || a.config.TeamsWebhookSSLExpiry == "" {
-		return
+// SSLSummary groups the certificates an SSL expiry summary reports on
+type SSLSummary struct {
+	Expiring []SSLExpiryInfo
+	Expired  []SSLExpiryInfo
+	Failed   []SSLExpiryInfo
+}
+
+// Count returns how many certificates the summary lists
+func (s SSLSummary) Count() int {
+	return len(s.Expiring) + len(s.Expired) + len(s.Failed)
+}
+
+// sslSeverity returns the severity label for a certificate's remaining days
+func sslSeverity(daysToExpiry int) string {
+	if daysToExpiry <= 7 {
+		return "critical"
 	}
+	return "warning"
+}
 
-	if len(expiringCerts) == 0 {
+// SendSSLExpirySummary sends the SSL expiry summary through every enabled channel
+func (a *Alerter) SendSSLExpirySummary(summary SSLSummary) {
+	if summary.Count() == 0 {
 		logger.Info("No expiring SSL certificates to report")
 		return
 	}
 
+	alertID := newAlertID()
+
 	// Sort by nearest expiry (ascending)
-	sort.Slice(expiringCerts, func(i, j int) bool {
-		return expiringCerts[i].DaysToExpiry < expiringCerts[j].DaysToExpiry
+	sort.Slice(summary.Expiring, func(i, j int) bool {
+		return summary.Expiring[i].DaysToExpiry < summary.Expiring[j].DaysToExpiry
 	})
 
-	// 🔹 Build MARKDOWN table for Teams
+	if a.config.TeamsEnabled && a.config.TeamsWebhookSSLExpiry != "" {
+		a.sendTeamsSSLExpirySummary(alertID, summary)
+	}
+
+	if !a.config.Enabled {
+		return
+	}
+
+	if a.config.WebhookURL != "" {
+		a.async(func() { a.sendWebhookSSLExpirySummary(alertID, summary) })
+	}
+
+	if a.config.SlackEnabled && a.config.SlackWebhook != "" {
+		a.async(func() { a.sendSlackSSLExpirySummary(alertID, summary) })
+	}
+
+	if a.config.EmailEnabled {
+		a.async(func() { a.sendEmailSSLExpirySummary(alertID, summary) })
+	}
+}
+
+// sendWebhookSSLExpirySummary posts the SSL expiry summary as structured JSON
+func (a *Alerter) sendWebhookSSLExpirySummary(alertID string, summary SSLSummary) {
+	payload := a.sslSummaryPayload(a.webhookVersion(0), alertID, summary)
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("Failed to marshal SSL expiry summary webhook payload: %v", err)
+		return
+	}
+
+	a.queueHTTP(alertID, structs.ChannelWebhook, a.config.WebhookURL, jsonData,
+		fmt.Sprintf("SSL expiry summary webhook (%d endpoints)", summary.Count()))
+}
+
+// sendSlackSSLExpirySummary posts the SSL expiry summary as a Slack message
+func (a *Alerter) sendSlackSSLExpirySummary(alertID string, summary SSLSummary) {
+	lang := a.language(structs.ChannelSlack)
+
 	var builder strings.Builder
+	for _, cert := range summary.Expiring {
+		emoji := "⚠️"
+		if sslSeverity(cert.DaysToExpiry) == "critical" {
+			emoji = "🚨"
+		}
+		builder.WriteString(utils.Translate(lang, "ssl.line",
+			"emoji", emoji,
+			"name", cert.EndpointName,
+			"url", cert.URL,
+			"date", cert.ExpiryDate.Format("02 Jan 2006"),
+			"days", strconv.Itoa(cert.DaysToExpiry),
+		) + "\n")
+	}
+	if len(summary.Expired) > 0 {
+		builder.WriteString("\n*" + utils.Translate(lang, "ssl.expired_title", "count", strconv.Itoa(len(summary.Expired))) + "*\n")
+		for _, cert := range summary.Expired {
+			builder.WriteString(utils.Translate(lang, "ssl.expired_line",
+				"name", cert.EndpointName,
+				"url", cert.URL,
+				"date", cert.ExpiryDate.Format("02 Jan 2006"),
+				"days", strconv.Itoa(-cert.DaysToExpiry),
+			) + "\n")
+		}
+	}
+	if len(summary.Failed) > 0 {
+		builder.WriteString("\n*" + utils.Translate(lang, "ssl.failed_title", "count", strconv.Itoa(len(summary.Failed))) + "*\n")
+		for _, cert := range summary.Failed {
+			builder.WriteString(utils.Translate(lang, "ssl.failed_line",
+				"name", cert.EndpointName,
+				"url", cert.URL,
+				"error", cert.Error,
+			) + "\n")
+		}
+	}
 
-	builder.WriteString("📢 SSL EXPIRY NOTIFICATIONS\n\n")
-	builder.WriteString("| Endpoint | URL | Expiry Date | Days Left | Severity |\n")
-	builder.WriteString("|---------|-----|------------|-----------|----------|\n")
+	color := "warning"
+	if len(summary.Expired) > 0 || len(summary.Failed) > 0 {
+		color = "danger"
+	}
+	payload := map[string]interface{}{
+		"text": "📢 " + utils.Translate(lang, "ssl.title"),
+		"attachments": []map[string]interface{}{
+			{
+				"color":  color,
+				"text":   strings.TrimPrefix(builder.String(), "\n"),
+				"footer": utils.Translate(lang, "footer"),
+				"ts":     time.Now().Unix(),
+			},
+		},
+	}
 
-	for _, cert := range expiringCerts {
-		status := "⚠️ Warning"
-		if cert.DaysToExpiry <= 7 {
-			status = "🚨 Critical"
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("Failed to marshal SSL expiry summary Slack payload: %v", err)
+		return
+	}
+
+	a.queueHTTP(alertID, structs.ChannelSlack, a.config.SlackWebhook, jsonData,
+		fmt.Sprintf("SSL expiry summary to Slack (%d endpoints)", summary.Count()))
+}
+
+// sendEmailSSLExpirySummary emails the SSL expiry summary as plain-text tables
+func (a *Alerter) sendEmailSSLExpirySummary(alertID string, summary SSLSummary) {
+	lang := a.language(structs.ChannelEmail)
+
+	var builder strings.Builder
+	builder.WriteString(utils.Translate(lang, "ssl.title") + "\r\n\r\n")
+	if len(summary.Expiring) > 0 {
+		builder.WriteString(fmt.Sprintf("%-30s %-12s %-9s %s\r\n",
+			utils.Translate(lang, "field.endpoint"),
+			utils.Translate(lang, "field.expiry_date"),
+			utils.Translate(lang, "field.days_left"),
+			utils.Translate(lang, "field.url"),
+		))
+		for _, cert := range summary.Expiring {
+			builder.WriteString(fmt.Sprintf("%-30s %-12s %-9d %s\r\n",
+				cert.EndpointName, cert.ExpiryDate.Format("02 Jan 2006"), cert.DaysToExpiry, cert.URL))
+		}
+		builder.WriteString("\r\n")
+	}
+	if len(summary.Expired) > 0 {
+		builder.WriteString(utils.Translate(lang, "ssl.expired_title", "count", strconv.Itoa(len(summary.Expired))) + "\r\n")
+		builder.WriteString(fmt.Sprintf("%-30s %-12s %-9s %s\r\n",
+			utils.Translate(lang, "field.endpoint"),
+			utils.Translate(lang, "field.expiry_date"),
+			utils.Translate(lang, "field.days_ago"),
+			utils.Translate(lang, "field.url"),
+		))
+		for _, cert := range summary.Expired {
+			builder.WriteString(fmt.Sprintf("%-30s %-12s %-9d %s\r\n",
+				cert.EndpointName, cert.ExpiryDate.Format("02 Jan 2006"), -cert.DaysToExpiry, cert.URL))
 		}
+		builder.WriteString("\r\n")
+	}
+	if len(summary.Failed) > 0 {
+		builder.WriteString(utils.Translate(lang, "ssl.failed_title", "count", strconv.Itoa(len(summary.Failed))) + "\r\n")
+		for _, cert := range summary.Failed {
+			builder.WriteString(fmt.Sprintf("%-30s %s\r\n  %s: %s\r\n",
+				cert.EndpointName, cert.URL, utils.Translate(lang, "field.error"), cert.Error))
+		}
+	}
 
-		builder.WriteString(fmt.Sprintf(
-			"| %s | %s | %s | %d | %s |\n",
-			cert.EndpointName,
-			cert.URL,
-			cert.ExpiryDate.Format("02 Jan 2006"),
-			cert.DaysToExpiry,
-			status,
+	subject := utils.Translate(lang, "ssl.subject", "count", strconv.Itoa(summary.Count()))
+	a.sendEmailAlert(alertID, a.config.EmailConfig.To, subject, builder.String())
+}
+
+// sendTeamsSSLExpirySummary posts the SSL expiry summary as markdown tables to Teams
+func (a *Alerter) sendTeamsSSLExpirySummary(alertID string, summary SSLSummary) {
+	// 🔹 Build MARKDOWN table for Teams
+	lang := a.language(structs.ChannelTeams)
+
+	var builder strings.Builder
+
+	builder.WriteString("📢 " + utils.Translate(lang, "ssl.title") + "\n\n")
+	if len(summary.Expiring) > 0 {
+		builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			utils.Translate(lang, "field.endpoint"),
+			utils.Translate(lang, "field.url"),
+			utils.Translate(lang, "field.expiry_date"),
+			utils.Translate(lang, "field.days_left"),
+			utils.Translate(lang, "field.severity"),
 		))
+		builder.WriteString("|---------|-----|------------|-----------|----------|\n")
+
+		for _, cert := range summary.Expiring {
+			status := utils.Translate(lang, "ssl.warning")
+			if sslSeverity(cert.DaysToExpiry) == "critical" {
+				status = utils.Translate(lang, "ssl.critical")
+			}
+
+			builder.WriteString(fmt.Sprintf(
+				"| %s | %s | %s | %d | %s |\n",
+				cert.EndpointName,
+				cert.URL,
+				cert.ExpiryDate.Format("02 Jan 2006"),
+				cert.DaysToExpiry,
+				status,
+			))
+		}
+		builder.WriteString("\n")
+	}
+	if len(summary.Expired) > 0 {
+		builder.WriteString("**" + utils.Translate(lang, "ssl.expired_title", "count", strconv.Itoa(len(summary.Expired))) + "**\n\n")
+		builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			utils.Translate(lang, "field.endpoint"),
+			utils.Translate(lang, "field.url"),
+			utils.Translate(lang, "field.expiry_date"),
+			utils.Translate(lang, "field.days_ago"),
+		))
+		builder.WriteString("|---------|-----|------------|----------|\n")
+		for _, cert := range summary.Expired {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n",
+				cert.EndpointName, cert.URL, cert.ExpiryDate.Format("02 Jan 2006"), -cert.DaysToExpiry))
+		}
+		builder.WriteString("\n")
+	}
+	if len(summary.Failed) > 0 {
+		builder.WriteString("**" + utils.Translate(lang, "ssl.failed_title", "count", strconv.Itoa(len(summary.Failed))) + "**\n\n")
+		builder.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+			utils.Translate(lang, "field.endpoint"),
+			utils.Translate(lang, "field.url"),
+			utils.Translate(lang, "field.error"),
+		))
+		builder.WriteString("|---------|-----|-------|\n")
+		for _, cert := range summary.Failed {
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				cert.EndpointName, cert.URL, strings.ReplaceAll(cert.Error, "|", "\\|")))
+		}
+		builder.WriteString("\n")
 	}
 
-	builder.WriteString("\n🔗 For more info visit: https://sitewatch.ezeebits.in\n")
+	builder.WriteString(utils.Translate(lang, "more_info", "url", "https://sitewatch.ezeebits.in") + "\n")
 
 	// 🔹 Send markdown text (NOT array JSON)
 	payload := map[string]interface{}{
@@ -393,20 +1077,6 @@ func (a *Alerter) SendSSLExpirySummary(expiringCerts []SSLExpiryInfo) {
 		return
 	}
 
-	resp, err := http.Post(
-		a.config.TeamsWebhookSSLExpiry,
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		logger.Errorf("Failed to send SSL expiry summary to Teams: %v", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		logger.Infof("SSL expiry summary sent to Teams (%d endpoints)", len(expiringCerts))
-	} else {
-		logger.Errorf("Teams webhook returned status %d", resp.StatusCode)
-	}
+	a.queueHTTP(alertID, structs.ChannelTeams, a.config.TeamsWebhookSSLExpiry, jsonData,
+		fmt.Sprintf("SSL expiry summary to Teams (%d endpoints)", summary.Count()))
 }
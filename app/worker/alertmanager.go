@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// alertmanagerVersion is the Alertmanager webhook payload version emitted
+const alertmanagerVersion = "4"
+
+// alertmanagerMessage mirrors the Prometheus Alertmanager webhook body
+type alertmanagerMessage struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	TruncatedAlerts   int                 `json:"truncatedAlerts"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []alertmanagerAlert `json:"alerts"`
+}
+
+// alertmanagerAlert is a single alert within an Alertmanager webhook
+type alertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// alertmanagerPayload builds an Alertmanager-compatible webhook for an endpoint alert
+func (a *Alerter) alertmanagerPayload(subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) alertmanagerMessage {
+	status := "firing"
+	if strings.HasSuffix(alertType, "recovery") {
+		status = "resolved"
+	}
+
+	// Recovery alerts are sent before LastStatusChange moves, so it still marks the incident start
+	startsAt := state.LastStatusChange
+	if startsAt.IsZero() {
+		startsAt = state.LastCheck
+	}
+	var endsAt time.Time
+	if status == "resolved" {
+		endsAt = time.Now()
+	}
+
+	labels := map[string]string{
+		"alertname": alertmanagerName(alertType),
+		"endpoint":  endpoint.Name,
+		"instance":  endpoint.URL,
+		"severity":  string(alertSeverity(alertType, endpoint, state)),
+		"priority":  string(endpoint.Priority),
+		"job":       "sitewatch",
+	}
+	if endpoint.ProjectID != "" {
+		labels["project"] = endpoint.ProjectID
+	}
+	if len(endpoint.Tags) > 0 {
+		labels["tags"] = strings.Join(endpoint.Tags, ",")
+	}
+	// Endpoint labels route alerts downstream; built-in labels take precedence
+	for key, value := range endpoint.Labels {
+		if _, exists := labels[key]; !exists {
+			labels[key] = value
+		}
+	}
+	for key, value := range a.config.CustomFields {
+		if _, exists := labels[key]; !exists {
+			labels[key] = value
+		}
+	}
+
+	annotations := map[string]string{
+		"summary":     subject,
+		"description": message,
+	}
+	if state.LastError != "" {
+		annotations["error"] = state.LastError
+	}
+	if endpoint.RunbookURL != "" {
+		annotations["runbook_url"] = endpoint.RunbookURL
+	}
+	if endpoint.Owner != "" {
+		labels["owner"] = endpoint.Owner
+	}
+
+	a.mu.RLock()
+	externalURL := a.baseURL
+	a.mu.RUnlock()
+
+	generatorURL := externalURL
+	if state.ID != "" && externalURL != "" {
+		generatorURL = a.historyURL(state.ID)
+	}
+
+	groupLabels := map[string]string{"alertname": labels["alertname"]}
+
+	return alertmanagerMessage{
+		Version:           alertmanagerVersion,
+		GroupKey:          "{}:{alertname=\"" + labels["alertname"] + "\", endpoint=\"" + endpoint.Name + "\"}",
+		Status:            status,
+		Receiver:          "sitewatch",
+		GroupLabels:       groupLabels,
+		CommonLabels:      labels,
+		CommonAnnotations: annotations,
+		ExternalURL:       externalURL,
+		Alerts: []alertmanagerAlert{{
+			Status:       status,
+			Labels:       labels,
+			Annotations:  annotations,
+			StartsAt:     startsAt,
+			EndsAt:       endsAt,
+			GeneratorURL: generatorURL,
+			Fingerprint:  alertmanagerFingerprint(alertType, endpoint),
+		}},
+	}
+}
+
+// alertmanagerName maps an alert type to a stable alertname label; recoveries resolve the matching failure
+func alertmanagerName(alertType string) string {
+	switch alertType {
+	case "failure", "recovery":
+		return "EndpointDown"
+	case "service_failure", "service_recovery":
+		return "ServiceDown"
+	case "self_failure", "self_recovery":
+		return "SiteWatchUnhealthy"
+	case "ssl_san_mismatch", "ssl_san_recovery":
+		return "CertificateHostnameMismatch"
+	case "tls_legacy", "tls_legacy_recovery":
+		return "LegacyTLSAccepted"
+	case "tls_downgrade":
+		return "TLSDowngrade"
+	case "sla_breach":
+		return "SLABreach"
+	case "sla_burn_rate":
+		return "ErrorBudgetBurn"
+	default:
+		return alertType
+	}
+}
+
+// alertmanagerFingerprint identifies an alert so firing and resolved notifications pair up
+func alertmanagerFingerprint(alertType string, endpoint structs.Endpoint) string {
+	sum := sha256.Sum256([]byte(alertmanagerName(alertType) + "\x00" + endpoint.ProjectID + "\x00" + endpoint.Name))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,282 @@
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// archiveSettingKey stores the start of the next hour to archive
+	archiveSettingKey = "archive_next_hour"
+	// archivePollInterval is how often the archiver looks for completed hours
+	archivePollInterval = 5 * time.Minute
+	// archiveSettle lets batched history writes of an hour land before it is archived
+	archiveSettle = time.Minute
+)
+
+// archiveLine is one check result in an archive file
+type archiveLine struct {
+	EndpointID     string              `json:"endpoint_id"`
+	Name           string              `json:"name,omitempty"`
+	URL            string              `json:"url,omitempty"`
+	ProjectID      string              `json:"project_id,omitempty"`
+	Timestamp      time.Time           `json:"timestamp"`
+	Status         string              `json:"status"`
+	StatusCode     int                 `json:"status_code,omitempty"`
+	ResponseTimeMs int64               `json:"response_time_ms"`
+	Error          string              `json:"error,omitempty"`
+	FailureKind    structs.FailureKind `json:"failure_kind,omitempty"`
+	RateLimited    bool                `json:"rate_limited,omitempty"`
+	Sampled        int                 `json:"sampled,omitempty"`
+}
+
+// ArchiveStatus reports how far check history has been archived
+type ArchiveStatus struct {
+	ArchivedUntil time.Time `json:"archived_until"`
+	LastObject    string    `json:"last_object,omitempty"`
+	LastRecords   int       `json:"last_records"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// Archiver writes each completed hour of check history as gzipped NDJSON to S3-compatible
+// object storage, one object per hour under a date-based key
+type Archiver struct {
+	config  structs.ArchiveConfig
+	db      *models.Database
+	client  *http.Client
+	monitor *Monitor
+
+	mu     sync.Mutex
+	status ArchiveStatus
+}
+
+// NewArchiver creates an archiver for the configured bucket
+func NewArchiver(config structs.ArchiveConfig, db *models.Database, monitor *Monitor) *Archiver {
+	return &Archiver{
+		config:  config,
+		db:      db,
+		client:  &http.Client{Timeout: 5 * time.Minute},
+		monitor: monitor,
+	}
+}
+
+// Run archives completed hours until ctx is cancelled, catching up on hours missed while
+// SiteWatch was down or the bucket unreachable
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(archivePollInterval)
+	defer ticker.Stop()
+
+	a.archivePending(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			a.archivePending(ctx, now)
+		}
+	}
+}
+
+// archivePending uploads every completed hour not archived yet, oldest first
+func (a *Archiver) archivePending(ctx context.Context, now time.Time) {
+	// Only the node writing history archives it
+	if !a.monitor.IsActive() {
+		return
+	}
+
+	// Start with the oldest history the database still keeps; older hours were cleaned up
+	oldest := now.AddDate(0, 0, -models.DataRetentionDays).UTC().Truncate(time.Hour)
+	var next time.Time
+	if found, err := a.db.GetSetting(archiveSettingKey, &next); err != nil || !found || next.Before(oldest) {
+		next = oldest
+	}
+
+	for !next.Add(time.Hour + archiveSettle).After(now) {
+		if ctx.Err() != nil {
+			return
+		}
+		key, count, err := a.archiveHour(ctx, next)
+		if err != nil {
+			a.mu.Lock()
+			if a.status.LastError == "" {
+				logger.Errorf("Archiving check history of %s failed, retrying: %v", next.Format("2006-01-02 15:04"), err)
+			}
+			a.status.LastError = err.Error()
+			a.mu.Unlock()
+			return
+		}
+
+		next = next.Add(time.Hour)
+		if err := a.db.SaveSetting(archiveSettingKey, next); err != nil {
+			logger.Errorf("Failed to save archive progress: %v", err)
+		}
+		a.mu.Lock()
+		if a.status.LastError != "" {
+			logger.Infof("Archiving check history recovered")
+		}
+		a.status.ArchivedUntil = next
+		a.status.LastError = ""
+		if count > 0 {
+			a.status.LastObject = key
+			a.status.LastRecords = count
+		}
+		a.mu.Unlock()
+		if count > 0 {
+			logger.Debugf("Archived %d check results to %s", count, key)
+		}
+	}
+}
+
+// archiveHour uploads the check results of the hour starting at hour, skipping empty hours
+func (a *Archiver) archiveHour(ctx context.Context, hour time.Time) (string, int, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	count := 0
+
+	var endpoint structs.Endpoint
+	lastID := ""
+	err := a.db.ForEachHealthRecord(hour, hour.Add(time.Hour), func(record *structs.HealthCheckRecord) error {
+		if record.EndpointID != lastID {
+			lastID = record.EndpointID
+			endpoint = structs.Endpoint{}
+			// Endpoints purged since keep only their ID
+			if state, ok := a.monitor.GetEndpointState(record.EndpointID); ok {
+				endpoint = state.Endpoint
+			}
+		}
+		count++
+		return enc.Encode(archiveLine{
+			EndpointID:     record.EndpointID,
+			Name:           endpoint.Name,
+			URL:            endpoint.URL,
+			ProjectID:      endpoint.ProjectID,
+			Timestamp:      record.Timestamp.UTC(),
+			Status:         record.Status,
+			StatusCode:     record.StatusCode,
+			ResponseTimeMs: record.ResponseTime.Milliseconds(),
+			Error:          record.Error,
+			FailureKind:    record.FailureKind,
+			RateLimited:    record.RateLimited,
+			Sampled:        record.Sampled,
+		})
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("reading history: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", 0, err
+	}
+	if count == 0 {
+		return "", 0, nil
+	}
+
+	key := a.objectKey(hour)
+	return key, count, a.put(ctx, key, buf.Bytes())
+}
+
+// objectKey names an hour's object by date, so lifecycle rules and queries can select by prefix
+func (a *Archiver) objectKey(hour time.Time) string {
+	hour = hour.UTC()
+	return fmt.Sprintf("%s/%s/history-%s.ndjson.gz", a.config.Prefix, hour.Format("2006/01/02"), hour.Format("20060102T15Z"))
+}
+
+// put uploads an object with a SigV4-signed PUT request
+func (a *Archiver) put(ctx context.Context, key string, body []byte) error {
+	endpoint, err := url.Parse(a.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	if a.config.PathStyle {
+		endpoint.Path += "/" + a.config.Bucket + "/" + key
+	} else {
+		endpoint.Host = a.config.Bucket + "." + endpoint.Host
+		endpoint.Path += "/" + key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	signV4(req, body, a.config.AccessKeyID, a.config.SecretAccessKey, a.config.Region, time.Now())
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("storage returned status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// Status returns how far check history has been archived
+func (a *Archiver) Status() ArchiveStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
+
+// signV4 signs a request for the S3 API with AWS Signature Version 4, signing the host,
+// date and payload hash
+func signV4(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	stamp := now.Format("20060102T150405Z")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonical := req.Method + "\n" +
+		req.URL.EscapedPath() + "\n" +
+		req.URL.RawQuery + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + stamp + "\n\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		payloadHash
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="+signature)
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// defaultBackoffAfter is how long an endpoint must be down before its interval stretches
+	defaultBackoffAfter = 1 * time.Hour
+	// defaultBackoffMaxInterval caps the stretched check interval
+	defaultBackoffMaxInterval = 30 * time.Minute
+)
+
+// nextBackoffInterval returns the stretched interval for a persistently failing endpoint,
+// or zero if the endpoint should be checked at its normal interval.
+// Caller must hold the state lock.
+func nextBackoffInterval(state *structs.EndpointState) time.Duration {
+	endpoint := state.Endpoint
+	if !endpoint.BackoffEnabled || state.Status != structs.StatusUnhealthy || state.LastStatusChange.IsZero() {
+		return 0
+	}
+
+	after := endpoint.BackoffAfter.Duration
+	if after == 0 {
+		after = defaultBackoffAfter
+	}
+	if time.Since(state.LastStatusChange) < after {
+		return 0
+	}
+
+	maxInterval := endpoint.BackoffMaxInterval.Duration
+	if maxInterval == 0 {
+		maxInterval = defaultBackoffMaxInterval
+	}
+
+	interval := state.BackoffInterval
+	if interval == 0 {
+		interval = state.CheckInterval
+	}
+	interval *= 2
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	if interval < state.CheckInterval {
+		interval = state.CheckInterval
+	}
+	return interval
+}
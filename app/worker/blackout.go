@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// ReloadBlackouts reloads blackout calendars from the database and updates every alerter
+func (m *Monitor) ReloadBlackouts() {
+	calendars, err := m.db.GetAllBlackoutCalendars()
+	if err != nil {
+		logger.Errorf("Failed to load blackout calendars: %v", err)
+		return
+	}
+
+	m.projectMu.Lock()
+	m.blackouts = calendars
+	m.projectMu.Unlock()
+
+	for _, alerter := range m.allAlerters() {
+		alerter.SetBlackouts(calendars)
+	}
+
+	logger.Infof("Loaded %d blackout calendars", len(calendars))
+}
+
+// currentBlackouts returns the loaded blackout calendars
+func (m *Monitor) currentBlackouts() []*structs.BlackoutCalendar {
+	m.projectMu.RLock()
+	defer m.projectMu.RUnlock()
+	return m.blackouts
+}
+
+// activeBlackout returns the strictest blackout action in effect at now and the period causing it
+func activeBlackout(calendars []*structs.BlackoutCalendar, now time.Time) (string, structs.BlackoutPeriod, bool) {
+	var action string
+	var active structs.BlackoutPeriod
+	for _, calendar := range calendars {
+		period, ok := calendar.ActivePeriod(now)
+		if !ok {
+			continue
+		}
+		if calendar.Action == structs.BlackoutSuppress {
+			return structs.BlackoutSuppress, period, true
+		}
+		action, active = calendar.Action, period
+	}
+	return action, active, action != ""
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// maxCheckBody caps how much of a response body a check reads
+const maxCheckBody = 1 << 20
+
+// bodyRegexes caches body_regex patterns by source, so each is compiled once
+var bodyRegexes sync.Map
+
+// responseBody reads a check's response body on first use, so body assertions and the
+// success expression share one read
+type responseBody struct {
+	resp *http.Response
+	data []byte
+	err  error
+	done bool
+}
+
+// read returns up to maxCheckBody bytes of the response body
+func (b *responseBody) read() ([]byte, error) {
+	if !b.done {
+		b.done = true
+		b.data, b.err = io.ReadAll(io.LimitReader(b.resp.Body, maxCheckBody))
+		if b.err != nil {
+			b.err = fmt.Errorf("failed to read response body: %v", b.err)
+		}
+	}
+	return b.data, b.err
+}
+
+// hasBodyAssertions reports whether an endpoint checks the content of its response body
+func hasBodyAssertions(endpoint structs.Endpoint) bool {
+	return endpoint.BodyContains != "" || endpoint.BodyNotContains != "" || endpoint.BodyRegex != ""
+}
+
+// checkBodyAssertions verifies body_contains, body_not_contains and body_regex against the
+// response body, returning an error describing the first that fails
+func checkBodyAssertions(endpoint structs.Endpoint, body *responseBody) error {
+	if !hasBodyAssertions(endpoint) {
+		return nil
+	}
+	data, err := body.read()
+	if err != nil {
+		return err
+	}
+
+	if endpoint.BodyContains != "" && !bytes.Contains(data, []byte(endpoint.BodyContains)) {
+		return fmt.Errorf("response body does not contain %q", endpoint.BodyContains)
+	}
+	if endpoint.BodyNotContains != "" && bytes.Contains(data, []byte(endpoint.BodyNotContains)) {
+		return fmt.Errorf("response body contains %q", endpoint.BodyNotContains)
+	}
+	if endpoint.BodyRegex != "" {
+		var re *regexp.Regexp
+		if cached, ok := bodyRegexes.Load(endpoint.BodyRegex); ok {
+			re = cached.(*regexp.Regexp)
+		} else {
+			compiled, err := regexp.Compile(endpoint.BodyRegex)
+			if err != nil {
+				return fmt.Errorf("invalid body_regex: %v", err)
+			}
+			bodyRegexes.Store(endpoint.BodyRegex, compiled)
+			re = compiled
+		}
+		if !re.Match(data) {
+			return fmt.Errorf("response body does not match %q", endpoint.BodyRegex)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/security"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// browserWidth and browserHeight are the viewport pages are rendered and captured at
+	browserWidth  = 1280
+	browserHeight = 800
+	// browserLoadShare is the part of the timeout given to loading the page and waiting
+	// for the selector; the rest is left for the screenshot
+	browserLoadShare = 0.8
+)
+
+// Browser runs browser checks in tabs of one shared headless Chrome, started on first use
+// and restarted if it stops responding
+type Browser struct {
+	config structs.BrowserConfig
+
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewBrowser creates a browser; Chrome is not started until the first check
+func NewBrowser(config structs.BrowserConfig) *Browser {
+	return &Browser{config: config}
+}
+
+// tab opens a new tab, starting Chrome if it is not running
+func (b *Browser) tab() (context.Context, context.CancelFunc, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A browser that crashed fails to open tabs; start a fresh one and try once more
+	for attempt := 0; attempt < 2; attempt++ {
+		if b.ctx == nil {
+			if err := b.start(); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		ctx, cancel := chromedp.NewContext(b.ctx)
+		if err := chromedp.Run(ctx); err == nil {
+			return ctx, cancel, nil
+		} else if attempt == 0 {
+			logger.Errorf("Headless Chrome stopped responding, restarting: %v", err)
+		}
+		cancel()
+		b.stop()
+	}
+	return nil, nil, fmt.Errorf("failed to open browser tab")
+}
+
+// start launches Chrome. Caller must hold b.mu.
+func (b *Browser) start() error {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.WindowSize(browserWidth, browserHeight))
+	if b.config.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(b.config.ExecPath))
+	}
+	if b.config.NoSandbox {
+		opts = append(opts, chromedp.NoSandbox)
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return fmt.Errorf("failed to start headless Chrome: %w", err)
+	}
+
+	b.ctx = ctx
+	b.cancel = func() {
+		cancel()
+		allocCancel()
+	}
+	logger.Infof("Started headless Chrome for browser checks")
+	return nil
+}
+
+// stop shuts Chrome down. Caller must hold b.mu.
+func (b *Browser) stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.ctx, b.cancel = nil, nil
+}
+
+// Close shuts Chrome down if it is running
+func (b *Browser) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stop()
+}
+
+// checkBrowser runs the endpoint's journey in headless Chrome, or without one loads the page and
+// waits for its wait_selector (or the document body) to become visible. A screenshot is captured
+// at the end, also when a step failed, and saved with the check result and the step timings.
+func (m *Monitor) checkBrowser(checkCtx context.Context, state *MonitorState, endpoint structs.Endpoint, timeout time.Duration) {
+	start := time.Now()
+
+	tabCtx, closeTab, err := m.browser.tab()
+	if err != nil {
+		m.handleCheckFailure(state, structs.FailureNetwork, fmt.Sprintf("browser unavailable: %v", err), 0)
+		return
+	}
+	defer closeTab()
+
+	ctx, cancel := context.WithTimeout(tabCtx, timeout)
+	defer cancel()
+	// Stop the page load when the check is abandoned or the monitor shuts down
+	defer context.AfterFunc(checkCtx, cancel)()
+
+	loadCtx, loadCancel := context.WithTimeout(ctx, time.Duration(float64(timeout)*browserLoadShare))
+	defer loadCancel()
+
+	// Global defaults first, so per-endpoint headers take precedence, as in HTTP checks
+	headers := network.Headers{}
+	for key, value := range m.config.DefaultHeaders {
+		headers[key] = value
+	}
+	for key, value := range endpoint.Headers {
+		headers[key] = value
+	}
+	setup := []chromedp.Action{network.SetExtraHTTPHeaders(headers)}
+	if m.config.UserAgent != "" {
+		setup = append(setup, emulation.SetUserAgentOverride(m.config.UserAgent))
+	}
+	if endpoint.InsecureSkipVerify {
+		setup = append(setup, security.SetIgnoreCertificateErrors(true))
+	}
+	if err := chromedp.Run(loadCtx, setup...); err != nil {
+		m.handleCheckFailure(state, structs.FailureNetwork, fmt.Sprintf("browser setup failed: %v", err), time.Since(start))
+		return
+	}
+
+	result := runJourney(loadCtx, endpoint, journeySteps(endpoint))
+	responseTime := time.Since(start)
+
+	// The screenshot is most useful when the page failed to render, so take it either way
+	var screenshot []byte
+	if err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&screenshot)); err != nil {
+		logger.Errorf("[%s] Failed to capture screenshot: %v", endpoint.Name, err)
+		screenshot = nil
+	}
+	state.mu.Lock()
+	state.screenshot = screenshot
+	state.steps = result.steps
+	state.mu.Unlock()
+
+	switch {
+	case result.rateLimited:
+		m.handleRateLimited(state, result.retryAfter, responseTime)
+	case result.err != nil:
+		m.handleCheckFailure(state, result.kind, result.err.Error(), responseTime)
+	default:
+		m.handleCheckSuccess(state, responseTime)
+	}
+}
+
+// journeyResult is the outcome of running a journey
+type journeyResult struct {
+	steps       []structs.StepResult
+	rateLimited bool
+	retryAfter  time.Duration
+	kind        structs.FailureKind
+	err         error
+}
+
+// journeySteps returns the steps a browser check runs: the endpoint's journey, starting with
+// loading the endpoint URL unless it navigates first, or a page load and wait_selector
+func journeySteps(endpoint structs.Endpoint) []structs.JourneyStep {
+	load := structs.JourneyStep{Action: structs.StepNavigate, Name: "load"}
+	if len(endpoint.Journey) == 0 {
+		selector := endpoint.WaitSelector
+		if selector == "" {
+			selector = "body"
+		}
+		return []structs.JourneyStep{load, {Action: structs.StepWait, Selector: selector}}
+	}
+	if endpoint.Journey[0].Action == structs.StepNavigate {
+		return endpoint.Journey
+	}
+	return append([]structs.JourneyStep{load}, endpoint.Journey...)
+}
+
+// runJourney runs steps in order until one fails. The first page load must answer with the
+// endpoint's expected status; later ones fail on an error status.
+func runJourney(ctx context.Context, endpoint structs.Endpoint, steps []structs.JourneyStep) journeyResult {
+	var result journeyResult
+	loaded := false
+
+	for i, step := range steps {
+		began := time.Now()
+		kind := structs.FailureApplication
+		var err error
+
+		switch step.Action {
+		case structs.StepNavigate:
+			var resp *network.Response
+			resp, err = chromedp.RunResponse(ctx, chromedp.Navigate(resolveJourneyURL(endpoint.URL, step.URL)))
+			if err == nil && resp == nil {
+				err = fmt.Errorf("no response for the page document")
+			}
+			if err != nil {
+				kind = structs.FailureNetwork
+				break
+			}
+
+			status := int(resp.Status)
+			if !loaded {
+				loaded = true
+				if status == http.StatusTooManyRequests && endpoint.ExpectedStatus != http.StatusTooManyRequests {
+					result.steps = append(result.steps, structs.StepResult{Step: step.Label(), Duration: time.Since(began)})
+					result.rateLimited = true
+					result.retryAfter = parseRetryAfter(headerValue(resp.Headers, "Retry-After"), time.Now())
+					return result
+				}
+				if status != endpoint.ExpectedStatus {
+					err = fmt.Errorf("unexpected status code: got %d, expected %d", status, endpoint.ExpectedStatus)
+				}
+			} else if status >= http.StatusBadRequest {
+				err = fmt.Errorf("page returned status code %d", status)
+			}
+		case structs.StepClick:
+			err = chromedp.Run(ctx, chromedp.Click(step.Selector, chromedp.ByQuery))
+		case structs.StepType:
+			err = chromedp.Run(ctx, chromedp.SendKeys(step.Selector, step.Value, chromedp.ByQuery))
+		case structs.StepWait:
+			err = chromedp.Run(ctx, chromedp.WaitVisible(step.Selector, chromedp.ByQuery))
+		case structs.StepAssertText:
+			selector := step.Selector
+			if selector == "" {
+				selector = "body"
+			}
+			var text string
+			err = chromedp.Run(ctx, chromedp.Text(selector, &text, chromedp.ByQuery))
+			if err == nil && !strings.Contains(text, step.Text) {
+				err = fmt.Errorf("text %q not found", step.Text)
+			}
+		default:
+			err = fmt.Errorf("unknown action %q", step.Action)
+		}
+
+		stepResult := structs.StepResult{Step: step.Label(), Duration: time.Since(began)}
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("timed out after %v", stepResult.Duration.Round(time.Millisecond))
+			}
+			stepResult.Error = err.Error()
+			result.steps = append(result.steps, stepResult)
+			result.kind = kind
+			result.err = fmt.Errorf("step %d (%s): %w", i+1, step.Label(), err)
+			return result
+		}
+		result.steps = append(result.steps, stepResult)
+	}
+	return result
+}
+
+// resolveJourneyURL resolves a navigate step's URL against the endpoint URL; an empty one is the endpoint URL
+func resolveJourneyURL(base, ref string) string {
+	if ref == "" {
+		return base
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// headerValue looks up a response header reported by Chrome, whose names keep the server's case
+func headerValue(headers network.Headers, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"encoding/json"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// statusCallbackEvent names the event posted to callback URLs
+const statusCallbackEvent = "endpoint.status_changed"
+
+// statusCallback is the body posted to an endpoint's callback_url when its status changes
+type statusCallback struct {
+	Event      string `json:"event"`
+	CallbackID string `json:"callback_id"`
+	structs.StatusEvent
+	ResponseTimeMs      int64             `json:"response_time_ms"`
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	AlertsSuppressed    bool              `json:"alerts_suppressed"`
+	Tags                []string          `json:"tags,omitempty"`
+	Labels              map[string]string `json:"labels,omitempty"`
+}
+
+// sendStatusCallback queues an endpoint's status change for its own callback URL, independent
+// of the alert channels, so teams can automate per service. Caller must hold the state lock.
+func (m *Monitor) sendStatusCallback(state *MonitorState, previous structs.HealthStatus) {
+	callbackURL := state.Endpoint.CallbackURL
+	if callbackURL == "" {
+		return
+	}
+
+	callbackID := newAlertID()
+	payload, err := json.Marshal(statusCallback{
+		Event:               statusCallbackEvent,
+		CallbackID:          callbackID,
+		StatusEvent:         statusEvent(state, previous),
+		ResponseTimeMs:      state.ResponseTime.Milliseconds(),
+		ConsecutiveFailures: state.ConsecutiveFailures,
+		AlertsSuppressed:    state.AlertsSuppressed,
+		Tags:                state.Endpoint.Tags,
+		Labels:              state.Endpoint.Labels,
+	})
+	if err != nil {
+		logger.Errorf("[%s] Failed to build status callback: %v", state.Endpoint.Name, err)
+		return
+	}
+
+	message := &structs.OutboxMessage{
+		AlertID:     callbackID,
+		ProjectID:   state.Endpoint.ProjectID,
+		Channel:     structs.ChannelCallback,
+		Description: "Status callback for " + state.Endpoint.Name,
+		URL:         callbackURL,
+		Body:        string(payload),
+	}
+	// Queued off the check path; shutdown drains it before the outbox is flushed
+	m.alerterFor(state.Endpoint.ProjectID).async(func() { m.outbox.Enqueue(message) })
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// CheckResult is the outcome of an on-demand check. Checked is false when no fresh health
+// check could be run, in which case Passed is false and Error says why.
+type CheckResult struct {
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	URL            string               `json:"url"`
+	Checked        bool                 `json:"checked"`
+	Passed         bool                 `json:"passed"`
+	Status         structs.HealthStatus `json:"status"`
+	Error          string               `json:"error,omitempty"`
+	ResponseTimeMs float64              `json:"response_time_ms"`
+	CheckedAt      time.Time            `json:"checked_at"`
+}
+
+// CheckNow checks the given endpoints immediately and waits for the results. A check that
+// is already running is waited for first, since its result may predate the request.
+func (m *Monitor) CheckNow(ids []string) []CheckResult {
+	var states []*MonitorState
+	m.mu.RLock()
+	for _, id := range ids {
+		if state, ok := m.states[id]; ok {
+			states = append(states, state)
+		}
+	}
+	m.mu.RUnlock()
+
+	results := make([]CheckResult, len(states))
+	var wg sync.WaitGroup
+	for i, state := range states {
+		wg.Add(1)
+		go func(i int, state *MonitorState) {
+			defer wg.Done()
+			results[i] = m.checkNow(state)
+		}(i, state)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// checkNow runs a fresh health check for one endpoint
+func (m *Monitor) checkNow(state *MonitorState) CheckResult {
+	requested := time.Now()
+
+	state.mu.RLock()
+	checksHealth := state.ChecksHealth()
+	state.mu.RUnlock()
+
+	if checksHealth {
+		m.waitForCheck(state.ID)
+		m.checkEndpoint(state)
+		// A scheduled check that started after the request took the slot; use its result
+		m.waitForCheck(state.ID)
+	}
+
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	result := CheckResult{
+		ID:     state.ID,
+		Name:   state.Endpoint.Name,
+		URL:    state.Endpoint.URL,
+		Status: state.Status,
+	}
+	switch {
+	case !checksHealth:
+		result.Error = "not checked: health checks are paused or disabled"
+	case state.LastCheck.Before(requested):
+		result.Error = "not checked: no health check completed"
+	default:
+		// A check passes on its own merit, before failure/success thresholds apply
+		result.Checked = true
+		result.Passed = state.ConsecutiveFailures == 0
+		result.Error = state.LastError
+		result.ResponseTimeMs = float64(state.ResponseTime.Microseconds()) / 1000.0
+		result.CheckedAt = state.LastCheck
+	}
+	return result
+}
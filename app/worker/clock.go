@@ -0,0 +1,37 @@
+package worker
+
+import "time"
+
+// clockRecheck bounds how long a scheduler sleeps before re-reading the wall clock, so a
+// suspend/resume or clock step is noticed within a minute instead of after the whole wait
+const clockRecheck = time.Minute
+
+// monotonic anchors a wall-clock time to the monotonic clock, so waiting for it counts elapsed
+// time and is not thrown off by a later NTP step or manual clock change
+func monotonic(t time.Time) time.Time {
+	now := time.Now()
+	return now.Add(t.Sub(now))
+}
+
+// sleepUntil waits until the wall clock reaches t, returning false if the monitor stops first
+func (m *Monitor) sleepUntil(t time.Time) bool {
+	// Timers count monotonic time, which stops during suspend and ignores clock steps
+	t = t.Round(0)
+	for {
+		wait := time.Until(t)
+		if wait <= 0 {
+			return true
+		}
+		if wait > clockRecheck {
+			wait = clockRecheck
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-m.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// applySchedule parses the endpoint's cron schedule and plans its next check from it.
+// Endpoints without a valid schedule run at their fixed interval. Caller must hold the state lock
+// or own the state exclusively.
+func (m *Monitor) applySchedule(state *MonitorState) {
+	state.cron = nil
+	state.resetCheckBaseline()
+	if state.Endpoint.Schedule == "" {
+		return
+	}
+
+	cron, err := utils.ParseCron(state.Endpoint.Schedule)
+	if err != nil {
+		logger.Errorf("[%s] Invalid schedule '%s' (%v), using check interval", state.Endpoint.Name, state.Endpoint.Schedule, err)
+		return
+	}
+	state.cron = cron
+	state.NextCheck = m.nextCheckAfter(state, time.Now())
+}
+
+// nextCheckAfter returns when the endpoint should next be checked after t, following its
+// cron schedule in the configured timezone or else its check interval. Intervals count elapsed
+// time on the monotonic clock, schedules follow the wall clock. Caller must hold the state lock.
+func (m *Monitor) nextCheckAfter(state *MonitorState, t time.Time) time.Time {
+	if state.cron == nil {
+		return monotonic(t.Add(state.CheckInterval))
+	}
+	next := state.cron.Next(t.In(m.loc))
+	if next.IsZero() {
+		// The schedule never fires again, park the endpoint
+		return t.AddDate(100, 0, 0)
+	}
+	return next
+}
+
+// nextCheckNotBefore returns the earliest check time at or after t, snapped to the next
+// scheduled run for cron-scheduled endpoints. Caller must hold the state lock.
+func (m *Monitor) nextCheckNotBefore(state *MonitorState, t time.Time) time.Time {
+	if state.cron == nil {
+		return monotonic(t)
+	}
+	return m.nextCheckAfter(state, t.Add(-time.Nanosecond))
+}
@@ -0,0 +1,226 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+const (
+	// maxDigestIncidents caps the incidents listed in a digest
+	maxDigestIncidents = 20
+	// maxDigestRegressions caps the latency regressions listed in a digest
+	maxDigestRegressions = 5
+	// digestRegressionRatio is the p95 increase over the previous period that counts as a regression
+	digestRegressionRatio = 1.2
+)
+
+// latencyRegression compares an endpoint's p95 latency with the previous period
+type latencyRegression struct {
+	name     string
+	previous float64
+	current  float64
+}
+
+// digestIncident is an incident attributed to an endpoint
+type digestIncident struct {
+	name     string
+	incident models.Incident
+}
+
+// startDigestSchedulers runs one scheduler per configured digest
+func (m *Monitor) startDigestSchedulers() {
+	for _, digest := range m.config.Digests {
+		digest := digest
+		schedule := parseSummarySchedule("digest "+digest.Name, digest.Schedule, digest.Time, digest.Weekday, digestLocation(digest))
+
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.runSchedule(schedule, func() {
+				m.sendDigest(digest, digestPeriod(schedule))
+			})
+		}()
+	}
+}
+
+// digestPeriod returns how far back a digest looks: a week for weekly digests, otherwise a day
+func digestPeriod(schedule *summarySchedule) time.Duration {
+	if schedule.weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// SendDigestNow sends the named digest immediately
+func (m *Monitor) SendDigestNow(name string) error {
+	for _, digest := range m.config.Digests {
+		if digest.Name == name {
+			schedule := parseSummarySchedule("digest "+digest.Name, digest.Schedule, digest.Time, digest.Weekday, digestLocation(digest))
+			m.sendDigest(digest, digestPeriod(schedule))
+			return nil
+		}
+	}
+	return fmt.Errorf("digest not found: %s", name)
+}
+
+// digestLanguage returns the language a digest is written in
+func (m *Monitor) digestLanguage(digest structs.DigestConfig) string {
+	if digest.Language != "" {
+		return digest.Language
+	}
+	return m.alerter.language(structs.ChannelEmail)
+}
+
+// sendDigest builds and emails a digest to its recipient group
+func (m *Monitor) sendDigest(digest structs.DigestConfig, period time.Duration) {
+	subject, body := m.buildDigest(digest, period, time.Now().In(digestLocation(digest)))
+	logger.Infof("Sending digest %s to %d recipients", digest.Name, len(digest.Recipients))
+	m.alerter.sendEmailAlert(newAlertID(), digest.Recipients, subject, body)
+}
+
+// digestLocation returns the timezone a digest is scheduled and rendered in
+func digestLocation(digest structs.DigestConfig) *time.Location {
+	loc, err := utils.LoadTimezone(digest.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// buildDigest summarizes down endpoints, incidents, latency regressions and
+// upcoming certificate expiries for the endpoints matching the digest filter,
+// rendered for the period ending at now in now's timezone and the digest language
+func (m *Monitor) buildDigest(digest structs.DigestConfig, period time.Duration, now time.Time) (string, string) {
+	var states []*structs.EndpointState
+	for _, state := range m.GetStatus() {
+		if state.Enabled && digest.Filter.Matches(state.Endpoint) {
+			states = append(states, state)
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Endpoint.Name < states[j].Endpoint.Name
+	})
+
+	var down []*structs.EndpointState
+	var incidents []digestIncident
+	var regressions []latencyRegression
+	for _, state := range states {
+		if state.Status == structs.StatusUnhealthy {
+			down = append(down, state)
+		}
+
+		// Only the two periods compared are read
+		records, err := m.db.GetHealthHistoryRange(state.ID, now.Add(-2*period), now)
+		if err != nil {
+			logger.Errorf("Digest %s: failed to load history for %s: %v", digest.Name, state.ID, err)
+			continue
+		}
+
+		current := models.RecordsSince(records, now.Add(-period))
+		for _, incident := range models.DetectIncidents(current) {
+			incidents = append(incidents, digestIncident{name: state.Endpoint.Name, incident: incident})
+		}
+
+		currentStats := models.ComputeLatencyStats(current)
+		previousStats := models.ComputeLatencyStats(models.RecordsBetween(records, now.Add(-2*period), now.Add(-period)))
+		if currentStats.Count > 0 && previousStats.Count > 0 && previousStats.P95 > 0 &&
+			currentStats.P95 >= previousStats.P95*digestRegressionRatio {
+			regressions = append(regressions, latencyRegression{
+				name:     state.Endpoint.Name,
+				previous: previousStats.P95,
+				current:  currentStats.P95,
+			})
+		}
+	}
+
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].incident.Start.After(incidents[j].incident.Start)
+	})
+	sort.Slice(regressions, func(i, j int) bool {
+		return regressions[i].current/regressions[i].previous > regressions[j].current/regressions[j].previous
+	})
+
+	included := make(map[string]bool, len(states))
+	for _, state := range states {
+		included[state.ID] = true
+	}
+
+	var certs []SSLExpiryInfo
+	for _, cert := range m.getExpiringCertificates() {
+		if included[cert.EndpointID] {
+			certs = append(certs, cert)
+		}
+	}
+
+	lang := m.digestLanguage(digest)
+	none := "  " + utils.Translate(lang, "none") + "\r\n"
+
+	var b strings.Builder
+	b.WriteString(utils.Translate(lang, "digest.title", "name", digest.Name) + "\r\n")
+	b.WriteString(utils.Translate(lang, "digest.period",
+		"from", now.Add(-period).Format("02 Jan 2006 15:04"),
+		"to", now.Format("02 Jan 2006 15:04 MST"),
+	) + "\r\n")
+	b.WriteString(utils.Translate(lang, "digest.endpoints", "total", strconv.Itoa(len(states)), "down", strconv.Itoa(len(down))) + "\r\n\r\n")
+
+	b.WriteString(utils.Translate(lang, "digest.down_now", "count", strconv.Itoa(len(down))) + "\r\n")
+	downFor := utils.Translate(lang, "digest.down_for")
+	for _, state := range down {
+		b.WriteString(fmt.Sprintf("  %-30s %s %-10s %s\r\n",
+			state.Endpoint.Name, downFor, now.Sub(state.LastStatusChange).Round(time.Minute), state.LastError))
+	}
+	if len(down) == 0 {
+		b.WriteString(none)
+	}
+
+	b.WriteString("\r\n" + utils.Translate(lang, "digest.incidents", "count", strconv.Itoa(len(incidents))) + "\r\n")
+	for i, item := range incidents {
+		if i == maxDigestIncidents {
+			b.WriteString("  " + utils.Translate(lang, "digest.more", "count", strconv.Itoa(len(incidents)-maxDigestIncidents)) + "\r\n")
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %-30s %s  %-10s %s\r\n",
+			item.name, item.incident.Start.In(now.Location()).Format("02 Jan 15:04"), item.incident.Duration, item.incident.Error))
+	}
+	if len(incidents) == 0 {
+		b.WriteString(none)
+	}
+
+	b.WriteString("\r\n" + utils.Translate(lang, "digest.regressions") + "\r\n")
+	for i, regression := range regressions {
+		if i == maxDigestRegressions {
+			break
+		}
+		b.WriteString(fmt.Sprintf("  %-30s %8.1fms -> %8.1fms (+%.0f%%)\r\n",
+			regression.name, regression.previous, regression.current, (regression.current/regression.previous-1)*100))
+	}
+	if len(regressions) == 0 {
+		b.WriteString(none)
+	}
+
+	b.WriteString("\r\n" + utils.Translate(lang, "digest.certificates", "count", strconv.Itoa(len(certs))) + "\r\n")
+	for _, cert := range certs {
+		b.WriteString(fmt.Sprintf("  %-30s %s %s\r\n", cert.EndpointName, cert.ExpiryDate.In(now.Location()).Format("02 Jan 2006"),
+			utils.Translate(lang, "digest.days", "days", strconv.Itoa(cert.DaysToExpiry))))
+	}
+	if len(certs) == 0 {
+		b.WriteString(none)
+	}
+
+	b.WriteString("\r\n" + utils.Translate(lang, "digest.dashboard", "url", m.config.PublicURL) + "\r\n")
+
+	subject := utils.Translate(lang, "digest.subject",
+		"name", digest.Name,
+		"down", strconv.Itoa(len(down)),
+		"incidents", strconv.Itoa(len(incidents)),
+	)
+	return subject, b.String()
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// batchSubjectNames is how many endpoint names a combined email's subject lists
+const batchSubjectNames = 3
+
+// emailBatch collects the alerts for one recipient list and language until its window closes
+type emailBatch struct {
+	alertID    string
+	lang       string
+	recipients []string
+	alerts     []batchedAlert
+}
+
+// batchedAlert is one alert waiting in an email batch
+type batchedAlert struct {
+	name    string
+	url     string
+	status  string
+	subject string
+	message string
+	at      time.Time
+}
+
+// queueEmail sends an alert email, or holds it for batch_window so alerts fired close
+// together reach the same recipients as one message
+func (a *Alerter) queueEmail(alertID, lang string, recipients []string, subject, message string, endpoint structs.Endpoint, state *structs.EndpointState) {
+	window := a.config.EmailConfig.BatchWindow.Duration
+	if window <= 0 {
+		a.sendEmailAlert(alertID, recipients, subject, message)
+		return
+	}
+
+	sorted := append([]string(nil), recipients...)
+	sort.Strings(sorted)
+	key := lang + "|" + strings.Join(sorted, ",")
+
+	alert := batchedAlert{
+		name:    endpoint.Name,
+		url:     endpoint.URL,
+		status:  string(state.Status),
+		subject: subject,
+		message: message,
+		at:      time.Now(),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if batch, ok := a.batches[key]; ok {
+		batch.alerts = append(batch.alerts, alert)
+		return
+	}
+	if a.batches == nil {
+		a.batches = make(map[string]*emailBatch)
+	}
+	a.batches[key] = &emailBatch{
+		alertID:    alertID,
+		lang:       lang,
+		recipients: recipients,
+		alerts:     []batchedAlert{alert},
+	}
+	time.AfterFunc(window, func() { a.flushEmailBatch(key) })
+}
+
+// flushEmailBatch sends a batch whose window has closed
+func (a *Alerter) flushEmailBatch(key string) {
+	a.mu.Lock()
+	batch, ok := a.batches[key]
+	delete(a.batches, key)
+	a.mu.Unlock()
+
+	if ok {
+		a.sendEmailBatch(batch)
+	}
+}
+
+// FlushEmailBatches sends every held email batch at once, for shutdown
+func (a *Alerter) FlushEmailBatches() {
+	a.mu.Lock()
+	batches := a.batches
+	a.batches = nil
+	a.mu.Unlock()
+
+	for _, batch := range batches {
+		a.sendEmailBatch(batch)
+	}
+}
+
+// sendEmailBatch sends a lone alert unchanged and several as one message with a table of endpoints
+func (a *Alerter) sendEmailBatch(batch *emailBatch) {
+	if len(batch.alerts) == 1 {
+		alert := batch.alerts[0]
+		a.sendEmailAlert(batch.alertID, batch.recipients, alert.subject, alert.message)
+		return
+	}
+
+	lang := batch.lang
+	loc := a.location()
+	count := strconv.Itoa(len(batch.alerts))
+
+	// Alerts are queued from separate goroutines, so put them back in the order they fired
+	sort.SliceStable(batch.alerts, func(i, j int) bool { return batch.alerts[i].at.Before(batch.alerts[j].at) })
+	first, last := batch.alerts[0].at, batch.alerts[len(batch.alerts)-1].at
+
+	var names []string
+	for _, alert := range batch.alerts {
+		if !containsString(names, alert.name) {
+			names = append(names, alert.name)
+		}
+	}
+	if len(names) > batchSubjectNames {
+		names = append(names[:batchSubjectNames], "…")
+	}
+
+	var builder strings.Builder
+	builder.WriteString(utils.Translate(lang, "email.batch.title", "count", count,
+		"from", first.In(loc).Format("15:04:05"), "to", last.In(loc).Format("15:04:05 MST")) + "\r\n\r\n")
+	builder.WriteString(fmt.Sprintf("%-30s %-12s %-50s %s\r\n",
+		utils.Translate(lang, "field.endpoint"),
+		utils.Translate(lang, "field.status"),
+		utils.Translate(lang, "field.alert"),
+		utils.Translate(lang, "field.url"),
+	))
+	for _, alert := range batch.alerts {
+		builder.WriteString(fmt.Sprintf("%-30s %-12s %-50s %s\r\n", alert.name, alert.status, alert.subject, alert.url))
+	}
+
+	builder.WriteString("\r\n" + utils.Translate(lang, "email.batch.details") + "\r\n")
+	for _, alert := range batch.alerts {
+		builder.WriteString("\r\n----------------------------------------\r\n")
+		builder.WriteString(alert.message + "\r\n")
+	}
+
+	subject := utils.Translate(lang, "email.batch.subject", "count", count, "names", strings.Join(names, ", "))
+	logger.Infof("Combined %d alerts into one email to %s", len(batch.alerts), strings.Join(batch.recipients, ","))
+	a.sendEmailAlert(batch.alertID, batch.recipients, subject, builder.String())
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// checkEvent builds the event for the latest check result. Caller must hold the state lock.
+func checkEvent(state *MonitorState) structs.CheckEvent {
+	return structs.CheckEvent{
+		EndpointID:     state.ID,
+		Name:           state.Endpoint.Name,
+		URL:            state.Endpoint.URL,
+		ProjectID:      state.Endpoint.ProjectID,
+		Status:         string(state.Status),
+		Passed:         state.ConsecutiveFailures == 0,
+		ResponseTimeMs: state.ResponseTime.Milliseconds(),
+		Error:          state.LastError,
+		Timestamp:      state.LastCheck,
+	}
+}
+
+// statusEvent builds the event for an endpoint's current status. Caller must hold the state lock.
+func statusEvent(state *MonitorState, previous structs.HealthStatus) structs.StatusEvent {
+	return structs.StatusEvent{
+		EndpointID:     state.ID,
+		Name:           state.Endpoint.Name,
+		URL:            state.Endpoint.URL,
+		ProjectID:      state.Endpoint.ProjectID,
+		Status:         string(state.Status),
+		PreviousStatus: string(previous),
+		Error:          state.LastError,
+		Timestamp:      state.LastCheck,
+	}
+}
+
+// emitCheck publishes a check result to event subscribers. Caller must hold the state lock.
+func (m *Monitor) emitCheck(state *MonitorState) {
+	if m.mqtt == nil && m.stream == nil && m.firehose == nil {
+		return
+	}
+	event := checkEvent(state)
+	if m.mqtt != nil {
+		m.mqtt.PublishCheck(event)
+	}
+	if m.stream != nil {
+		m.stream.PublishCheck(event)
+	}
+	if m.firehose != nil {
+		m.firehose.PublishCheck(event)
+	}
+}
+
+// emitStatusChange publishes a status transition to event subscribers and the endpoint's
+// callback URL. Caller must hold the state lock.
+func (m *Monitor) emitStatusChange(state *MonitorState, previous structs.HealthStatus) {
+	m.sendStatusCallback(state, previous)
+	if m.mqtt == nil && m.stream == nil {
+		return
+	}
+	event := statusEvent(state, previous)
+	if m.mqtt != nil {
+		m.mqtt.PublishStatus(event)
+	}
+	if m.stream != nil {
+		m.stream.PublishStatus(event)
+	}
+}
+
+// currentStatusEvents returns the current status of every endpoint
+func (m *Monitor) currentStatusEvents() []structs.StatusEvent {
+	m.mu.RLock()
+	states := make([]*MonitorState, 0, len(m.states))
+	for _, state := range m.states {
+		states = append(states, state)
+	}
+	m.mu.RUnlock()
+
+	events := make([]structs.StatusEvent, 0, len(states))
+	for _, state := range states {
+		state.mu.RLock()
+		events = append(events, statusEvent(state, ""))
+		state.mu.RUnlock()
+	}
+	return events
+}
@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// eventQueueSize bounds events buffered while the stream is unreachable
+const eventQueueSize = 4096
+
+// kafkaBatchSize caps the records sent in one Kafka REST Proxy request
+const kafkaBatchSize = 100
+
+// streamEvent is an event waiting to be delivered
+type streamEvent struct {
+	topic   string
+	key     string
+	payload json.RawMessage
+}
+
+// EventStreamer streams every check result and state transition to NATS or Kafka
+type EventStreamer struct {
+	config structs.EventStreamConfig
+	queue  chan streamEvent
+	client *http.Client
+}
+
+// NewEventStreamer creates a streamer for the configured driver
+func NewEventStreamer(config structs.EventStreamConfig) *EventStreamer {
+	return &EventStreamer{
+		config: config,
+		queue:  make(chan streamEvent, eventQueueSize),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PublishCheck queues a check result on <prefix>.checks
+func (s *EventStreamer) PublishCheck(event structs.CheckEvent) {
+	s.enqueue(s.config.TopicPrefix+".checks", event.EndpointID, event)
+}
+
+// PublishStatus queues a state transition on <prefix>.transitions
+func (s *EventStreamer) PublishStatus(event structs.StatusEvent) {
+	s.enqueue(s.config.TopicPrefix+".transitions", event.EndpointID, event)
+}
+
+// enqueue marshals and queues an event without blocking the check path
+func (s *EventStreamer) enqueue(topic, key string, event interface{}) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("Failed to marshal stream event: %v", err)
+		return
+	}
+
+	select {
+	case s.queue <- streamEvent{topic: topic, key: key, payload: payload}:
+	default:
+		logger.Debugf("Event stream queue full, dropping event for %s", topic)
+	}
+}
+
+// Run delivers queued events until ctx is cancelled
+func (s *EventStreamer) Run(ctx context.Context) {
+	if s.config.Driver == structs.EventStreamKafka {
+		s.runKafka(ctx)
+		return
+	}
+	s.runNATS(ctx)
+}
+
+// runNATS keeps a NATS connection open and publishes queued events
+func (s *EventStreamer) runNATS(ctx context.Context) {
+	retry := 5 * time.Second
+	for {
+		client, err := utils.DialNATS(s.config.URL, "sitewatch", s.config.Username, s.config.Password, s.config.Token, 10*time.Second)
+		if err != nil {
+			logger.Errorf("NATS connect to %s failed: %v (retrying in %v)", s.config.URL, err, retry)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retry):
+			}
+			if retry < 5*time.Minute {
+				retry *= 2
+			}
+			continue
+		}
+
+		logger.Infof("Connected to NATS server %s", s.config.URL)
+		retry = 5 * time.Second
+
+	publish:
+		for {
+			select {
+			case <-ctx.Done():
+				client.Close()
+				return
+			case <-client.Done():
+				break publish
+			case event := <-s.queue:
+				if err := client.Publish(event.topic, event.payload); err != nil {
+					break publish
+				}
+			}
+		}
+		logger.Errorf("NATS connection lost: %v", client.Err())
+	}
+}
+
+// runKafka batches queued events and produces them through a Kafka REST Proxy
+func (s *EventStreamer) runKafka(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	batches := make(map[string][]streamEvent)
+	pending := 0
+	flush := func() {
+		for topic, events := range batches {
+			if err := s.produceKafka(topic, events); err != nil {
+				logger.Errorf("Failed to produce %d events to Kafka topic %s: %v", len(events), topic, err)
+			}
+		}
+		batches = make(map[string][]streamEvent)
+		pending = 0
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return
+		case event := <-s.queue:
+			batches[event.topic] = append(batches[event.topic], event)
+			pending++
+			if pending >= kafkaBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			if pending > 0 {
+				flush()
+			}
+		}
+	}
+}
+
+// produceKafka posts records to the REST Proxy v2 API, keyed by endpoint ID for per-endpoint ordering
+func (s *EventStreamer) produceKafka(topic string, events []streamEvent) error {
+	type record struct {
+		Key   string          `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+	records := make([]record, 0, len(events))
+	for _, event := range events {
+		records = append(records, record{Key: event.key, Value: event.payload})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.URL+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if s.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.Token)
+	} else if s.config.Username != "" {
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("REST proxy returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// firehoseQueueSize bounds check results buffered while the webhook is slow or down
+const firehoseQueueSize = 10000
+
+// firehoseAttempts is how many times a batch is posted before it is dropped
+const firehoseAttempts = 3
+
+// Firehose posts every check result to a webhook in batches for external analytics
+type Firehose struct {
+	config structs.FirehoseConfig
+	queue  chan structs.CheckEvent
+	client *http.Client
+}
+
+// NewFirehose creates a firehose for the configured webhook
+func NewFirehose(config structs.FirehoseConfig) *Firehose {
+	return &Firehose{
+		config: config,
+		queue:  make(chan structs.CheckEvent, firehoseQueueSize),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PublishCheck queues a check result without blocking the check path
+func (f *Firehose) PublishCheck(event structs.CheckEvent) {
+	select {
+	case f.queue <- event:
+	default:
+		logger.Debugf("Firehose queue full, dropping check result for %s", event.EndpointID)
+	}
+}
+
+// Run batches queued results and posts them until ctx is cancelled, flushing what is left on shutdown
+func (f *Firehose) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.config.FlushInterval.Duration)
+	defer ticker.Stop()
+
+	batch := make([]structs.CheckEvent, 0, f.config.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		f.send(ctx, batch)
+		batch = make([]structs.CheckEvent, 0, f.config.BatchSize)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Drain what was queued before shutdown
+			for {
+				select {
+				case event := <-f.queue:
+					batch = append(batch, event)
+					if len(batch) >= f.config.BatchSize {
+						flush()
+					}
+					continue
+				default:
+				}
+				break
+			}
+			flush()
+			return
+		case event := <-f.queue:
+			batch = append(batch, event)
+			if len(batch) >= f.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send posts a batch, retrying with a short backoff while the monitor is running
+func (f *Firehose) send(ctx context.Context, batch []structs.CheckEvent) {
+	body, err := json.Marshal(map[string]interface{}{
+		"checks":  batch,
+		"count":   len(batch),
+		"sent_at": time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		logger.Errorf("Failed to marshal firehose batch: %v", err)
+		return
+	}
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err = f.post(body)
+		if err == nil {
+			logger.Debugf("Firehose posted %d check results", len(batch))
+			return
+		}
+		if attempt == firehoseAttempts || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	logger.Errorf("Firehose dropped %d check results: %v", len(batch), err)
+}
+
+// post delivers one batch to the webhook
+func (f *Firehose) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, f.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range f.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+)
+
+// haSync shares the leader's endpoints, state and settings with the standby through a file
+// next to the lock file, since each instance of an HA pair keeps its own database
+type haSync struct {
+	path   string
+	nodeID string
+
+	// published is the state version last written by this node as leader
+	published uint64
+	// applied is when the set last imported from the other node was written
+	applied time.Time
+}
+
+// newHASync creates the sync for the configured sync file
+func newHASync(path, nodeID string) *haSync {
+	return &haSync{path: path, nodeID: nodeID}
+}
+
+// read loads the published endpoint set, returning nil if none has been published yet
+func (s *haSync) read() (*models.EndpointSet, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var set models.EndpointSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// write replaces the sync file atomically
+func (s *haSync) write(set *models.EndpointSet) error {
+	data, err := json.Marshal(set)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(s.path), "."+filepath.Base(s.path)+"."+s.nodeID)
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// importHASet copies into the database the endpoint set last published by the other node,
+// if it is newer than the one already imported. It reports whether anything was imported.
+func (m *Monitor) importHASet() bool {
+	set, err := m.haSync.read()
+	if err != nil {
+		logger.Errorf("Failed to read HA sync file: %v", err)
+		return false
+	}
+	if set == nil || set.Holder == m.haSync.nodeID || !set.Written.After(m.haSync.applied) {
+		return false
+	}
+	if err := m.db.ImportEndpointSet(set); err != nil {
+		logger.Errorf("Failed to import endpoints from HA leader %s: %v", set.Holder, err)
+		return false
+	}
+	m.haSync.applied = set.Written
+	logger.Debugf("Imported %d endpoints from HA leader %s", len(set.Endpoints), set.Holder)
+	return true
+}
+
+// syncFromLeader brings a standby's endpoints and their state up to date with the leader's
+func (m *Monitor) syncFromLeader() {
+	if !m.importHASet() {
+		return
+	}
+	result, err := m.reloadEndpoints()
+	if err != nil {
+		logger.Errorf("Failed to reload endpoints from HA leader: %v", err)
+		return
+	}
+	if result.Added+result.Updated+result.Removed > 0 {
+		logger.Infof("Synced endpoints from HA leader: %d added, %d updated, %d removed", result.Added, result.Updated, result.Removed)
+	}
+
+	// A standby runs no checks, so the leader's results replace its own
+	snapshots, err := m.db.GetAllEndpointStates()
+	if err != nil {
+		logger.Errorf("Error loading endpoint states from database: %v", err)
+		return
+	}
+	m.mu.RLock()
+	for id, state := range m.states {
+		if snapshot, ok := snapshots[id]; ok {
+			state.mu.Lock()
+			state.Restore(snapshot)
+			state.mu.Unlock()
+		}
+	}
+	m.mu.RUnlock()
+	m.touch()
+}
+
+// publishToStandby writes the leader's endpoints, state and settings for the standby when
+// anything changed since the last write
+func (m *Monitor) publishToStandby() {
+	version := m.StateVersion()
+	if version == m.haSync.published {
+		return
+	}
+	set, err := m.db.ExportEndpointSet()
+	if err != nil {
+		logger.Errorf("Failed to export endpoints for HA standby: %v", err)
+		return
+	}
+	set.Holder = m.haSync.nodeID
+	set.Written = time.Now()
+	if err := m.haSync.write(set); err != nil {
+		logger.Errorf("Failed to write HA sync file: %v", err)
+		return
+	}
+	m.haSync.published = version
+}
+
+// syncHA runs on every lease round: a standby catches up with the leader before it contends
+// for the lease, and the leader publishes its changes after renewing it
+func (m *Monitor) syncHA(leader bool) {
+	if leader {
+		m.publishToStandby()
+	} else {
+		m.syncFromLeader()
+	}
+}
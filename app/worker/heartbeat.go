@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// Heartbeat pings external dead-man's-switch services, such as healthchecks.io or Better Stack,
+// after scheduler cycles, so they raise the alarm when SiteWatch itself stops
+type Heartbeat struct {
+	config structs.HeartbeatConfig
+	client *http.Client
+	due    chan struct{}
+
+	mu       sync.Mutex
+	lastBeat time.Time
+	statuses map[string]HeartbeatStatus
+}
+
+// HeartbeatStatus reports the last ping of one heartbeat URL
+type HeartbeatStatus struct {
+	URL         string    `json:"url"`
+	LastPing    time.Time `json:"last_ping"`
+	LastSuccess time.Time `json:"last_success"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// NewHeartbeat creates a heartbeat pinging the configured URLs through the alert HTTP client
+func NewHeartbeat(config structs.HeartbeatConfig, client *http.Client) *Heartbeat {
+	return &Heartbeat{
+		config:   config,
+		client:   client,
+		due:      make(chan struct{}, 1),
+		statuses: make(map[string]HeartbeatStatus),
+	}
+}
+
+// CycleCompleted notes a finished scheduler cycle, queueing a ping at most once per interval
+func (h *Heartbeat) CycleCompleted(now time.Time) {
+	h.mu.Lock()
+	if now.Sub(h.lastBeat) < h.config.Interval.Duration {
+		h.mu.Unlock()
+		return
+	}
+	h.lastBeat = now
+	h.mu.Unlock()
+
+	select {
+	case h.due <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends queued pings until ctx is cancelled; pings never hold up the scheduler
+func (h *Heartbeat) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.due:
+			for _, url := range h.config.URLs {
+				h.ping(ctx, url)
+			}
+		}
+	}
+}
+
+// ping sends one heartbeat and records how it went
+func (h *Heartbeat) ping(ctx context.Context, url string) {
+	ctx, cancel := context.WithTimeout(ctx, h.config.Timeout.Duration)
+	defer cancel()
+
+	err := func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return nil
+	}()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := HeartbeatStatus{URL: url, LastPing: time.Now(), LastSuccess: h.statuses[url].LastSuccess}
+	if err != nil {
+		status.LastError = err.Error()
+		// Log once per streak, the external service alerts on the missed heartbeats anyway
+		if h.statuses[url].LastError == "" {
+			logger.Errorf("Heartbeat to %s failed: %v", url, err)
+		}
+	} else {
+		status.LastSuccess = status.LastPing
+		if h.statuses[url].LastError != "" {
+			logger.Infof("Heartbeat to %s recovered", url)
+		}
+	}
+	h.statuses[url] = status
+}
+
+// Statuses returns the last ping of every heartbeat URL, in configured order
+func (h *Heartbeat) Statuses() []HeartbeatStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	statuses := make([]HeartbeatStatus, 0, len(h.config.URLs))
+	for _, url := range h.config.URLs {
+		status, ok := h.statuses[url]
+		if !ok {
+			status = HeartbeatStatus{URL: url}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
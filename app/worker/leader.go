@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// leaderLease is the content of the shared lock file
+type leaderLease struct {
+	Holder   string    `json:"holder"`
+	Acquired time.Time `json:"acquired"`
+	Expires  time.Time `json:"expires"`
+}
+
+// LeaderStatus describes this instance's role in an HA pair
+type LeaderStatus struct {
+	Enabled bool       `json:"enabled"`
+	NodeID  string     `json:"node_id,omitempty"`
+	Leader  bool       `json:"leader"`
+	Holder  string     `json:"holder,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	Expires *time.Time `json:"expires,omitempty"`
+	// ReadOnly is set on instances started with --read-only, which never lead
+	ReadOnly bool `json:"read_only"`
+}
+
+// LeaderElector holds a lease in a lock file on storage shared by the instances of an HA pair.
+// The holder renews the lease; a standby takes over once it expires.
+type LeaderElector struct {
+	nodeID string
+	path   string
+	lease  time.Duration
+
+	mu     sync.RWMutex
+	leader bool
+	held   leaderLease
+
+	// sync is called around each lease round with the role, see SetSync
+	sync func(leader bool)
+}
+
+// NewLeaderElector creates an elector from the HA config
+func NewLeaderElector(config structs.HAConfig) *LeaderElector {
+	return &LeaderElector{
+		nodeID: config.NodeID,
+		path:   config.LockFile,
+		lease:  config.LeaseDuration.Duration,
+	}
+}
+
+// IsLeader reports whether this instance currently holds the lease
+func (e *LeaderElector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// Status returns this instance's role and the current lease holder
+func (e *LeaderElector) Status() LeaderStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	status := LeaderStatus{
+		Enabled: true,
+		NodeID:  e.nodeID,
+		Leader:  e.leader,
+		Holder:  e.held.Holder,
+	}
+	if e.held.Holder != "" {
+		status.Since = &e.held.Acquired
+		status.Expires = &e.held.Expires
+	}
+	return status
+}
+
+// SetSync registers a function called as a standby before each attempt to take the lease,
+// and as the leader after each renewal. Call it before Run.
+func (e *LeaderElector) SetSync(sync func(leader bool)) {
+	e.sync = sync
+}
+
+// round syncs and campaigns once
+func (e *LeaderElector) round() {
+	if e.sync != nil && !e.IsLeader() {
+		e.sync(false)
+	}
+	e.campaign()
+	if e.sync != nil && e.IsLeader() {
+		e.sync(true)
+	}
+}
+
+// Run renews or contends for the lease until ctx is done, then releases it if held
+func (e *LeaderElector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+			e.round()
+		}
+	}
+}
+
+// campaign takes or renews the lease when it is free, expired or already ours
+func (e *LeaderElector) campaign() {
+	now := time.Now()
+	current, err := e.read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// An unreadable lock file must not leave two leaders; step down until it is readable
+		logger.Errorf("Failed to read HA lock file: %v", err)
+		e.setLeader(false, leaderLease{})
+		return
+	}
+
+	if current.Holder != "" && current.Holder != e.nodeID && now.Before(current.Expires) {
+		e.setLeader(false, current)
+		return
+	}
+
+	renewal := current.Holder == e.nodeID && now.Before(current.Expires)
+	next := leaderLease{Holder: e.nodeID, Acquired: now, Expires: now.Add(e.lease)}
+	if renewal {
+		next.Acquired = current.Acquired
+	}
+	if err := e.write(next); err != nil {
+		logger.Errorf("Failed to write HA lock file: %v", err)
+		e.setLeader(false, current)
+		return
+	}
+
+	// Both instances may have seen an expired lease; let a concurrent write land, the last writer wins
+	if !renewal {
+		time.Sleep(e.lease / 10)
+	}
+	confirmed, err := e.read()
+	if err != nil || confirmed.Holder != e.nodeID {
+		e.setLeader(false, confirmed)
+		return
+	}
+	e.setLeader(true, confirmed)
+}
+
+// release expires the lease so the standby can take over without waiting
+func (e *LeaderElector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	current, err := e.read()
+	if err != nil || current.Holder != e.nodeID {
+		return
+	}
+	current.Expires = time.Now()
+	if err := e.write(current); err != nil {
+		logger.Errorf("Failed to release HA lease: %v", err)
+		return
+	}
+	e.mu.Lock()
+	e.leader = false
+	e.held = current
+	e.mu.Unlock()
+	logger.Infof("Released HA leadership")
+}
+
+// setLeader records the role and logs transitions
+func (e *LeaderElector) setLeader(leader bool, lease leaderLease) {
+	e.mu.Lock()
+	was := e.leader
+	e.leader = leader
+	e.held = lease
+	e.mu.Unlock()
+
+	switch {
+	case leader && !was:
+		logger.Infof("Became HA leader (%s), running checks and alerts", e.nodeID)
+	case !leader && was:
+		logger.Infof("Lost HA leadership to %s, serving read-only", lease.Holder)
+	}
+}
+
+// read loads the lease from the lock file
+func (e *LeaderElector) read() (leaderLease, error) {
+	var lease leaderLease
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return lease, err
+	}
+	if len(data) == 0 {
+		return lease, nil
+	}
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return lease, fmt.Errorf("invalid lock file: %w", err)
+	}
+	return lease, nil
+}
+
+// write replaces the lock file atomically
+func (e *LeaderElector) write(lease leaderLease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(e.path), "."+filepath.Base(e.path)+"."+e.nodeID)
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.path)
+}
+
+// IsActive reports whether this instance runs checks and alerts: never in read-only mode,
+// always without HA, otherwise only while it holds the lease
+func (m *Monitor) IsActive() bool {
+	if m.config.ReadOnly {
+		return false
+	}
+	return m.leader == nil || m.leader.IsLeader()
+}
+
+// ReadOnly reports whether the instance was started in read-only mode
+func (m *Monitor) ReadOnly() bool {
+	return m.config.ReadOnly
+}
+
+// LeaderStatus returns this instance's HA role
+func (m *Monitor) LeaderStatus() LeaderStatus {
+	if m.leader == nil {
+		return LeaderStatus{Enabled: m.config.HA.Enabled, Leader: !m.config.ReadOnly, ReadOnly: m.config.ReadOnly}
+	}
+	return m.leader.Status()
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// accountCheck counts a health check as it starts and detects scheduled checks that did not run
+// since the previous one, e.g. because the process stalled or the host was suspended. The gap is
+// measured against the effective interval, so waits for backoff or Retry-After are not missed
+// checks; cron-scheduled endpoints and the first check after a start, enable or settings change
+// are not measured.
+func (m *Monitor) accountCheck(state *MonitorState, now time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	// Gaps are measured on the wall clock like the history, where a host suspend shows too
+	now = now.Round(0)
+	state.ChecksRun++
+	previous := state.lastRun
+	state.lastRun = now
+
+	interval := state.CheckInterval
+	if state.BackoffInterval > interval {
+		interval = state.BackoffInterval
+	}
+	if previous.IsZero() || state.cron != nil || interval <= 0 {
+		return
+	}
+	if state.RateLimitedUntil.After(previous) {
+		return
+	}
+	// A standby that just took over did not miss the checks the old leader ran
+	if since := m.LeaderStatus().Since; since != nil && previous.Before(*since) {
+		return
+	}
+
+	// Checks due in the gap, rounded, less the one starting now
+	gap := now.Sub(previous)
+	missed := int((gap+interval/2)/interval) - 1
+	if missed <= 0 {
+		return
+	}
+
+	state.MissedChecks += missed
+	state.LastMissedCheck = now
+	logger.Errorf("[%s] WARNING: %d check(s) missed, %v since the previous check with a %v interval",
+		state.Endpoint.Name, missed, gap.Round(time.Second), interval)
+}
+
+// resetCheckBaseline stops the next check from counting the time since the last one as missed
+// checks, after the endpoint was off or its interval changed. Caller must hold the state lock.
+func (state *MonitorState) resetCheckBaseline() {
+	state.lastRun = time.Time{}
+}
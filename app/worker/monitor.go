@@ -3,13 +3,19 @@ package worker
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ashanmugaraja/cronzee/app/logger"
 	"github.com/ashanmugaraja/cronzee/app/models"
 	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
 )
 
 // Monitor manages health checks for multiple endpoints
@@ -17,35 +23,187 @@ type Monitor struct {
 	config  *structs.Config
 	states  map[string]*MonitorState
 	alerter *Alerter
+	pool    *TransportPool
+	limiter *RateLimiter
 	db      *models.Database
 	ticker  *time.Ticker
 	ctx     context.Context
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
 	mu      sync.RWMutex
+
+	// Running checks and the services delivering their results outlive the schedulers at shutdown
+	checkCtx     context.Context
+	cancelChecks context.CancelFunc
+	serviceCtx   context.Context
+	stopServices context.CancelFunc
+	services     sync.WaitGroup
+
+	// version changes whenever any endpoint state does; seeded from the start time so it never repeats across restarts
+	version uint64
+
+	loc *time.Location
+
+	inflight    map[string]*inflightCheck
+	inflightMu  sync.Mutex
+	stuckChecks uint64
+	// overlapSkips counts dispatches skipped because the endpoint's previous check was still running
+	overlapSkips uint64
+
+	// stateWriteErrors counts failed state snapshot writes; self is the self-monitoring verdict
+	stateWriteErrors uint64
+	self             selfMonitor
+
+	serviceStates map[string]structs.HealthStatus
+	serviceMu     sync.Mutex
+
+	projectAlerters map[string]*Alerter
+	users           []*structs.User
+	onCall          []*structs.OnCallSchedule
+	blackouts       []*structs.BlackoutCalendar
+	actionKey       []byte
+	push            *WebPusher
+	history         *historyWriter
+	mqtt            *MQTTPublisher
+	stream          *EventStreamer
+	firehose        *Firehose
+	otlp            *OTLPExporter
+	heartbeat       *Heartbeat
+	archiver        *Archiver
+	leader          *LeaderElector
+	haSync          *haSync
+	outbox          *Outbox
+	browser         *Browser
+	projectMu       sync.RWMutex
 }
 
 // MonitorState tracks the state of a monitored endpoint with mutex
 type MonitorState struct {
 	*structs.EndpointState
-	mu sync.RWMutex
+	jar    http.CookieJar
+	recent *resultRing
+	cron   *utils.CronSchedule
+	// screenshot and steps are the latest browser check's capture and step timings, saved with its history record
+	screenshot []byte
+	steps      []structs.StepResult
+	mu         sync.RWMutex
+
+	// lastRun is when the previous health check started, for missed-check accounting
+	lastRun time.Time
+	// lastDuration is how long the previous health check took from start to finish
+	lastDuration time.Duration
+	// lastLegacyProbe is when the server was last probed for TLS 1.0 and 1.1
+	lastLegacyProbe time.Time
+
+	// lastRecorded, held and pending track sampled history: the status last seen, and the
+	// successes held back since the last write with the latest of them
+	lastRecorded string
+	held         int
+	pending      *structs.HealthCheckRecord
+}
+
+// cookieJar returns the endpoint's session cookie jar, creating it on first use
+func (s *MonitorState) cookieJar() http.CookieJar {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.jar == nil {
+		s.jar, _ = cookiejar.New(nil)
+	}
+	return s.jar
 }
 
 // NewMonitor creates a new health monitor
 func NewMonitor(config *structs.Config, db *models.Database) *Monitor {
 	ctx, cancel := context.WithCancel(context.Background())
+	checkCtx, cancelChecks := context.WithCancel(context.Background())
+	serviceCtx, stopServices := context.WithCancel(context.Background())
 
 	monitor := &Monitor{
-		config:  config,
-		states:  make(map[string]*MonitorState),
-		alerter: NewAlerter(&config.Alerting),
-		db:      db,
-		ctx:     ctx,
-		cancel:  cancel,
+		config:   config,
+		states:   make(map[string]*MonitorState),
+		alerter:  NewAlerter(&config.Alerting),
+		pool:     NewTransportPool(),
+		limiter:  NewRateLimiter(config.MaxChecksPerSecond),
+		db:       db,
+		ctx:      ctx,
+		cancel:   cancel,
+		inflight: make(map[string]*inflightCheck),
+
+		checkCtx:     checkCtx,
+		cancelChecks: cancelChecks,
+		serviceCtx:   serviceCtx,
+		stopServices: stopServices,
+
+		serviceStates: make(map[string]structs.HealthStatus),
+
+		projectAlerters: make(map[string]*Alerter),
+		history:         newHistoryWriter(db),
+		version:         uint64(time.Now().UnixNano()),
+	}
+
+	// Alerts, summaries and schedules use the configured timezone
+	monitor.loc = time.UTC
+	if loc, err := utils.LoadTimezone(config.Timezone); err == nil {
+		monitor.loc = loc
+	}
+	monitor.alerter.SetLocation(monitor.loc)
+
+	// An HA node starts from the endpoints and keys last published by the leader
+	if config.HA.Enabled && !config.ReadOnly {
+		monitor.haSync = newHASync(config.HA.SyncFile, config.HA.NodeID)
+		monitor.importHASet()
+	}
+
+	// Sign alert action links so operators can respond from Teams
+	monitor.actionKey = loadActionKey(config, db)
+	monitor.alerter.SetActionLinks(config.PublicURL, monitor.actionKey)
+
+	// Webhook, Slack, Teams and push alerts share one client honouring alert_http
+	alertClient := newAlertClient(config.AlertHTTP)
+
+	// Browser push notifications share one VAPID key pair across projects
+	if push, err := NewWebPusher(db, config.WebPushSubject, alertClient); err != nil {
+		logger.Errorf("Web Push unavailable: %v", err)
+	} else {
+		monitor.push = push
+		monitor.alerter.SetWebPusher(push)
 	}
 
-	// Initialize endpoint states from database
+	if config.MQTT.Enabled {
+		monitor.mqtt = NewMQTTPublisher(config.MQTT, monitor.currentStatusEvents)
+	}
+	if config.EventStream.Enabled {
+		monitor.stream = NewEventStreamer(config.EventStream)
+	}
+	if config.Firehose.Enabled {
+		monitor.firehose = NewFirehose(config.Firehose)
+	}
+	if config.OTLP.Enabled {
+		monitor.otlp = NewOTLPExporter(config.OTLP)
+		monitor.alerter.SetOTLP(monitor.otlp)
+	}
+	if config.Heartbeat.Enabled {
+		monitor.heartbeat = NewHeartbeat(config.Heartbeat, alertClient)
+	}
+	if config.Archive.Enabled {
+		monitor.archiver = NewArchiver(config.Archive, db, monitor)
+	}
+	// A read-only instance never contends for the lease, it must not take over checks
+	if config.HA.Enabled && !config.ReadOnly {
+		monitor.leader = NewLeaderElector(config.HA)
+		monitor.leader.SetSync(monitor.syncHA)
+	}
+	monitor.outbox = NewOutbox(db, alertClient, monitor.alerterFor)
+	monitor.browser = NewBrowser(config.Browser)
+	monitor.alerter.SetOutbox(monitor.outbox, "")
+
+	// Initialize endpoint states, users and project alerters from database
 	monitor.loadEndpointsFromDB()
+	monitor.ReloadUsers()
+	monitor.ReloadOnCall()
+	monitor.ReloadBlackouts()
+	monitor.ReloadProjects()
 
 	return monitor
 }
@@ -61,46 +219,34 @@ func (m *Monitor) loadEndpointsFromDB() {
 		return
 	}
 
+	snapshots, err := m.db.GetAllEndpointStates()
+	if err != nil {
+		logger.Errorf("Error loading endpoint states from database: %v", err)
+	}
+
 	for _, stored := range endpoints {
-		checkInterval := stored.CheckInterval
-		if checkInterval == 0 && stored.MonitorHealth {
-			checkInterval = m.config.CheckInterval.Duration
-		}
-		m.states[stored.ID] = &MonitorState{
-			EndpointState: &structs.EndpointState{
-				ID:               stored.ID,
-				Endpoint:         stored.ToEndpoint(),
-				Status:           structs.StatusUnknown,
-				LastCheck:        time.Now(),
-				Enabled:          stored.Enabled,
-				AlertsSuppressed: stored.AlertsSuppressed,
-				MonitorHealth:    stored.MonitorHealth,
-				CheckInterval:    checkInterval,
-				NextCheck:        time.Now(),
-			},
+		// Archived endpoints keep their config and history but are not checked
+		if stored.Archived {
+			continue
 		}
-	}
-}
 
-// ReloadEndpoints reloads endpoints from the database
-func (m *Monitor) ReloadEndpoints() {
-	m.loadEndpointsFromDB()
-	logger.Infof("Reloaded %d endpoints from database", len(m.states))
-}
+		m.states[stored.ID] = m.newMonitorState(stored)
 
-// AddEndpoint adds a new endpoint to monitoring
-func (m *Monitor) AddEndpoint(stored *structs.StoredEndpoint) error {
-	if err := m.db.SaveEndpoint(stored); err != nil {
-		return err
+		// Restore status and timings from before the restart
+		if snapshot, ok := snapshots[stored.ID]; ok {
+			m.states[stored.ID].Restore(snapshot)
+		}
 	}
+}
 
+// newMonitorState creates the monitoring state for a stored endpoint
+func (m *Monitor) newMonitorState(stored *structs.StoredEndpoint) *MonitorState {
 	checkInterval := stored.CheckInterval
 	if checkInterval == 0 && stored.MonitorHealth {
 		checkInterval = m.config.CheckInterval.Duration
 	}
 
-	m.mu.Lock()
-	m.states[stored.ID] = &MonitorState{
+	state := &MonitorState{
 		EndpointState: &structs.EndpointState{
 			ID:               stored.ID,
 			Endpoint:         stored.ToEndpoint(),
@@ -108,20 +254,209 @@ func (m *Monitor) AddEndpoint(stored *structs.StoredEndpoint) error {
 			LastCheck:        time.Now(),
 			Enabled:          stored.Enabled,
 			AlertsSuppressed: stored.AlertsSuppressed,
+			SuppressedUntil:  stored.SuppressedUntil,
 			MonitorHealth:    stored.MonitorHealth,
+			HealthPaused:     stored.HealthPaused,
+			SSLPaused:        stored.SSLPaused,
 			CheckInterval:    checkInterval,
 			NextCheck:        time.Now(),
+			CreatedAt:        stored.CreatedAt,
 		},
+		recent: m.loadRecentResults(stored.ID),
+	}
+	m.applySchedule(state)
+	return state
+}
+
+// ReloadResult counts the endpoint changes picked up by a reload
+type ReloadResult struct {
+	Added   int
+	Updated int
+	Removed int
+	Total   int // Endpoints monitored after the reload
+}
+
+// ReloadEndpoints merges endpoint changes made directly in the database into the monitor.
+// Known endpoints take the stored settings but keep their status, counters and next check time;
+// new endpoints start from their saved state snapshot, and deleted or archived ones are dropped.
+func (m *Monitor) ReloadEndpoints() (ReloadResult, error) {
+	result, err := m.reloadEndpoints()
+	if err != nil {
+		return result, err
+	}
+	logger.Infof("Reloaded endpoints from database: %d added, %d updated, %d removed", result.Added, result.Updated, result.Removed)
+	return result, nil
+}
+
+// reloadEndpoints merges the stored endpoints into the monitor without logging
+func (m *Monitor) reloadEndpoints() (ReloadResult, error) {
+	var result ReloadResult
+
+	endpoints, err := m.db.GetAllEndpoints()
+	if err != nil {
+		return result, err
 	}
+	snapshots, err := m.db.GetAllEndpointStates()
+	if err != nil {
+		logger.Errorf("Error loading endpoint states from database: %v", err)
+	}
+
+	m.mu.Lock()
+	seen := make(map[string]bool, len(endpoints))
+	for _, stored := range endpoints {
+		if stored.Archived {
+			continue
+		}
+		seen[stored.ID] = true
+
+		state, ok := m.states[stored.ID]
+		if !ok {
+			state = m.newMonitorState(stored)
+			if snapshot, ok := snapshots[stored.ID]; ok {
+				state.Restore(snapshot)
+			}
+			m.states[stored.ID] = state
+			result.Added++
+			continue
+		}
+
+		state.mu.Lock()
+		if m.mergeStoredEndpoint(state, stored) {
+			result.Updated++
+		}
+		state.mu.Unlock()
+	}
+
+	for id := range m.states {
+		if !seen[id] {
+			delete(m.states, id)
+			result.Removed++
+		}
+	}
+	result.Total = len(m.states)
 	m.mu.Unlock()
+	m.touch()
+	return result, nil
+}
+
+// mergeStoredEndpoint applies stored settings to a running endpoint, leaving its runtime
+// state alone, and reports whether anything changed. Caller must hold the state lock.
+func (m *Monitor) mergeStoredEndpoint(state *MonitorState, stored *structs.StoredEndpoint) bool {
+	checkInterval := stored.CheckInterval
+	if checkInterval == 0 && stored.MonitorHealth {
+		checkInterval = m.config.CheckInterval.Duration
+	}
+
+	endpoint := stored.ToEndpoint()
+	changed := !reflect.DeepEqual(state.Endpoint, endpoint) ||
+		state.Enabled != stored.Enabled ||
+		state.AlertsSuppressed != stored.AlertsSuppressed ||
+		!state.SuppressedUntil.Equal(stored.SuppressedUntil) ||
+		state.MonitorHealth != stored.MonitorHealth ||
+		state.HealthPaused != stored.HealthPaused ||
+		state.SSLPaused != stored.SSLPaused ||
+		state.CheckInterval != checkInterval
+	if !changed {
+		return false
+	}
+
+	scheduleChanged := state.Endpoint.Schedule != endpoint.Schedule
+	if state.Endpoint.URL != endpoint.URL {
+		// The certificate belongs to the old URL; check the new one on the next run
+		state.SSLCertExpiry = time.Time{}
+		state.SSLExpiringSoon = false
+		state.SSLUncoveredHosts = nil
+		state.SSLError = ""
+		state.TLSVersion, state.TLSCipher, state.TLSLegacy = 0, 0, 0
+		state.DaysToExpiry = 0
+		state.LastSSLCheck = time.Time{}
+		state.jar = nil
+	}
+	if !state.Enabled || !state.ChecksHealth() || state.CheckInterval != checkInterval {
+		state.resetCheckBaseline()
+	}
+	if state.HealthPaused && !stored.HealthPaused {
+		// The SSL-only schedule parked the endpoint for a day
+		state.NextCheck = time.Now()
+	}
+	state.Endpoint = endpoint
+	state.Enabled = stored.Enabled
+	state.AlertsSuppressed = stored.AlertsSuppressed
+	state.SuppressedUntil = stored.SuppressedUntil
+	state.MonitorHealth = stored.MonitorHealth
+	state.HealthPaused = stored.HealthPaused
+	state.SSLPaused = stored.SSLPaused
+	state.CheckInterval = checkInterval
+	if !stored.BackoffEnabled {
+		state.BackoffInterval = 0
+	}
+	if !stored.UseCookies {
+		state.jar = nil
+	}
+	if scheduleChanged {
+		m.applySchedule(state)
+	}
+	return true
+}
+
+// AddEndpoint adds a new endpoint to monitoring
+func (m *Monitor) AddEndpoint(stored *structs.StoredEndpoint) error {
+	if err := m.db.SaveEndpoint(stored); err != nil {
+		return err
+	}
+
+	state := m.newMonitorState(stored)
+
+	m.mu.Lock()
+	m.states[stored.ID] = state
+	m.mu.Unlock()
+	m.touch()
 
 	logger.Infof("Added endpoint: %s", stored.Name)
 	return nil
 }
 
-// RemoveEndpoint removes an endpoint from monitoring
-func (m *Monitor) RemoveEndpoint(id string) error {
-	logger.Debugf("RemoveEndpoint called with id: %s", id)
+// ArchiveEndpoint stops checking an endpoint while keeping its config and history
+func (m *Monitor) ArchiveEndpoint(id string) error {
+	if err := m.db.ArchiveEndpoint(id); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	delete(m.states, id)
+	m.mu.Unlock()
+	m.touch()
+
+	logger.Infof("Archived endpoint: %s", id)
+	return nil
+}
+
+// RestoreEndpoint resumes checking an archived endpoint from its last known state
+func (m *Monitor) RestoreEndpoint(id string) error {
+	stored, err := m.db.RestoreEndpoint(id)
+	if err != nil {
+		return err
+	}
+
+	state := m.newMonitorState(stored)
+	if snapshots, err := m.db.GetAllEndpointStates(); err == nil {
+		if snapshot, ok := snapshots[id]; ok {
+			state.Restore(snapshot)
+		}
+	}
+
+	m.mu.Lock()
+	m.states[id] = state
+	m.mu.Unlock()
+	m.touch()
+
+	logger.Infof("Restored endpoint: %s", stored.Name)
+	return nil
+}
+
+// PurgeEndpoint permanently removes an endpoint with its state and history
+func (m *Monitor) PurgeEndpoint(id string) error {
+	logger.Debugf("PurgeEndpoint called with id: %s", id)
 
 	if err := m.db.DeleteEndpoint(id); err != nil {
 		logger.Errorf("Error deleting from DB: %v", err)
@@ -131,8 +466,9 @@ func (m *Monitor) RemoveEndpoint(id string) error {
 	m.mu.Lock()
 	delete(m.states, id)
 	m.mu.Unlock()
+	m.touch()
 
-	logger.Infof("Removed endpoint: %s", id)
+	logger.Infof("Purged endpoint: %s", id)
 	return nil
 }
 
@@ -146,9 +482,11 @@ func (m *Monitor) EnableEndpoint(id string) error {
 	if state, ok := m.states[id]; ok {
 		state.mu.Lock()
 		state.Enabled = true
+		state.resetCheckBaseline()
 		state.mu.Unlock()
 	}
 	m.mu.Unlock()
+	m.touch()
 
 	logger.Infof("Enabled endpoint: %s", id)
 	return nil
@@ -167,6 +505,7 @@ func (m *Monitor) DisableEndpoint(id string) error {
 		state.mu.Unlock()
 	}
 	m.mu.Unlock()
+	m.touch()
 
 	logger.Infof("Disabled endpoint: %s", id)
 	return nil
@@ -181,19 +520,30 @@ func (m *Monitor) EnableHealthMonitoring(id string, stored *structs.StoredEndpoi
 		state.mu.Lock()
 		state.MonitorHealth = true
 		state.CheckInterval = stored.CheckInterval
+		state.resetCheckBaseline()
 		state.Endpoint.Timeout.Duration = stored.Timeout
 		state.Endpoint.ExpectedStatus = stored.ExpectedStatus
 		state.Endpoint.FailureThreshold = stored.FailureThreshold
+		state.Endpoint.NetworkThreshold = stored.NetworkThreshold
+		state.Endpoint.AppThreshold = stored.AppThreshold
 		state.Endpoint.SuccessThreshold = stored.SuccessThreshold
+		state.Endpoint.FailureDuration = structs.Duration{Duration: stored.FailureDuration}
+		state.Endpoint.SuccessDuration = structs.Duration{Duration: stored.SuccessDuration}
 		state.NextCheck = time.Now()
 		state.mu.Unlock()
+		m.touch()
 		logger.Infof("Enabled health monitoring for endpoint: %s", id)
 	}
 }
 
-// SuppressAlerts suppresses alerts for an endpoint
+// SuppressAlerts suppresses alerts for an endpoint until they are unsuppressed
 func (m *Monitor) SuppressAlerts(id string) error {
-	if err := m.db.SuppressAlerts(id); err != nil {
+	return m.SnoozeAlerts(id, time.Time{})
+}
+
+// SnoozeAlerts suppresses alerts for an endpoint until the given time, or indefinitely when it is zero
+func (m *Monitor) SnoozeAlerts(id string, until time.Time) error {
+	if err := m.db.SuppressAlerts(id, until); err != nil {
 		return err
 	}
 
@@ -201,11 +551,17 @@ func (m *Monitor) SuppressAlerts(id string) error {
 	if state, ok := m.states[id]; ok {
 		state.mu.Lock()
 		state.AlertsSuppressed = true
+		state.SuppressedUntil = until
 		state.mu.Unlock()
 	}
 	m.mu.Unlock()
+	m.touch()
 
-	logger.Infof("Suppressed alerts for endpoint: %s", id)
+	if until.IsZero() {
+		logger.Infof("Suppressed alerts for endpoint: %s", id)
+	} else {
+		logger.Infof("Snoozed alerts for endpoint %s until %s", id, until.In(m.loc).Format(time.RFC3339))
+	}
 	return nil
 }
 
@@ -218,13 +574,82 @@ func (m *Monitor) UpdateEndpointSettings(id string, stored *structs.StoredEndpoi
 		state.mu.Lock()
 		state.Endpoint.Timeout = structs.Duration{Duration: stored.Timeout}
 		state.Endpoint.FailureThreshold = stored.FailureThreshold
+		state.Endpoint.NetworkThreshold = stored.NetworkThreshold
+		state.Endpoint.AppThreshold = stored.AppThreshold
 		state.Endpoint.SuccessThreshold = stored.SuccessThreshold
+		state.Endpoint.FailureDuration = structs.Duration{Duration: stored.FailureDuration}
+		state.Endpoint.SuccessDuration = structs.Duration{Duration: stored.SuccessDuration}
+		state.Endpoint.ResolveTo = stored.ResolveTo
+		state.Endpoint.HostHeader = stored.HostHeader
+		state.Endpoint.UseCookies = stored.UseCookies
+		state.Endpoint.Priority = stored.Priority
+		state.Endpoint.InsecureSkipVerify = stored.InsecureSkipVerify
+		state.Endpoint.CertFingerprint = stored.CertFingerprint
+		state.Endpoint.Tags = stored.Tags
+		state.Endpoint.SLATarget = stored.SLATarget
+		state.Endpoint.Description = stored.Description
+		state.Endpoint.Owner = stored.Owner
+		state.Endpoint.RunbookURL = stored.RunbookURL
+		state.Endpoint.Labels = stored.Labels
+		state.Endpoint.RateLimitMode = stored.RateLimitMode
+		state.Endpoint.CheckType = stored.CheckType
+		state.Endpoint.ExpectedStatus = stored.ExpectedStatus
+		state.Endpoint.WaitSelector = stored.WaitSelector
+		state.Endpoint.ContentType = stored.ContentType
+		state.Endpoint.SuccessExpression = stored.SuccessExpression
+		state.Endpoint.BodyContains = stored.BodyContains
+		state.Endpoint.BodyNotContains = stored.BodyNotContains
+		state.Endpoint.BodyRegex = stored.BodyRegex
+		state.Endpoint.AlternateHosts = stored.AlternateHosts
+		state.Endpoint.CallbackURL = stored.CallbackURL
+		state.Endpoint.HistorySample = stored.HistorySample
+		state.Endpoint.Journey = stored.Journey
+		state.Endpoint.BackoffEnabled = stored.BackoffEnabled
+		state.Endpoint.BackoffAfter = structs.Duration{Duration: stored.BackoffAfter}
+		state.Endpoint.BackoffMaxInterval = structs.Duration{Duration: stored.BackoffMaxInterval}
+		state.Endpoint.Retries = stored.Retries
+		state.Endpoint.RetryDelay = structs.Duration{Duration: stored.RetryDelay}
+		if !stored.BackoffEnabled {
+			state.BackoffInterval = 0
+		}
+		if !stored.UseCookies {
+			state.jar = nil
+		}
+		if state.CheckInterval != stored.CheckInterval {
+			state.resetCheckBaseline()
+		}
 		state.CheckInterval = stored.CheckInterval
+		if state.Endpoint.Schedule != stored.Schedule {
+			state.Endpoint.Schedule = stored.Schedule
+			m.applySchedule(state)
+		}
 		state.mu.Unlock()
+		m.touch()
 		logger.Infof("Updated endpoint settings: %s", id)
 	}
 }
 
+// ReplaceEndpoint saves an edited endpoint and applies it to the running monitor in one step.
+// The endpoint keeps its status, counters and next check time.
+func (m *Monitor) ReplaceEndpoint(stored *structs.StoredEndpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.db.SaveEndpoint(stored); err != nil {
+		return err
+	}
+
+	if state, ok := m.states[stored.ID]; ok {
+		state.mu.Lock()
+		m.mergeStoredEndpoint(state, stored)
+		state.mu.Unlock()
+	}
+	m.touch()
+
+	logger.Infof("Replaced endpoint settings: %s", stored.Name)
+	return nil
+}
+
 // UnsuppressAlerts enables alerts for an endpoint
 func (m *Monitor) UnsuppressAlerts(id string) error {
 	if err := m.db.UnsuppressAlerts(id); err != nil {
@@ -235,14 +660,19 @@ func (m *Monitor) UnsuppressAlerts(id string) error {
 	if state, ok := m.states[id]; ok {
 		state.mu.Lock()
 		state.AlertsSuppressed = false
+		state.SuppressedUntil = time.Time{}
 		state.mu.Unlock()
 	}
 	m.mu.Unlock()
+	m.touch()
 
 	logger.Infof("Unsuppressed alerts for endpoint: %s", id)
 	return nil
 }
 
+// schedulerTick is how often the timer scheduler looks for due endpoints
+const schedulerTick = 5 * time.Second
+
 func isStandardHealthInterval(d time.Duration) bool {
 	switch d {
 	case 1 * time.Minute, 2 * time.Minute, 5 * time.Minute:
@@ -254,6 +684,21 @@ func isStandardHealthInterval(d time.Duration) bool {
 
 // Start begins monitoring all endpoints
 func (m *Monitor) Start() {
+	if m.config.ReadOnly {
+		logger.Infof("Read-only mode: serving the dashboard and APIs from the database without checks or alerts")
+		return
+	}
+
+	// Contend for the HA lease before the first checks, so a standby stays quiet from the start
+	if m.leader != nil {
+		m.leader.round()
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.leader.Run(m.serviceCtx)
+		}()
+	}
+
 	// Perform initial check
 	m.checkAllEndpoints()
 
@@ -261,7 +706,7 @@ func (m *Monitor) Start() {
 	m.startGroupedHealthChecks([]time.Duration{1 * time.Minute, 2 * time.Minute, 5 * time.Minute})
 
 	// Legacy periodic checks (for SSL-only endpoints and endpoints using non-standard intervals)
-	m.ticker = time.NewTicker(5 * time.Second)
+	m.ticker = time.NewTicker(schedulerTick)
 	m.wg.Add(1)
 	go func() {
 		defer m.wg.Done()
@@ -269,57 +714,247 @@ func (m *Monitor) Start() {
 			select {
 			case <-m.ctx.Done():
 				return
-			case <-m.ticker.C:
+			case now := <-m.ticker.C:
 				m.checkDueEndpointsLegacy()
+				// Only the node running checks vouches for them; a standby takes over the heartbeat on failover
+				if m.heartbeat != nil && m.IsActive() {
+					m.heartbeat.CycleCompleted(now)
+				}
 			}
 		}
 	}()
 
+	// Lift snoozes whose time is up
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.startSnoozeExpiry()
+	}()
+
+	// Cancel checks that hang past their timeout
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.startWatchdog()
+	}()
+
+	// Evaluate SLA targets and error-budget burn
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.startSLAEvaluator()
+	}()
+
+	// Alert when SiteWatch itself is unhealthy
+	if m.config.SelfMonitoring.Enabled {
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.startSelfMonitor()
+		}()
+	}
+
+	// Roll up services and alert at the service level
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.startServiceEvaluator()
+	}()
+
 	// Start daily SSL expiry summary scheduler
 	m.wg.Add(1)
 	go func() {
 		defer m.wg.Done()
 		m.startSSLExpirySummaryScheduler()
 	}()
+	// Start scheduled email digests
+	m.startDigestSchedulers()
+	// Generate monthly availability reports
+	m.startReportScheduler()
+
+	// Deliver queued notifications, including any left over from before a restart
+	m.services.Add(1)
+	go func() {
+		defer m.services.Done()
+		m.outbox.Run(m.serviceCtx)
+	}()
+
+	// Flush check results to the database in batches
+	m.services.Add(1)
+	go func() {
+		defer m.services.Done()
+		m.history.run(m.serviceCtx)
+	}()
+
+	// Publish status changes to the MQTT broker
+	if m.mqtt != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.mqtt.Run(m.serviceCtx)
+		}()
+	}
+
+	// Stream check results and transitions to NATS or Kafka
+	if m.stream != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.stream.Run(m.serviceCtx)
+		}()
+	}
+
+	// Post every check result to the firehose webhook in batches
+	if m.firehose != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.firehose.Run(m.serviceCtx)
+		}()
+	}
+
+	// Export alert events to the OpenTelemetry collector
+	if m.otlp != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.otlp.Run(m.serviceCtx)
+		}()
+	}
+
+	// Tell dead-man's-switch services that scheduling is alive
+	if m.heartbeat != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.heartbeat.Run(m.serviceCtx)
+		}()
+	}
+
+	// Keep check history in object storage beyond the database retention
+	if m.archiver != nil {
+		m.services.Add(1)
+		go func() {
+			defer m.services.Done()
+			m.archiver.Run(m.serviceCtx)
+		}()
+	}
 }
 
-// Stop stops the monitor
+// Stop shuts the monitor down without losing results: scheduling stops, running checks
+// get up to shutdown_timeout to finish, then their records and alerts are written and delivered
 func (m *Monitor) Stop() {
 	if m.ticker != nil {
 		m.ticker.Stop()
 	}
 	m.cancel()
-	m.wg.Wait()
+
+	timeout := m.config.ShutdownTimeout.Duration
+	if !waitTimeout(&m.wg, timeout) {
+		logger.Infof("Checks still running after %s, cancelling them", timeout)
+		m.cancelChecks()
+		m.wg.Wait()
+	}
+	m.cancelChecks()
+
+	// Alerts fired by the last checks, and those held for email batching, go to the outbox
+	m.alerter.Drain()
+	m.projectMu.RLock()
+	for _, alerter := range m.projectAlerters {
+		alerter.Drain()
+	}
+	m.projectMu.RUnlock()
+
+	m.stopServices()
+	m.services.Wait()
+	if !m.config.ReadOnly {
+		m.outbox.Flush()
+	}
+	// Checks that finished during shutdown may have queued results after the writer stopped
+	m.history.drain()
+	m.pool.CloseIdleConnections()
+	m.browser.Close()
+	logger.Infof("Monitor stopped, pending results and alerts flushed")
+}
+
+// waitTimeout waits for wg for at most timeout, reporting whether it finished
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
 }
 
 // checkAllEndpoints checks all configured endpoints
 func (m *Monitor) checkAllEndpoints() {
-	var wg sync.WaitGroup
+	if !m.IsActive() {
+		return
+	}
+
+	var due []*MonitorState
 
 	m.mu.RLock()
 	for _, state := range m.states {
 		state.mu.RLock()
 		enabled := state.Enabled
+		scheduled := state.cron != nil
 		state.mu.RUnlock()
 
-		if !enabled {
+		// Cron-scheduled endpoints wait for their schedule, they may be offline on purpose
+		if !enabled || scheduled {
+			continue
+		}
+
+		due = append(due, state)
+	}
+	m.mu.RUnlock()
+
+	m.runChecks(due, schedulerTick)
+}
+
+// runChecks checks the given endpoints concurrently, dispatching higher priorities first.
+// It waits at most wait for the round: checks still running after that carry on against their
+// own deadlines, so one hanging endpoint cannot hold up the next round for everyone else
+func (m *Monitor) runChecks(due []*MonitorState, wait time.Duration) {
+	sortByPriority(due)
+
+	var round sync.WaitGroup
+	for _, state := range due {
+		// A check still running from an earlier round is left alone, without using up dispatch capacity
+		if m.checkRunning(state.ID) {
+			atomic.AddUint64(&m.overlapSkips, 1)
 			continue
 		}
+		// Dispatch in priority order within the global checks-per-second cap
+		if err := m.limiter.Wait(m.ctx); err != nil {
+			break
+		}
 
-		wg.Add(1)
+		round.Add(1)
+		m.wg.Add(1)
 		go func(s *MonitorState) {
-			defer wg.Done()
+			defer m.wg.Done()
+			defer round.Done()
 			m.checkEndpoint(s)
 		}(state)
 	}
-	m.mu.RUnlock()
-
-	wg.Wait()
+	waitTimeout(&round, wait)
 }
 
 // checkDueEndpoints checks endpoints that are due for checking
 func (m *Monitor) checkDueEndpoints() {
-	var wg sync.WaitGroup
+	if !m.IsActive() {
+		return
+	}
+
+	var due []*MonitorState
 	now := time.Now()
 
 	m.mu.RLock()
@@ -333,15 +968,11 @@ func (m *Monitor) checkDueEndpoints() {
 			continue
 		}
 
-		wg.Add(1)
-		go func(s *MonitorState) {
-			defer wg.Done()
-			m.checkEndpoint(s)
-		}(state)
+		due = append(due, state)
 	}
 	m.mu.RUnlock()
 
-	wg.Wait()
+	m.runChecks(due, schedulerTick)
 }
 
 func (m *Monitor) startGroupedHealthChecks(intervals []time.Duration) {
@@ -378,68 +1009,88 @@ func (m *Monitor) startGroupedHealthChecks(intervals []time.Duration) {
 }
 
 func (m *Monitor) checkEndpointsByInterval(interval time.Duration) {
+	if !m.IsActive() {
+		return
+	}
+
 	checkTime := time.Now()
-	var wg sync.WaitGroup
+	var due []*MonitorState
 
 	m.mu.RLock()
 	for _, state := range m.states {
 		state.mu.RLock()
 		enabled := state.Enabled
-		monitorHealth := state.MonitorHealth
+		monitorHealth := state.ChecksHealth()
 		checkInterval := state.CheckInterval
+		backoff := state.BackoffInterval
+		nextCheck := state.NextCheck
+		rateLimitedUntil := state.RateLimitedUntil
+		scheduled := state.cron != nil
 		state.mu.RUnlock()
 
-		if !enabled || !monitorHealth {
+		if !enabled || !monitorHealth || scheduled {
 			continue
 		}
 		if checkInterval != interval {
 			continue
 		}
+		// Backed-off endpoints wait for their stretched interval (half a tick of slack)
+		if backoff > 0 && checkTime.Add(interval/2).Before(nextCheck) {
+			continue
+		}
+		// Rate-limited endpoints wait until the server's Retry-After has passed
+		if checkTime.Add(interval / 2).Before(rateLimitedUntil) {
+			continue
+		}
 
-		wg.Add(1)
-		go func(s *MonitorState) {
-			defer wg.Done()
-			m.checkEndpoint(s)
-		}(state)
+		due = append(due, state)
 	}
 	m.mu.RUnlock()
 
-	wg.Wait()
+	// Half an interval is plenty for healthy checks; slower ones are grouped in the next run
+	m.runChecks(due, interval/2)
 
-	// Send a single grouped Teams alert for this interval run
-	var unhealthyStates []*structs.EndpointState
+	// Send a single grouped Teams alert per project for this interval run
+	unhealthyStates := make(map[*Alerter][]*structs.EndpointState)
 	if m.alerter != nil {
 		m.mu.RLock()
 		for _, state := range m.states {
 			state.mu.RLock()
 			enabled := state.Enabled
-			monitorHealth := state.MonitorHealth
+			monitorHealth := state.ChecksHealth()
 			checkInterval := state.CheckInterval
 			status := state.Status
 			suppressed := state.AlertsSuppressed
+			acknowledged := state.Acknowledged
 			endpointState := state.EndpointState
+			scheduled := state.cron != nil
 			state.mu.RUnlock()
 
-			if !enabled || suppressed || !monitorHealth {
+			if !enabled || suppressed || acknowledged || !monitorHealth || scheduled {
 				continue
 			}
 			if checkInterval != interval {
 				continue
 			}
 			if status == structs.StatusUnhealthy {
-				unhealthyStates = append(unhealthyStates, endpointState)
+				alerter := m.alerterFor(endpointState.Endpoint.ProjectID)
+				unhealthyStates[alerter] = append(unhealthyStates[alerter], endpointState)
 			}
 		}
 		m.mu.RUnlock()
 	}
 
-	if len(unhealthyStates) > 0 {
-		m.alerter.SendGroupedTeamsHealthAlert(interval, checkTime, unhealthyStates)
+	for alerter, states := range unhealthyStates {
+		alerter.SendGroupedTeamsHealthAlert(interval, checkTime, states)
 	}
 }
 
 func (m *Monitor) checkDueEndpointsLegacy() {
-	var wg sync.WaitGroup
+	if !m.IsActive() {
+		return
+	}
+
+	var due []*MonitorState
 	now := time.Now()
 
 	m.mu.RLock()
@@ -447,8 +1098,9 @@ func (m *Monitor) checkDueEndpointsLegacy() {
 		state.mu.RLock()
 		enabled := state.Enabled
 		nextCheck := state.NextCheck
-		monitorHealth := state.MonitorHealth
+		monitorHealth := state.ChecksHealth()
 		checkInterval := state.CheckInterval
+		scheduled := state.cron != nil
 		state.mu.RUnlock()
 
 		if !enabled || now.Before(nextCheck) {
@@ -456,29 +1108,25 @@ func (m *Monitor) checkDueEndpointsLegacy() {
 		}
 
 		// Standard interval endpoints are handled by grouped schedulers
-		if monitorHealth && isStandardHealthInterval(checkInterval) {
+		if monitorHealth && !scheduled && isStandardHealthInterval(checkInterval) {
 			continue
 		}
 
-		wg.Add(1)
-		go func(s *MonitorState) {
-			defer wg.Done()
-			m.checkEndpoint(s)
-		}(state)
+		due = append(due, state)
 	}
 	m.mu.RUnlock()
 
-	wg.Wait()
+	m.runChecks(due, schedulerTick)
 }
 
 // checkEndpoint performs a health check on a single endpoint
 func (m *Monitor) checkEndpoint(state *MonitorState) {
 	state.mu.RLock()
-	monitorHealth := state.MonitorHealth
+	monitorHealth := state.ChecksHealth()
 	url := state.Endpoint.URL
 	state.mu.RUnlock()
 
-	// If health monitoring is disabled, only check SSL certificate
+	// If health monitoring is disabled or paused, only check SSL certificate
 	if !monitorHealth {
 		m.checkSSLOnly(state, url)
 		return
@@ -487,130 +1135,294 @@ func (m *Monitor) checkEndpoint(state *MonitorState) {
 	start := time.Now()
 
 	state.mu.RLock()
+	endpoint := state.Endpoint
 	timeout := state.Endpoint.Timeout.Duration
 	method := state.Endpoint.Method
 	headers := state.Endpoint.Headers
 	expectedStatus := state.Endpoint.ExpectedStatus
 	state.mu.RUnlock()
 
-	ctx, cancel := context.WithTimeout(m.ctx, timeout)
+	checkCtx, done, ok := m.beginCheck(state.ID, checkBudget(endpoint, timeout))
+	if !ok {
+		logger.Debugf("[%s] Previous check still running, skipping", endpoint.Name)
+		return
+	}
+	defer done()
+	defer func() {
+		state.mu.Lock()
+		state.lastDuration = time.Since(start)
+		state.mu.Unlock()
+	}()
+
+	m.accountCheck(state, start)
+
+	if endpoint.CheckType == structs.CheckBrowser {
+		m.checkBrowser(checkCtx, state, endpoint, timeout)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(checkCtx, timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	req, err := http.NewRequestWithContext(m.pool.WithTrace(ctx), method, url, nil)
 	if err != nil {
-		m.handleCheckFailure(state, fmt.Sprintf("failed to create request: %v", err), 0)
+		m.handleCheckFailure(state, structs.FailureNetwork, fmt.Sprintf("failed to create request: %v", err), 0)
 		return
 	}
 
+	// Global defaults first, so per-endpoint headers take precedence
+	if m.config.UserAgent != "" {
+		req.Header.Set("User-Agent", m.config.UserAgent)
+	}
+	for key, value := range m.config.DefaultHeaders {
+		req.Header.Set(key, value)
+	}
+
 	// Add custom headers
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
-	client := &http.Client{
-		Timeout: timeout,
+	// Present the production hostname when checking an origin directly
+	if endpoint.HostHeader != "" {
+		req.Host = endpoint.HostHeader
+	}
+
+	client := m.pool.Client(endpoint, timeout)
+
+	// Keep session cookies across redirects and between checks
+	if endpoint.UseCookies {
+		client.Jar = state.cookieJar()
 	}
 
 	resp, err := client.Do(req)
 	responseTime := time.Since(start)
 
+	// Retry a network blip or gateway error within this check before it counts as a failure
+	attempts := 1
+	for endpoint.CheckType != structs.CheckNegative && attempts <= endpoint.Retries && retryable(endpoint, resp, err, expectedStatus) {
+		if resp != nil {
+			io.CopyN(io.Discard, resp.Body, 64<<10)
+			resp.Body.Close()
+		}
+		delay := retryBackoff(endpoint, attempts)
+		logger.Debugf("[%s] Attempt %d failed, retrying in %v", endpoint.Name, attempts, delay)
+		select {
+		case <-time.After(delay):
+		case <-checkCtx.Done():
+			// Cut short by shutdown or the watchdog, the check has no result
+			return
+		}
+
+		// Each retry gets the full timeout
+		retryCtx, retryCancel := context.WithTimeout(checkCtx, timeout)
+		defer retryCancel()
+		attemptStart := time.Now()
+		resp, err = client.Do(req.Clone(m.pool.WithTrace(retryCtx)))
+		responseTime = time.Since(attemptStart)
+		attempts++
+	}
+	if attempts > 1 && !retryable(endpoint, resp, err, expectedStatus) {
+		logger.Infof("[%s] Request got through on attempt %d", endpoint.Name, attempts)
+	}
+
+	if endpoint.CheckType == structs.CheckNegative {
+		// A check cut short by shutdown or the watchdog says nothing about whether the host answers
+		if err != nil && checkCtx.Err() != nil {
+			return
+		}
+		m.handleNegativeResult(state, endpoint, resp, err, responseTime)
+		return
+	}
+
 	if err != nil {
-		m.handleCheckFailure(state, fmt.Sprintf("request failed: %v", err), responseTime)
+		message := fmt.Sprintf("request failed: %v", err)
+		if attempts > 1 {
+			message = fmt.Sprintf("request failed after %d attempts: %v", attempts, err)
+		}
+		m.handleCheckFailure(state, structs.FailureNetwork, message, responseTime)
 		return
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != expectedStatus {
-		m.handleCheckFailure(state,
+	// Drain the rest of a small body so the connection can be returned to the pool; a larger
+	// one is cut off, since reading it could cost more than opening a new connection
+	defer io.CopyN(io.Discard, resp.Body, 64<<10)
+
+	// Alert if a pinned certificate unexpectedly changes
+	if endpoint.CertFingerprint != "" && resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		got := CertFingerprint(resp.TLS.PeerCertificates[0])
+		if got != NormalizeFingerprint(endpoint.CertFingerprint) {
+			m.handleCheckFailure(state, structs.FailureApplication,
+				fmt.Sprintf("certificate fingerprint mismatch: got %s, expected %s", got, NormalizeFingerprint(endpoint.CertFingerprint)),
+				responseTime)
+			return
+		}
+	}
+
+	// A 429 means we are checking too often, not necessarily that the endpoint is down
+	if resp.StatusCode == http.StatusTooManyRequests && expectedStatus != http.StatusTooManyRequests {
+		m.handleRateLimited(state, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), responseTime)
+		return
+	}
+
+	body := &responseBody{resp: resp}
+
+	// A success expression replaces the expected status
+	if endpoint.SuccessExpression != "" {
+		if err := evalSuccessExpression(endpoint.SuccessExpression, resp, body, responseTime); err != nil {
+			m.handleCheckFailure(state, structs.FailureApplication, err.Error(), responseTime)
+			return
+		}
+	} else if resp.StatusCode != expectedStatus {
+		m.handleCheckFailure(state, structs.FailureApplication,
 			fmt.Sprintf("unexpected status code: got %d, expected %d", resp.StatusCode, expectedStatus),
 			responseTime)
 		return
 	}
 
+	// An HTML error page served with the expected status is still a failure
+	if endpoint.ContentType != "" {
+		if err := utils.MatchContentType(resp.Header.Get("Content-Type"), endpoint.ContentType); err != nil {
+			m.handleCheckFailure(state, structs.FailureApplication, err.Error(), responseTime)
+			return
+		}
+	}
+
+	// Likewise a 200 serving an error page, caught by what the body should or should not say
+	if err := checkBodyAssertions(endpoint, body); err != nil {
+		m.handleCheckFailure(state, structs.FailureApplication, err.Error(), responseTime)
+		return
+	}
+
 	m.handleCheckSuccess(state, responseTime)
 }
 
 // checkSSLOnly checks only the SSL certificate for an endpoint (no health check)
 func (m *Monitor) checkSSLOnly(state *MonitorState, url string) {
+	m.refreshSSL(state, false)
+
 	state.mu.Lock()
 	defer state.mu.Unlock()
 
+	// Set next check to 24 hours for SSL-only endpoints
 	now := time.Now()
-	shouldCheckSSL := state.LastSSLCheck.IsZero() || now.Sub(state.LastSSLCheck) >= 24*time.Hour
-
-	if shouldCheckSSL {
-		sslInfo := CheckSSLCertificate(url, m.config.SSLExpiryWarningDays)
-		if sslInfo.IsHTTPS {
-			state.SSLCertExpiry = sslInfo.Expiry
-			state.DaysToExpiry = sslInfo.DaysToExpiry
-			state.SSLExpiringSoon = sslInfo.ExpiringSoon
-			state.LastSSLCheck = now
-
-			if sslInfo.ExpiringSoon {
-				logger.Infof("[%s] ⚠️  SSL certificate expiring in %d days", state.Endpoint.Name, sslInfo.DaysToExpiry)
-			}
+	state.LastCheck = now
+	state.NextCheck = now.Add(24 * time.Hour)
 
-			logger.Infof("[%s] SSL certificate validated (expires: %s, days remaining: %d)",
-				state.Endpoint.Name, sslInfo.Expiry.Format("2006-01-02"), sslInfo.DaysToExpiry)
-		}
+	m.saveStateSnapshot(state)
+}
+
+// refreshSSL checks an endpoint's certificate once a day, or at once when forced, and probes
+// for legacy TLS at most once a day. The handshakes run without the state lock, which is
+// only taken to apply the result. It reports whether a certificate was checked.
+func (m *Monitor) refreshSSL(state *MonitorState, force bool) bool {
+	now := time.Now()
+
+	// A negative endpoint is healthy while it is down, so it has no certificate to check
+	state.mu.RLock()
+	endpoint := state.Endpoint
+	due := force || ((state.LastSSLCheck.IsZero() || now.Sub(state.LastSSLCheck) >= 24*time.Hour) &&
+		endpoint.CheckType != structs.CheckNegative && !state.SSLPaused)
+	probeLegacy := force || now.Sub(state.lastLegacyProbe) >= legacyTLSInterval
+	state.mu.RUnlock()
+	if !due {
+		return false
 	}
 
-	// Set next check to 24 hours for SSL-only endpoints
-	state.LastCheck = now
-	state.NextCheck = now.Add(24 * time.Hour)
+	ctx, cancel := context.WithTimeout(m.checkCtx, sslProbeBudget)
+	sslInfo := checkSSL(ctx, endpoint, m.config.SSLExpiryWarningDays, probeLegacy)
+	cancel()
+	if !sslInfo.IsHTTPS {
+		return false
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if sslInfo.LegacyProbed {
+		state.lastLegacyProbe = now
+	} else {
+		sslInfo.LegacyTLS = state.TLSLegacy
+	}
+	state.SSLCertExpiry = sslInfo.Expiry
+	state.DaysToExpiry = sslInfo.DaysToExpiry
+	state.SSLExpiringSoon = sslInfo.ExpiringSoon
+	state.SSLError = sslInfo.Error
+	state.LastSSLCheck = now
+	m.updateSANCoverage(state, sslInfo.Uncovered)
+	m.updateTLSProtocol(state, sslInfo)
+
+	if sslInfo.ExpiringSoon {
+		logger.Infof("[%s] ⚠️  SSL certificate expiring in %d days", state.Endpoint.Name, sslInfo.DaysToExpiry)
+	}
+
+	if force {
+		logger.Infof("[%s] 🔁 SSL revalidated (expires: %s, days remaining: %d)",
+			state.Endpoint.Name, sslInfo.Expiry.Format("2006-01-02"), sslInfo.DaysToExpiry)
+	} else {
+		logger.Infof("[%s] SSL certificate validated (expires: %s, days remaining: %d)",
+			state.Endpoint.Name, sslInfo.Expiry.Format("2006-01-02"), sslInfo.DaysToExpiry)
+	}
+
+	m.saveStateSnapshot(state)
+	return true
 }
 
-// handleCheckSuccess handles a successful health check
+// handleCheckSuccess handles a successful health check, then checks the certificate if due
 func (m *Monitor) handleCheckSuccess(state *MonitorState, responseTime time.Duration) {
+	m.recordCheckSuccess(state, responseTime)
+	m.refreshSSL(state, false)
+}
+
+// recordCheckSuccess applies a successful health check to the endpoint state
+func (m *Monitor) recordCheckSuccess(state *MonitorState, responseTime time.Duration) {
 	state.mu.Lock()
 	defer state.mu.Unlock()
 
 	state.LastCheck = time.Now()
 	state.LastSuccess = state.LastCheck
-	state.NextCheck = time.Now().Add(state.CheckInterval)
+	state.NextCheck = m.nextCheckAfter(state, state.LastCheck)
+	if state.BackoffInterval > 0 {
+		logger.Infof("[%s] Check interval restored to %v", state.Endpoint.Name, state.CheckInterval)
+		state.BackoffInterval = 0
+	}
 	state.ResponseTime = responseTime
 	state.ConsecutiveFailures = 0
+	state.ConsecutiveNetwork = 0
+	state.ConsecutiveApp = 0
+	state.FailingSince = time.Time{}
 	state.ConsecutiveSuccesses++
+	if state.ConsecutiveSuccesses == 1 {
+		state.HealthySince = state.LastCheck
+	}
 	state.LastError = ""
+	state.LastFailureKind = ""
+	state.RateLimited = false
 
 	previousStatus := state.Status
 
 	// Update status if threshold is met
-	if state.ConsecutiveSuccesses >= state.Endpoint.SuccessThreshold {
+	if successThresholdReached(state.EndpointState) {
 		state.Status = structs.StatusHealthy
 	}
 
-	// Check SSL certificate expiry for HTTPS endpoints (once per day)
-	// Run immediately for new endpoints (LastSSLCheck is zero) or if 24 hours have passed
-	now := time.Now()
-	shouldCheckSSL := state.LastSSLCheck.IsZero() || now.Sub(state.LastSSLCheck) >= 24*time.Hour
-
-	if shouldCheckSSL {
-		sslInfo := CheckSSLCertificate(state.Endpoint.URL, m.config.SSLExpiryWarningDays)
-		if sslInfo.IsHTTPS {
-			state.SSLCertExpiry = sslInfo.Expiry
-			state.DaysToExpiry = sslInfo.DaysToExpiry
-			state.SSLExpiringSoon = sslInfo.ExpiringSoon
-			state.LastSSLCheck = now
-
-			if sslInfo.ExpiringSoon {
-				logger.Infof("[%s] ⚠️  SSL certificate expiring in %d days", state.Endpoint.Name, sslInfo.DaysToExpiry)
-			}
-
-			logger.Infof("[%s] SSL certificate validated (expires: %s, days remaining: %d)",
-				state.Endpoint.Name, sslInfo.Expiry.Format("2006-01-02"), sslInfo.DaysToExpiry)
-		}
-	}
-
 	logger.Infof("[%s] ✓ Health check passed (status: %s, response time: %v)",
 		state.Endpoint.Name, state.Status, responseTime)
 
 	// Send recovery alert if endpoint recovered
 	if previousStatus == structs.StatusUnhealthy && state.Status == structs.StatusHealthy {
 		state.LastStatusChange = time.Now()
-		if !state.AlertsSuppressed {
-			m.alerter.SendRecoveryAlert(state.Endpoint, state.EndpointState)
+		// Only close incidents that were announced, even if that was before a restart
+		if !state.AlertsSuppressed && state.FailureAlertSent {
+			m.alerterFor(state.Endpoint.ProjectID).SendRecoveryAlert(state.Endpoint, state.EndpointState)
 		}
+		state.FailureAlertSent = false
+		state.Acknowledged = false
+	}
+
+	if previousStatus != state.Status {
+		m.emitStatusChange(state, previousStatus)
 	}
 
 	// Save health check record to database
@@ -618,35 +1430,72 @@ func (m *Monitor) handleCheckSuccess(state *MonitorState, responseTime time.Dura
 }
 
 // handleCheckFailure handles a failed health check
-func (m *Monitor) handleCheckFailure(state *MonitorState, errorMsg string, responseTime time.Duration) {
+func (m *Monitor) handleCheckFailure(state *MonitorState, kind structs.FailureKind, errorMsg string, responseTime time.Duration) {
 	state.mu.Lock()
 	defer state.mu.Unlock()
 
+	state.RateLimited = false
+	m.recordFailure(state, kind, errorMsg, responseTime)
+}
+
+// recordFailure applies a failed check to the endpoint state. Caller must hold the state lock.
+func (m *Monitor) recordFailure(state *MonitorState, kind structs.FailureKind, errorMsg string, responseTime time.Duration) {
 	state.LastCheck = time.Now()
-	state.NextCheck = time.Now().Add(state.CheckInterval)
+	state.NextCheck = m.nextCheckAfter(state, state.LastCheck)
 	state.ResponseTime = responseTime
 	state.ConsecutiveSuccesses = 0
+	state.HealthySince = time.Time{}
 	state.ConsecutiveFailures++
+	if state.ConsecutiveFailures == 1 {
+		state.FailingSince = state.LastCheck
+	}
+	if kind == structs.FailureNetwork {
+		state.ConsecutiveNetwork++
+	} else {
+		state.ConsecutiveApp++
+	}
 	state.LastError = errorMsg
+	state.LastFailureKind = kind
 
 	previousStatus := state.Status
 
 	// Update status if threshold is met
-	if state.ConsecutiveFailures >= state.Endpoint.FailureThreshold {
+	if failureThresholdReached(state.EndpointState) {
 		state.Status = structs.StatusUnhealthy
 	}
 
-	logger.Infof("[%s] ✗ Health check failed (status: %s, error: %s)",
-		state.Endpoint.Name, state.Status, errorMsg)
+	logger.Infof("[%s] ✗ Health check failed (status: %s, %s error: %s)",
+		state.Endpoint.Name, state.Status, kind, errorMsg)
 
 	// Send alert if endpoint became unhealthy
 	if previousStatus != structs.StatusUnhealthy && state.Status == structs.StatusUnhealthy {
 		state.LastStatusChange = time.Now()
-		if !state.AlertsSuppressed {
-			m.alerter.SendFailureAlert(state.Endpoint, state.EndpointState)
+	}
+
+	// Alert once per incident; the flag is persisted so restarts don't re-fire.
+	// A new endpoint that never passed is left alone until its grace period is over.
+	if state.Status == structs.StatusUnhealthy && !state.FailureAlertSent && !state.AlertsSuppressed {
+		if state.InGracePeriod(m.config.NewEndpointGrace.Duration, state.LastCheck) {
+			logger.Infof("[%s] New endpoint still in its grace period, failure alert held back", state.Endpoint.Name)
+		} else {
+			m.alerterFor(state.Endpoint.ProjectID).SendFailureAlert(state.Endpoint, state.EndpointState)
+			state.FailureAlertSent = true
 		}
 	}
 
+	if previousStatus != state.Status {
+		m.emitStatusChange(state, previousStatus)
+	}
+
+	// Stretch the interval for endpoints that have been down for a long time
+	if backoff := nextBackoffInterval(state.EndpointState); backoff > 0 {
+		if backoff != state.BackoffInterval {
+			logger.Infof("[%s] Backing off, next check in %v", state.Endpoint.Name, backoff)
+		}
+		state.BackoffInterval = backoff
+		state.NextCheck = m.nextCheckNotBefore(state, state.LastCheck.Add(backoff))
+	}
+
 	// Save health check record to database
 	m.saveHealthRecord(state, errorMsg)
 }
@@ -663,11 +1512,64 @@ func (m *Monitor) saveHealthRecord(state *MonitorState, errorMsg string) {
 		Status:       string(state.Status),
 		ResponseTime: state.ResponseTime,
 		Error:        errorMsg,
+		FailureKind:  state.LastFailureKind,
+		RateLimited:  state.RateLimited,
+	}
+	if state.RateLimited {
+		record.StatusCode = http.StatusTooManyRequests
+	}
+	record.Steps = state.steps
+	state.steps = nil
+
+	records := state.sampleHistory(record)
+	if len(records) == 0 {
+		// A held-back success keeps no screenshot
+		state.screenshot = nil
+	}
+	if state.screenshot != nil {
+		record.Screenshot = true
+		if err := m.db.SaveScreenshot(state.ID, record.Timestamp, state.screenshot, errorMsg != ""); err != nil {
+			logger.Errorf("[%s] Failed to save screenshot: %v", state.Endpoint.Name, err)
+			record.Screenshot = false
+		}
+		state.screenshot = nil
 	}
 
-	if err := m.db.SaveHealthCheckRecord(record); err != nil {
-		logger.Errorf("Error saving health check record: %v", err)
+	// Serve recent reads from memory and write history in the background
+	for _, record := range records {
+		if state.recent != nil {
+			state.recent.add(*record)
+		}
+		m.history.enqueue(record)
 	}
+
+	recordSLACheck(state)
+	m.saveStateSnapshot(state)
+	m.emitCheck(state)
+}
+
+// saveStateSnapshot persists the endpoint state so it survives restarts.
+// Caller must hold the state lock.
+func (m *Monitor) saveStateSnapshot(state *MonitorState) {
+	m.touch()
+	if m.db == nil {
+		return
+	}
+
+	if err := m.db.SaveEndpointState(state.ID, state.Snapshot()); err != nil {
+		atomic.AddUint64(&m.stateWriteErrors, 1)
+		logger.Errorf("Error saving endpoint state: %v", err)
+	}
+}
+
+// touch records that endpoint state changed
+func (m *Monitor) touch() {
+	atomic.AddUint64(&m.version, 1)
+}
+
+// StateVersion returns a counter that changes whenever endpoint state does
+func (m *Monitor) StateVersion() uint64 {
+	return atomic.LoadUint64(&m.version)
 }
 
 // GetStatus returns the current status of all endpoints
@@ -684,54 +1586,72 @@ func (m *Monitor) GetStatus() map[string]*structs.EndpointState {
 	return status
 }
 
-// startSSLExpirySummaryScheduler schedules daily SSL expiry summary at configured time
-func (m *Monitor) startSSLExpirySummaryScheduler() {
-	loc, err := time.LoadLocation("Asia/Kolkata")
-	if err != nil {
-		loc = time.FixedZone("IST", 5*60*60+30*60)
-	}
+// GetEndpointState returns the current state of a single endpoint
+func (m *Monitor) GetEndpointState(id string) (*structs.EndpointState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	// Parse configured time (format: HH:MM)
-	var hour, minute int
-	_, err = fmt.Sscanf(m.config.SSLSummaryTime, "%d:%d", &hour, &minute)
-	if err != nil {
-		logger.Errorf("Invalid SSL summary time format '%s', using default 09:30", m.config.SSLSummaryTime)
-		hour, minute = 9, 30
+	state, ok := m.states[id]
+	if !ok {
+		return nil, false
 	}
+	return state.EndpointState, true
+}
 
-	for {
-		now := time.Now().In(loc)
-
-		// Calculate next scheduled time
-		next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
-		if now.After(next) {
-			// If it's already past the scheduled time today, schedule for tomorrow
-			next = next.Add(24 * time.Hour)
-		}
-
-		duration := next.Sub(now)
-		logger.Infof("Next SSL expiry summary scheduled at: %s (in %v)", next.Format("02 Jan 2006 03:04 PM"), duration.Round(time.Minute))
-
-		select {
-		case <-m.ctx.Done():
-			return
-		case <-time.After(duration):
-			// Send SSL expiry summary
-			m.sendSSLExpirySummary()
-		}
-	}
+// GetPoolStats returns connection reuse statistics for the shared HTTP transports
+func (m *Monitor) GetPoolStats() PoolStats {
+	return m.pool.Stats()
 }
 
-// sendSSLExpirySummary collects and sends SSL expiry summary
-func (m *Monitor) sendSSLExpirySummary() {
-	expiringCerts := m.getExpiringCertificates()
+// startSSLExpirySummaryScheduler schedules the SSL expiry summary (daily, weekly or cron) at configured time
+func (m *Monitor) startSSLExpirySummaryScheduler() {
+	schedule := parseSummarySchedule("SSL expiry summary", m.config.SSLSummarySchedule, m.config.SSLSummaryTime, m.config.SSLSummaryWeekday, m.loc)
+	m.runSchedule(schedule, func() {
+		// Send SSL expiry summary
+		m.sendSSLExpirySummary()
+	})
+}
 
-	if len(expiringCerts) > 0 {
-		logger.Infof("Sending SSL expiry summary for %d certificates", len(expiringCerts))
-		m.alerter.SendSSLExpirySummary(expiringCerts)
+// sendSSLExpirySummary collects and sends SSL expiry summary, returning the number of certificates reported
+func (m *Monitor) sendSSLExpirySummary() int {
+	expired, failed := m.getProblemCertificates()
+	summary := SSLSummary{Expiring: m.getExpiringCertificates(), Expired: expired, Failed: failed}
+
+	if summary.Count() > 0 {
+		logger.Infof("Sending SSL expiry summary for %d certificates (%d expiring, %d expired, %d check failed)",
+			summary.Count(), len(summary.Expiring), len(summary.Expired), len(summary.Failed))
+
+		// Each project receives only its own certificates, in the same order
+		byAlerter := make(map[*Alerter]*SSLSummary)
+		var order []*Alerter
+		project := func(cert SSLExpiryInfo) *SSLSummary {
+			alerter := m.alerterFor(cert.ProjectID)
+			if _, ok := byAlerter[alerter]; !ok {
+				order = append(order, alerter)
+				byAlerter[alerter] = &SSLSummary{}
+			}
+			return byAlerter[alerter]
+		}
+		for _, cert := range summary.Expiring {
+			s := project(cert)
+			s.Expiring = append(s.Expiring, cert)
+		}
+		for _, cert := range summary.Expired {
+			s := project(cert)
+			s.Expired = append(s.Expired, cert)
+		}
+		for _, cert := range summary.Failed {
+			s := project(cert)
+			s.Failed = append(s.Failed, cert)
+		}
+		for _, alerter := range order {
+			alerter.SendSSLExpirySummary(*byAlerter[alerter])
+		}
 	} else {
 		logger.Info("No expiring SSL certificates to report in daily summary")
 	}
+
+	return summary.Count()
 }
 
 // getExpiringCertificates returns a list of expiring SSL certificates sorted by days remaining (ascending)
@@ -739,21 +1659,24 @@ func (m *Monitor) getExpiringCertificates() []SSLExpiryInfo {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	loc, _ := time.LoadLocation("Asia/Kolkata")
+	loc := m.loc
 	now := time.Now().In(loc)
 	var expiringCerts []SSLExpiryInfo
 
 	for _, state := range m.states {
 		state.mu.RLock()
-		if state.SSLExpiringSoon && !state.SSLCertExpiry.IsZero() {
+		// Certificates that expired since their last check are listed as expired instead
+		if state.SSLExpiringSoon && !state.SSLCertExpiry.IsZero() && !state.SSLPaused && state.SSLCertExpiry.After(now) {
 
 			expiry := state.SSLCertExpiry.In(loc)
 			daysLeft := int(expiry.Sub(now).Hours() / 24)
 			expiringCerts = append(expiringCerts, SSLExpiryInfo{
+				EndpointID:   state.ID,
 				EndpointName: state.Endpoint.Name,
 				URL:          state.Endpoint.URL,
 				ExpiryDate:   expiry,
 				DaysToExpiry: daysLeft,
+				ProjectID:    state.Endpoint.ProjectID,
 			})
 		}
 		state.mu.RUnlock()
@@ -771,31 +1694,66 @@ func (m *Monitor) getExpiringCertificates() []SSLExpiryInfo {
 	return expiringCerts
 }
 
-// forceSSLCheck runs SSL validation immediately (ignores 24h rule)
-func (m *Monitor) forceSSLCheck(state *MonitorState) {
-	state.mu.Lock()
-	defer state.mu.Unlock()
+// getProblemCertificates returns certificates that have already expired, most recently expired
+// first, and those whose last check could not read them, by endpoint name
+func (m *Monitor) getProblemCertificates() (expired, failed []SSLExpiryInfo) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	sslInfo := CheckSSLCertificate(state.Endpoint.URL, m.config.SSLExpiryWarningDays)
-	if !sslInfo.IsHTTPS {
-		return
+	loc := m.loc
+	now := time.Now().In(loc)
+	for _, state := range m.states {
+		state.mu.RLock()
+		if state.SSLPaused || state.LastSSLCheck.IsZero() {
+			state.mu.RUnlock()
+			continue
+		}
+		cert := SSLExpiryInfo{
+			EndpointID:   state.ID,
+			EndpointName: state.Endpoint.Name,
+			URL:          state.Endpoint.URL,
+			ProjectID:    state.Endpoint.ProjectID,
+			Error:        state.SSLError,
+		}
+		switch {
+		case state.SSLError != "":
+			failed = append(failed, cert)
+		case !state.SSLCertExpiry.IsZero() && state.SSLCertExpiry.Before(now):
+			cert.ExpiryDate = state.SSLCertExpiry.In(loc)
+			cert.DaysToExpiry = int(cert.ExpiryDate.Sub(now).Hours() / 24)
+			expired = append(expired, cert)
+		}
+		state.mu.RUnlock()
 	}
 
-	state.SSLCertExpiry = sslInfo.Expiry
-	state.DaysToExpiry = sslInfo.DaysToExpiry
-	state.SSLExpiringSoon = sslInfo.ExpiringSoon
-	state.LastSSLCheck = time.Now()
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpiryDate.After(expired[j].ExpiryDate) })
+	sort.Slice(failed, func(i, j int) bool { return failed[i].EndpointName < failed[j].EndpointName })
+	return expired, failed
+}
 
-	if sslInfo.ExpiringSoon {
-		logger.Infof("[%s] ⚠️ SSL expiring in %d days",
-			state.Endpoint.Name, sslInfo.DaysToExpiry)
+// forceSSLCheck runs SSL validation immediately (ignores 24h rule)
+func (m *Monitor) forceSSLCheck(state *MonitorState) {
+	m.refreshSSL(state, true)
+}
+
+// TriggerSSLRecheckFor forces SSL validation for a single endpoint
+func (m *Monitor) TriggerSSLRecheckFor(id string) error {
+	m.mu.RLock()
+	state, ok := m.states[id]
+	m.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("endpoint not found: %s", id)
 	}
 
-	logger.Infof("[%s] 🔁 SSL revalidated (expires: %s, days remaining: %d)",
-		state.Endpoint.Name,
-		sslInfo.Expiry.Format("2006-01-02"),
-		sslInfo.DaysToExpiry,
-	)
+	logger.Infof("🔄 Manual SSL recheck started for endpoint: %s", id)
+	go m.forceSSLCheck(state)
+	return nil
+}
+
+// SendSSLExpirySummaryNow sends the SSL expiry summary immediately and returns the number of certificates reported
+func (m *Monitor) SendSSLExpirySummaryNow() int {
+	return m.sendSSLExpirySummary()
 }
 
 // TriggerSSLRecheck forces SSL validation for all endpoints
@@ -806,6 +1764,11 @@ func (m *Monitor) TriggerSSLRecheck() {
 	logger.Infof("🔄 Manual SSL recheck started for all endpoints")
 
 	for _, state := range m.states {
-		go m.forceSSLCheck(state)
+		state.mu.RLock()
+		paused := state.SSLPaused
+		state.mu.RUnlock()
+		if !paused {
+			go m.forceSSLCheck(state)
+		}
 	}
 }
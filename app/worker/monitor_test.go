@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/config"
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// newTestMonitor builds an unstarted monitor on a fresh database with the default config
+func newTestMonitor(t testing.TB) *Monitor {
+	t.Helper()
+	logger.Init()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := models.NewDatabase(filepath.Join(dir, "sitewatch.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewMonitor(cfg, db)
+}
+
+// addTestEndpoint adds a health-checked endpoint for url and returns its state
+func addTestEndpoint(t testing.TB, m *Monitor, id, url string) *MonitorState {
+	t.Helper()
+	stored := &structs.StoredEndpoint{
+		ID:             id,
+		Name:           id,
+		URL:            url,
+		Method:         http.MethodGet,
+		Timeout:        30 * time.Second,
+		ExpectedStatus: http.StatusOK,
+		Enabled:        true,
+		MonitorHealth:  true,
+	}
+	if err := m.AddEndpoint(stored); err != nil {
+		t.Fatal(err)
+	}
+	return m.states[id]
+}
+
+func TestRunChecksDoesNotWaitForHangingEndpoint(t *testing.T) {
+	m := newTestMonitor(t)
+
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fast.Close()
+	defer hanging.Close()
+
+	slowState := addTestEndpoint(t, m, "hanging", hanging.URL)
+	fastState := addTestEndpoint(t, m, "fast", fast.URL)
+	defer func() {
+		// Let the hanging check finish before its server and the database close
+		close(release)
+		m.wg.Wait()
+	}()
+
+	const wait = 500 * time.Millisecond
+	start := time.Now()
+	m.runChecks([]*MonitorState{slowState, fastState}, wait)
+	if elapsed := time.Since(start); elapsed > wait+time.Second {
+		t.Fatalf("runChecks took %v, want at most about %v", elapsed, wait)
+	}
+
+	fastState.mu.RLock()
+	lastCheck, lastSuccess, lastError := fastState.LastCheck, fastState.LastSuccess, fastState.LastError
+	fastState.mu.RUnlock()
+	if lastCheck.IsZero() {
+		t.Fatal("fast endpoint was not checked in the round")
+	}
+	if lastSuccess.IsZero() {
+		t.Fatalf("fast endpoint check failed: %s", lastError)
+	}
+
+	if !m.checkRunning("hanging") {
+		t.Fatal("hanging endpoint's check should still be running after the round")
+	}
+}
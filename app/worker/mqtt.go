@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// mqttQueueSize bounds messages buffered while the broker is unreachable
+const mqttQueueSize = 1024
+
+// mqttMessage is a message waiting to be published
+type mqttMessage struct {
+	topic   string
+	payload []byte
+	retain  bool
+}
+
+// MQTTPublisher publishes endpoint status changes and check results to an MQTT broker
+type MQTTPublisher struct {
+	config   structs.MQTTConfig
+	queue    chan mqttMessage
+	snapshot func() []structs.StatusEvent
+}
+
+// NewMQTTPublisher creates a publisher; snapshot supplies current statuses to retain on connect
+func NewMQTTPublisher(config structs.MQTTConfig, snapshot func() []structs.StatusEvent) *MQTTPublisher {
+	return &MQTTPublisher{
+		config:   config,
+		queue:    make(chan mqttMessage, mqttQueueSize),
+		snapshot: snapshot,
+	}
+}
+
+// PublishStatus queues a retained last-status message for the endpoint
+func (p *MQTTPublisher) PublishStatus(event structs.StatusEvent) {
+	p.enqueue(p.topic(event.EndpointID, "status"), event, true)
+}
+
+// PublishCheck queues a check result when publish_checks is enabled
+func (p *MQTTPublisher) PublishCheck(event structs.CheckEvent) {
+	if !p.config.PublishChecks {
+		return
+	}
+	p.enqueue(p.topic(event.EndpointID, "check"), event, false)
+}
+
+// topic builds <prefix>/<endpoint-id>/<kind>, replacing characters MQTT reserves
+func (p *MQTTPublisher) topic(endpointID, kind string) string {
+	id := strings.NewReplacer("/", "_", "+", "_", "#", "_").Replace(endpointID)
+	return p.config.TopicPrefix + "/" + id + "/" + kind
+}
+
+// enqueue marshals and queues a message without blocking the check path
+func (p *MQTTPublisher) enqueue(topic string, event interface{}, retain bool) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("Failed to marshal MQTT payload: %v", err)
+		return
+	}
+
+	select {
+	case p.queue <- mqttMessage{topic: topic, payload: payload, retain: retain}:
+	default:
+		logger.Debugf("MQTT queue full, dropping message for %s", topic)
+	}
+}
+
+// Run keeps a broker connection open and drains the queue until ctx is cancelled
+func (p *MQTTPublisher) Run(ctx context.Context) {
+	retry := 5 * time.Second
+	for {
+		client, err := utils.DialMQTT(p.config.Broker, p.config.ClientID, p.config.Username, p.config.Password,
+			p.config.KeepAlive.Duration, 10*time.Second)
+		if err != nil {
+			logger.Errorf("MQTT connect to %s failed: %v (retrying in %v)", p.config.Broker, err, retry)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retry):
+			}
+			if retry < 5*time.Minute {
+				retry *= 2
+			}
+			continue
+		}
+
+		logger.Infof("Connected to MQTT broker %s", p.config.Broker)
+		retry = 5 * time.Second
+
+		if !p.serve(ctx, client) {
+			return
+		}
+		logger.Errorf("MQTT connection lost: %v", client.Err())
+	}
+}
+
+// serve publishes on one connection; it returns false once ctx is cancelled
+func (p *MQTTPublisher) serve(ctx context.Context, client *utils.MQTTClient) bool {
+	// Refresh retained statuses so new subscribers see every endpoint
+	for _, event := range p.snapshot() {
+		p.PublishStatus(event)
+	}
+
+	keepAlive := time.NewTicker(p.config.KeepAlive.Duration / 2)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			client.Close()
+			return false
+		case <-client.Done():
+			return true
+		case <-keepAlive.C:
+			if err := client.Ping(); err != nil {
+				return true
+			}
+		case msg := <-p.queue:
+			if err := client.Publish(msg.topic, msg.payload, msg.retain); err != nil {
+				// Keep retained statuses for the next connection
+				if msg.retain {
+					p.enqueue(msg.topic, json.RawMessage(msg.payload), true)
+				}
+				return true
+			}
+		}
+	}
+}
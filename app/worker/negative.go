@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// handleNegativeResult applies the outcome of a check on a negative endpoint, such as a
+// decommissioned host. It is healthy while nothing answers, or while it answers with its
+// expected_status (e.g. 410 Gone); any other answer means the old service is back.
+func (m *Monitor) handleNegativeResult(state *MonitorState, endpoint structs.Endpoint, resp *http.Response, err error, responseTime time.Duration) {
+	if err != nil {
+		logger.Debugf("[%s] No answer, as expected: %v", endpoint.Name, err)
+		m.handleCheckSuccess(state, responseTime)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if endpoint.ExpectedStatus != 0 && resp.StatusCode == endpoint.ExpectedStatus {
+		m.handleCheckSuccess(state, responseTime)
+		return
+	}
+
+	errorMsg := fmt.Sprintf("endpoint answered with status code %d, expected no answer", resp.StatusCode)
+	if endpoint.ExpectedStatus != 0 {
+		errorMsg = fmt.Sprintf("endpoint answered with status code %d, expected %d or no answer", resp.StatusCode, endpoint.ExpectedStatus)
+	}
+	m.handleCheckFailure(state, structs.FailureApplication, errorMsg, responseTime)
+}
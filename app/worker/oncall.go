@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// OnCallShift is who is on call for a schedule now and who takes over at the next handoff
+type OnCallShift struct {
+	UserID      string    `json:"user_id"`
+	NextUserID  string    `json:"next_user_id"`
+	NextHandoff time.Time `json:"next_handoff"`
+}
+
+// onCallRotation returns the weekly handoff schedule of an on-call schedule
+func onCallRotation(schedule *structs.OnCallSchedule) *summarySchedule {
+	loc, err := utils.LoadTimezone(schedule.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return parseSummarySchedule("on-call "+schedule.Name, "weekly", schedule.HandoffTime, schedule.HandoffDay, loc)
+}
+
+// PreviousOnCallHandoff returns the most recent handoff at or before now
+func PreviousOnCallHandoff(schedule *structs.OnCallSchedule, now time.Time) time.Time {
+	next := onCallRotation(schedule).next(now)
+	if next.After(now) {
+		return next.AddDate(0, 0, -7)
+	}
+	return next
+}
+
+// CurrentOnCall returns the shift in effect at now; ok is false for a schedule without participants
+func CurrentOnCall(schedule *structs.OnCallSchedule, now time.Time) (OnCallShift, bool) {
+	if len(schedule.Participants) == 0 {
+		return OnCallShift{}, false
+	}
+
+	rotation := onCallRotation(schedule)
+	previous := PreviousOnCallHandoff(schedule, now)
+
+	// Count calendar days so DST changes don't shift the rotation
+	date := func(t time.Time) time.Time {
+		y, m, d := t.In(rotation.loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	days := int(date(previous).Sub(date(schedule.Start)).Hours() / 24)
+	weeks := days / 7
+	if days < 0 && days%7 != 0 {
+		weeks--
+	}
+
+	n := len(schedule.Participants)
+	index := (weeks%n + n) % n
+	return OnCallShift{
+		UserID:      schedule.Participants[index],
+		NextUserID:  schedule.Participants[(index+1)%n],
+		NextHandoff: rotation.next(now.Add(time.Second)),
+	}, true
+}
+
+// ReloadOnCall reloads on-call schedules from the database and updates every alerter
+func (m *Monitor) ReloadOnCall() {
+	schedules, err := m.db.GetAllOnCallSchedules()
+	if err != nil {
+		logger.Errorf("Failed to load on-call schedules: %v", err)
+		return
+	}
+
+	m.projectMu.Lock()
+	m.onCall = schedules
+	m.projectMu.Unlock()
+
+	for _, alerter := range m.allAlerters() {
+		alerter.SetOnCallSchedules(schedules)
+	}
+
+	logger.Infof("Loaded %d on-call schedules", len(schedules))
+}
+
+// currentOnCallSchedules returns the loaded on-call schedules
+func (m *Monitor) currentOnCallSchedules() []*structs.OnCallSchedule {
+	m.projectMu.RLock()
+	defer m.projectMu.RUnlock()
+	return m.onCall
+}
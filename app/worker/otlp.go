@@ -0,0 +1,350 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// otlpQueueSize bounds alert events waiting to be exported
+	otlpQueueSize = 1000
+	// otlpLogInterval is how often queued alert events are posted as log records
+	otlpLogInterval = 5 * time.Second
+	// otlpScope names the instrumentation scope of everything SiteWatch exports
+	otlpScope = "sitewatch"
+)
+
+// otlpResolutionBuckets are the histogram bounds, in seconds, of the time from firing to resolving
+var otlpResolutionBuckets = []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400}
+
+// alertEvent is an alert that fired or resolved, as exported over OTLP
+type alertEvent struct {
+	time        time.Time
+	alertID     string
+	alertType   string
+	name        string
+	severity    structs.Severity
+	fingerprint string
+	resolved    bool
+	subject     string
+	endpoint    structs.Endpoint
+	endpointID  string
+	// resolution is the time since the matching alert fired, for resolved events
+	resolution time.Duration
+}
+
+// alertSeries identifies one counter of fired or resolved alerts
+type alertSeries struct {
+	name     string
+	state    string
+	severity string
+	project  string
+}
+
+// key orders series so exports list them the same way every time
+func (s alertSeries) key() string {
+	return s.project + "\x00" + s.name + "\x00" + s.state + "\x00" + s.severity
+}
+
+// OTLPExporter exports alert events to an OpenTelemetry collector over OTLP/HTTP with JSON
+// encoding: a log record for every alert fired or resolved, and metrics counting alerts and
+// how long they took to resolve
+type OTLPExporter struct {
+	config structs.OTLPConfig
+	queue  chan alertEvent
+	client *http.Client
+	start  time.Time
+
+	mu          sync.Mutex
+	firing      map[string]time.Time
+	counts      map[alertSeries]uint64
+	resolutions []uint64
+	resolvedSum float64
+	resolved    uint64
+}
+
+// NewOTLPExporter creates an exporter for the configured collector
+func NewOTLPExporter(config structs.OTLPConfig) *OTLPExporter {
+	return &OTLPExporter{
+		config:      config,
+		queue:       make(chan alertEvent, otlpQueueSize),
+		client:      &http.Client{Timeout: 10 * time.Second},
+		start:       time.Now(),
+		firing:      make(map[string]time.Time),
+		counts:      make(map[alertSeries]uint64),
+		resolutions: make([]uint64, len(otlpResolutionBuckets)+1),
+	}
+}
+
+// RecordAlert counts an alert and queues its log record without blocking the alert path
+func (e *OTLPExporter) RecordAlert(event alertEvent) {
+	e.mu.Lock()
+	if event.resolved {
+		if fired, ok := e.firing[event.fingerprint]; ok {
+			delete(e.firing, event.fingerprint)
+			event.resolution = event.time.Sub(fired)
+			seconds := event.resolution.Seconds()
+			e.resolutions[sort.SearchFloat64s(otlpResolutionBuckets, seconds)]++
+			e.resolvedSum += seconds
+			e.resolved++
+		}
+	} else if _, ok := e.firing[event.fingerprint]; !ok {
+		e.firing[event.fingerprint] = event.time
+	}
+	state := "firing"
+	if event.resolved {
+		state = "resolved"
+	}
+	e.counts[alertSeries{name: event.name, state: state, severity: string(event.severity), project: event.endpoint.ProjectID}]++
+	e.mu.Unlock()
+
+	select {
+	case e.queue <- event:
+	default:
+		logger.Debugf("OTLP queue full, dropping alert event %s", event.alertID)
+	}
+}
+
+// Run posts queued log records every few seconds and metrics every metric_interval until
+// ctx is cancelled, then exports what is left
+func (e *OTLPExporter) Run(ctx context.Context) {
+	logTicker := time.NewTicker(otlpLogInterval)
+	defer logTicker.Stop()
+	metricTicker := time.NewTicker(e.config.MetricInterval.Duration)
+	defer metricTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.exportLogs()
+			e.exportMetrics()
+			return
+		case <-logTicker.C:
+			e.exportLogs()
+		case <-metricTicker.C:
+			e.exportMetrics()
+		}
+	}
+}
+
+// exportLogs posts every queued alert event as one batch of log records
+func (e *OTLPExporter) exportLogs() {
+	var records []map[string]interface{}
+	for {
+		select {
+		case event := <-e.queue:
+			records = append(records, e.logRecord(event))
+			continue
+		default:
+		}
+		break
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"resourceLogs": []map[string]interface{}{{
+			"resource": e.resource(),
+			"scopeLogs": []map[string]interface{}{{
+				"scope":      map[string]interface{}{"name": otlpScope},
+				"logRecords": records,
+			}},
+		}},
+	}
+	if err := e.post("/v1/logs", payload); err != nil {
+		logger.Errorf("OTLP dropped %d alert log records: %v", len(records), err)
+	}
+}
+
+// logRecord renders an alert event as an OTLP log record
+func (e *OTLPExporter) logRecord(event alertEvent) map[string]interface{} {
+	number, text := otlpSeverity(event.severity)
+	state := "firing"
+	if event.resolved {
+		state = "resolved"
+	}
+
+	attributes := []map[string]interface{}{
+		otlpString("alert.id", event.alertID),
+		otlpString("alert.type", event.alertType),
+		otlpString("alert.name", event.name),
+		otlpString("alert.state", state),
+		otlpString("alert.severity", string(event.severity)),
+		otlpString("alert.fingerprint", event.fingerprint),
+		otlpString("endpoint.id", event.endpointID),
+		otlpString("endpoint.name", event.endpoint.Name),
+		otlpString("endpoint.url", event.endpoint.URL),
+		otlpString("endpoint.priority", string(event.endpoint.Priority)),
+	}
+	if event.endpoint.ProjectID != "" {
+		attributes = append(attributes, otlpString("project.id", event.endpoint.ProjectID))
+	}
+	if event.resolution > 0 {
+		attributes = append(attributes, map[string]interface{}{
+			"key":   "alert.resolution_seconds",
+			"value": map[string]interface{}{"doubleValue": event.resolution.Seconds()},
+		})
+	}
+	keys := make([]string, 0, len(event.endpoint.Labels))
+	for key := range event.endpoint.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attributes = append(attributes, otlpString("endpoint.label."+key, event.endpoint.Labels[key]))
+	}
+
+	nanos := strconv.FormatInt(event.time.UnixNano(), 10)
+	return map[string]interface{}{
+		"timeUnixNano":         nanos,
+		"observedTimeUnixNano": nanos,
+		"severityNumber":       number,
+		"severityText":         text,
+		"body":                 map[string]interface{}{"stringValue": event.subject},
+		"attributes":           attributes,
+	}
+}
+
+// exportMetrics posts the cumulative alert counters and resolution time histogram
+func (e *OTLPExporter) exportMetrics() {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	start := strconv.FormatInt(e.start.UnixNano(), 10)
+
+	e.mu.Lock()
+	series := make([]alertSeries, 0, len(e.counts))
+	for s := range e.counts {
+		series = append(series, s)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].key() < series[j].key() })
+	points := make([]map[string]interface{}, 0, len(series))
+	for _, s := range series {
+		attributes := []map[string]interface{}{
+			otlpString("alert.name", s.name),
+			otlpString("alert.state", s.state),
+			otlpString("alert.severity", s.severity),
+		}
+		if s.project != "" {
+			attributes = append(attributes, otlpString("project.id", s.project))
+		}
+		points = append(points, map[string]interface{}{
+			"attributes":        attributes,
+			"startTimeUnixNano": start,
+			"timeUnixNano":      now,
+			"asInt":             strconv.FormatUint(e.counts[s], 10),
+		})
+	}
+	bucketCounts := make([]string, len(e.resolutions))
+	for i, count := range e.resolutions {
+		bucketCounts[i] = strconv.FormatUint(count, 10)
+	}
+	histogram := map[string]interface{}{
+		"startTimeUnixNano": start,
+		"timeUnixNano":      now,
+		"count":             strconv.FormatUint(e.resolved, 10),
+		"sum":               e.resolvedSum,
+		"bucketCounts":      bucketCounts,
+		"explicitBounds":    otlpResolutionBuckets,
+	}
+	e.mu.Unlock()
+
+	if len(points) == 0 {
+		return
+	}
+
+	// Temporality 2 is cumulative: totals since the exporter started
+	payload := map[string]interface{}{
+		"resourceMetrics": []map[string]interface{}{{
+			"resource": e.resource(),
+			"scopeMetrics": []map[string]interface{}{{
+				"scope": map[string]interface{}{"name": otlpScope},
+				"metrics": []map[string]interface{}{
+					{
+						"name":        "sitewatch.alerts",
+						"description": "Alerts fired and resolved.",
+						"unit":        "{alert}",
+						"sum": map[string]interface{}{
+							"dataPoints":             points,
+							"aggregationTemporality": 2,
+							"isMonotonic":            true,
+						},
+					},
+					{
+						"name":        "sitewatch.alert.resolution_time",
+						"description": "Time from an alert firing to it resolving.",
+						"unit":        "s",
+						"histogram": map[string]interface{}{
+							"dataPoints":             []map[string]interface{}{histogram},
+							"aggregationTemporality": 2,
+						},
+					},
+				},
+			}},
+		}},
+	}
+	if err := e.post("/v1/metrics", payload); err != nil {
+		logger.Errorf("OTLP metrics export failed: %v", err)
+	}
+}
+
+// resource describes this SiteWatch instance
+func (e *OTLPExporter) resource() map[string]interface{} {
+	return map[string]interface{}{
+		"attributes": []map[string]interface{}{otlpString("service.name", e.config.ServiceName)},
+	}
+}
+
+// post sends one OTLP/HTTP JSON request to the collector
+func (e *OTLPExporter) post(path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(e.config.Endpoint, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// otlpString builds a string key/value attribute
+func otlpString(key, value string) map[string]interface{} {
+	return map[string]interface{}{"key": key, "value": map[string]interface{}{"stringValue": value}}
+}
+
+// otlpSeverity maps an alert severity to an OpenTelemetry severity number and text
+func otlpSeverity(severity structs.Severity) (int, string) {
+	switch severity {
+	case structs.SeverityCritical:
+		return 17, "ERROR"
+	case structs.SeverityWarning:
+		return 13, "WARN"
+	default:
+		return 9, "INFO"
+	}
+}
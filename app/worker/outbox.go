@@ -0,0 +1,251 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+const (
+	// outboxMaxAttempts is how many deliveries are tried before a message is dead-lettered
+	outboxMaxAttempts = 8
+	// outboxBaseDelay is the wait before the first retry, doubling after each failure
+	outboxBaseDelay = 10 * time.Second
+	// outboxMaxDelay caps the wait between retries
+	outboxMaxDelay = 10 * time.Minute
+	// outboxPollInterval is how often the outbox looks for messages due for a retry
+	outboxPollInterval = 5 * time.Second
+	// outboxTimeout bounds a single delivery attempt when alert_http sets no timeout
+	outboxTimeout = 15 * time.Second
+)
+
+// Outbox persists outgoing notifications and delivers them with retries, so a receiver
+// outage or a restart does not drop alerts
+type Outbox struct {
+	db      *models.Database
+	client  *http.Client
+	resolve func(projectID string) *Alerter
+	wake    chan struct{}
+
+	inflight map[string]bool
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+
+	// delivered, failed and dbErrors count outcomes for self-monitoring
+	delivered uint64
+	failed    uint64
+	dbErrors  uint64
+}
+
+// NewOutbox creates an outbox delivering through client; resolve returns the alerter whose
+// email settings deliver a project's mail
+func NewOutbox(db *models.Database, client *http.Client, resolve func(projectID string) *Alerter) *Outbox {
+	return &Outbox{
+		db:       db,
+		client:   client,
+		resolve:  resolve,
+		wake:     make(chan struct{}, 1),
+		inflight: make(map[string]bool),
+	}
+}
+
+// Enqueue persists a message and wakes the dispatcher to deliver it
+func (o *Outbox) Enqueue(message *structs.OutboxMessage) {
+	message.Status = structs.OutboxPending
+	message.NextAttempt = time.Now()
+
+	if err := o.db.SaveOutboxMessage(message); err != nil {
+		// Better an unpersisted attempt than a dropped alert
+		atomic.AddUint64(&o.dbErrors, 1)
+		logger.Errorf("Failed to queue %s: %v", message.Description, err)
+		o.wg.Add(1)
+		go func() {
+			defer o.wg.Done()
+			o.attempt(message)
+		}()
+		return
+	}
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due messages until ctx is done, then waits for deliveries in progress.
+// Messages left pending by a previous run are picked up immediately.
+func (o *Outbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	o.dispatch()
+	for {
+		select {
+		case <-ctx.Done():
+			o.wg.Wait()
+			return
+		case <-ticker.C:
+			o.dispatch()
+		case <-o.wake:
+			o.dispatch()
+		}
+	}
+}
+
+// Flush delivers every message that is due and waits for the deliveries, for shutdown
+// after Run has returned
+func (o *Outbox) Flush() {
+	o.dispatch()
+	o.wg.Wait()
+}
+
+// Retry requeues a dead-lettered message with a fresh set of attempts
+func (o *Outbox) Retry(id string) error {
+	message, err := o.db.GetOutboxMessage(id)
+	if err != nil {
+		return err
+	}
+	if message.Status != structs.OutboxDead {
+		return fmt.Errorf("outbox message %s is %s, only dead messages can be retried", id, message.Status)
+	}
+
+	message.Attempts = 0
+	o.Enqueue(message)
+	return nil
+}
+
+// dispatch starts delivery of every pending message that is due and not already being sent
+func (o *Outbox) dispatch() {
+	messages, err := o.db.GetOutboxMessages(structs.OutboxPending)
+	if err != nil {
+		logger.Errorf("Failed to load outbox: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, message := range messages {
+		if message.NextAttempt.After(now) {
+			continue
+		}
+
+		o.mu.Lock()
+		busy := o.inflight[message.ID]
+		o.inflight[message.ID] = true
+		o.mu.Unlock()
+		if busy {
+			continue
+		}
+
+		o.wg.Add(1)
+		go func(message *structs.OutboxMessage) {
+			defer o.wg.Done()
+			o.attempt(message)
+
+			o.mu.Lock()
+			delete(o.inflight, message.ID)
+			o.mu.Unlock()
+		}(message)
+	}
+}
+
+// attempt delivers a message once and records the outcome
+func (o *Outbox) attempt(message *structs.OutboxMessage) {
+	start := time.Now()
+	status, permanent, err := o.send(message)
+	message.Attempts++
+
+	now := time.Now()
+	record := structs.DeliveryAttempt{Time: start, StatusCode: status, LatencyMs: now.Sub(start).Milliseconds()}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	message.History = append(message.History, record)
+
+	switch {
+	case err == nil:
+		atomic.AddUint64(&o.delivered, 1)
+		message.Status = structs.OutboxDelivered
+		message.DeliveredAt = &now
+		message.LastError = ""
+		logger.Infof("%s sent successfully", message.Description)
+	case permanent || message.Attempts >= outboxMaxAttempts:
+		atomic.AddUint64(&o.failed, 1)
+		message.Status = structs.OutboxDead
+		message.LastError = err.Error()
+		logger.Errorf("%s failed after %d attempts, moved to dead letters: %v", message.Description, message.Attempts, err)
+	default:
+		atomic.AddUint64(&o.failed, 1)
+		message.LastError = err.Error()
+		message.NextAttempt = now.Add(outboxBackoff(message.Attempts))
+		logger.Errorf("%s failed (attempt %d), retrying at %s: %v", message.Description, message.Attempts, message.NextAttempt.Format(time.RFC3339), err)
+	}
+
+	if message.ID == "" {
+		return
+	}
+	if err := o.db.SaveOutboxMessage(message); err != nil {
+		atomic.AddUint64(&o.dbErrors, 1)
+		logger.Errorf("Failed to update outbox message %s: %v", message.ID, err)
+	}
+}
+
+// send performs one delivery, returning the receiver's HTTP status where there is one;
+// permanent reports failures that retrying cannot fix
+func (o *Outbox) send(message *structs.OutboxMessage) (int, bool, error) {
+	if message.Channel == structs.ChannelEmail {
+		return 0, false, o.resolve(message.ProjectID).deliverEmail(message.Recipients, message.Subject, message.Body)
+	}
+	return postJSON(o.client, message.URL, []byte(message.Body))
+}
+
+// newAlertID returns a random ID shared by every delivery of one alert
+func newAlertID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// outboxBackoff returns the wait after the given number of failed attempts
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseDelay
+	for i := 1; i < attempts && delay < outboxMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxDelay)
+}
+
+// postJSON posts a JSON body to a webhook receiver and returns its status code. Client
+// errors other than 408 and 429 are permanent: the receiver rejected the request itself.
+func postJSON(client *http.Client, url string, body []byte) (int, bool, error) {
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+
+	err = fmt.Errorf("receiver returned status code %d", resp.StatusCode)
+	permanent := resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests
+	return resp.StatusCode, permanent, err
+}
+
+// RetryOutboxMessage requeues a dead-lettered notification
+func (m *Monitor) RetryOutboxMessage(id string) error {
+	return m.outbox.Retry(id)
+}
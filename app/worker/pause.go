@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// PauseHealthChecks stops an endpoint's health checks while its certificate is still tracked
+func (m *Monitor) PauseHealthChecks(id string) error {
+	return m.setPaused(id, "health checks", true, func(e *structs.StoredEndpoint) { e.HealthPaused = true })
+}
+
+// ResumeHealthChecks restarts an endpoint's health checks with an immediate check
+func (m *Monitor) ResumeHealthChecks(id string) error {
+	return m.setPaused(id, "health checks", false, func(e *structs.StoredEndpoint) { e.HealthPaused = false })
+}
+
+// PauseSSLChecks stops tracking an endpoint's certificate while its health is still checked
+func (m *Monitor) PauseSSLChecks(id string) error {
+	return m.setPaused(id, "SSL checks", true, func(e *structs.StoredEndpoint) { e.SSLPaused = true })
+}
+
+// ResumeSSLChecks restarts tracking an endpoint's certificate
+func (m *Monitor) ResumeSSLChecks(id string) error {
+	return m.setPaused(id, "SSL checks", false, func(e *structs.StoredEndpoint) { e.SSLPaused = false })
+}
+
+// setPaused applies a pause flag to the stored endpoint and its live state
+func (m *Monitor) setPaused(id, checks string, paused bool, apply func(*structs.StoredEndpoint)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, err := m.db.GetEndpoint(id)
+	if err != nil {
+		return err
+	}
+	apply(stored)
+	if err := m.db.SaveEndpoint(stored); err != nil {
+		return err
+	}
+
+	if state, ok := m.states[id]; ok {
+		state.mu.Lock()
+		m.mergeStoredEndpoint(state, stored)
+		state.mu.Unlock()
+	}
+	m.touch()
+
+	if paused {
+		logger.Infof("Paused %s for endpoint: %s", checks, stored.Name)
+	} else {
+		logger.Infof("Resumed %s for endpoint: %s", checks, stored.Name)
+	}
+	return nil
+}
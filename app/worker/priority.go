@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// sortByPriority orders states so critical endpoints are dispatched first under load
+func sortByPriority(states []*MonitorState) {
+	ranks := make(map[*MonitorState]int, len(states))
+	for _, state := range states {
+		state.mu.RLock()
+		ranks[state] = state.Endpoint.Priority.Rank()
+		state.mu.RUnlock()
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		return ranks[states[i]] < ranks[states[j]]
+	})
+}
+
+// alertSeverity classifies an alert from the endpoint's priority and what went wrong.
+// Recoveries are informational, an unreachable high-priority endpoint or a failed service is
+// as urgent as a critical endpoint, and SLA alerts warn about trends rather than outages.
+func alertSeverity(alertType string, endpoint structs.Endpoint, state *structs.EndpointState) structs.Severity {
+	if strings.HasSuffix(alertType, "recovery") {
+		return structs.SeverityInfo
+	}
+	sla := strings.HasPrefix(alertType, "sla")
+
+	switch endpoint.Priority {
+	case structs.PriorityCritical:
+		if sla {
+			return structs.SeverityWarning
+		}
+		return structs.SeverityCritical
+	case structs.PriorityHigh:
+		unreachable := alertType == "failure" && state.LastFailureKind == structs.FailureNetwork
+		if unreachable || alertType == "service_failure" {
+			return structs.SeverityCritical
+		}
+		return structs.SeverityWarning
+	case structs.PriorityLow:
+		return structs.SeverityInfo
+	default:
+		return structs.SeverityWarning
+	}
+}
+
+// channelAllows reports whether a channel takes alerts of a severity under channel_min_severity
+func (a *Alerter) channelAllows(channel string, severity structs.Severity) bool {
+	min := structs.Severity(a.config.ChannelMinSeverity[channel])
+	return min == "" || severity.Rank() <= min.Rank()
+}
+
+// shouldEscalate reports whether an endpoint's priority qualifies for pager escalation
+func (a *Alerter) shouldEscalate(priority structs.Priority) bool {
+	threshold := structs.Priority(a.config.EscalationMinPriority)
+	if !threshold.Valid() {
+		threshold = structs.PriorityHigh
+	}
+	return priority.Rank() <= threshold.Rank()
+}
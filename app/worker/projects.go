@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// ReloadProjects rebuilds the per-project alerters from the database
+func (m *Monitor) ReloadProjects() {
+	projects, err := m.db.GetAllProjects()
+	if err != nil {
+		logger.Errorf("Failed to load projects: %v", err)
+		return
+	}
+
+	users := m.currentUsers()
+	onCall := m.currentOnCallSchedules()
+	blackouts := m.currentBlackouts()
+	alerters := make(map[string]*Alerter)
+	for _, project := range projects {
+		if project.Alerting != nil {
+			alerter := NewAlerter(project.Alerting)
+			alerter.SetUsers(users)
+			alerter.SetOnCallSchedules(onCall)
+			alerter.SetBlackouts(blackouts)
+			alerter.SetActionLinks(m.config.PublicURL, m.actionKey)
+			alerter.SetWebPusher(m.push)
+			alerter.SetOutbox(m.outbox, project.ID)
+			if m.otlp != nil {
+				alerter.SetOTLP(m.otlp)
+			}
+			alerter.SetLocation(m.loc)
+			alerters[project.ID] = alerter
+		}
+	}
+
+	m.projectMu.Lock()
+	m.projectAlerters = alerters
+	m.projectMu.Unlock()
+
+	logger.Infof("Loaded %d projects", len(projects))
+}
+
+// alerterFor returns the alerter for a project, falling back to the global alerter
+func (m *Monitor) alerterFor(projectID string) *Alerter {
+	if projectID == "" {
+		return m.alerter
+	}
+
+	m.projectMu.RLock()
+	defer m.projectMu.RUnlock()
+
+	if alerter, ok := m.projectAlerters[projectID]; ok {
+		return alerter
+	}
+	return m.alerter
+}
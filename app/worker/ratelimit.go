@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces outbound checks evenly to cap checks per second
+type RateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	mu       sync.Mutex
+}
+
+// NewRateLimiter creates a limiter allowing perSecond checks; zero or less disables limiting
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+	}
+}
+
+// Wait blocks until the next check slot is available or the context is cancelled
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	if r.interval == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// historyQueueSize bounds check results waiting to be written
+const historyQueueSize = 4096
+
+// historyBatchSize caps the records written in one transaction
+const historyBatchSize = 256
+
+// resultRing keeps the last N check results for an endpoint. Guarded by the state lock.
+type resultRing struct {
+	records []structs.HealthCheckRecord
+	next    int
+	count   int
+}
+
+// newResultRing creates a ring holding up to capacity results
+func newResultRing(capacity int) *resultRing {
+	return &resultRing{records: make([]structs.HealthCheckRecord, capacity)}
+}
+
+// add stores a result, overwriting the oldest when full
+func (r *resultRing) add(record structs.HealthCheckRecord) {
+	if len(r.records) == 0 {
+		return
+	}
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+	if r.count < len(r.records) {
+		r.count++
+	}
+}
+
+// newest returns up to limit results, newest first like GetHealthHistory
+func (r *resultRing) newest(limit int) []*structs.HealthCheckRecord {
+	if limit <= 0 || limit > r.count {
+		limit = r.count
+	}
+	records := make([]*structs.HealthCheckRecord, 0, limit)
+	for i := 1; i <= limit; i++ {
+		record := r.records[(r.next-i+len(r.records))%len(r.records)]
+		records = append(records, &record)
+	}
+	return records
+}
+
+// loadRecentResults seeds an endpoint's ring from stored history
+func (m *Monitor) loadRecentResults(id string) *resultRing {
+	ring := newResultRing(m.config.RecentResults)
+	records, err := m.db.GetHealthHistory(id, m.config.RecentResults)
+	if err != nil {
+		logger.Errorf("Error loading recent results for %s: %v", id, err)
+		return ring
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		ring.add(*records[i])
+	}
+	return ring
+}
+
+// RecentResults returns up to limit in-memory results for an endpoint, newest first.
+// ok is false when the ring cannot answer and the caller should read the database.
+func (m *Monitor) RecentResults(id string, limit int) ([]*structs.HealthCheckRecord, bool) {
+	if limit <= 0 || limit > m.config.RecentResults {
+		return nil, false
+	}
+
+	m.mu.RLock()
+	state, exists := m.states[id]
+	m.mu.RUnlock()
+	if !exists || state.recent == nil {
+		return nil, false
+	}
+
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.recent.newest(limit), true
+}
+
+// Sparkline returns recent response times in milliseconds, oldest first
+func (m *Monitor) Sparkline(id string) []float64 {
+	records, ok := m.RecentResults(id, m.config.RecentResults)
+	if !ok {
+		return nil
+	}
+	points := make([]float64, len(records))
+	for i, record := range records {
+		points[len(records)-1-i] = float64(record.ResponseTime.Microseconds()) / 1000.0
+	}
+	return points
+}
+
+// historyWriter persists check results asynchronously in batches
+type historyWriter struct {
+	db    *models.Database
+	queue chan *structs.HealthCheckRecord
+
+	// failures counts failed writes for self-monitoring
+	failures uint64
+}
+
+// newHistoryWriter creates a writer for the history bucket
+func newHistoryWriter(db *models.Database) *historyWriter {
+	return &historyWriter{
+		db:    db,
+		queue: make(chan *structs.HealthCheckRecord, historyQueueSize),
+	}
+}
+
+// enqueue queues a record, writing it directly if the queue is full so no results are lost
+func (w *historyWriter) enqueue(record *structs.HealthCheckRecord) {
+	select {
+	case w.queue <- record:
+	default:
+		if err := w.db.SaveHealthCheckRecord(record); err != nil {
+			atomic.AddUint64(&w.failures, 1)
+			logger.Errorf("Error saving health check record: %v", err)
+		}
+	}
+}
+
+// drain writes any records still queued
+func (w *historyWriter) drain() {
+	var batch []*structs.HealthCheckRecord
+	for {
+		select {
+		case record := <-w.queue:
+			batch = append(batch, record)
+		default:
+			if len(batch) > 0 {
+				if err := w.db.SaveHealthCheckRecords(batch); err != nil {
+					atomic.AddUint64(&w.failures, 1)
+					logger.Errorf("Error saving %d health check records: %v", len(batch), err)
+				}
+			}
+			return
+		}
+	}
+}
+
+// run flushes queued records every second until ctx is cancelled, then drains the queue
+func (w *historyWriter) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var batch []*structs.HealthCheckRecord
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.db.SaveHealthCheckRecords(batch); err != nil {
+			atomic.AddUint64(&w.failures, 1)
+			logger.Errorf("Error saving %d health check records: %v", len(batch), err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			w.drain()
+			return
+		case record := <-w.queue:
+			batch = append(batch, record)
+			if len(batch) >= historyBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
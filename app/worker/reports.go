@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+const (
+	// reportSchedule generates the previous month's reports early on the 1st, in UTC
+	reportSchedule = "0 2 1 * *"
+	// reportMonthFormat is the month a report covers
+	reportMonthFormat = "2006-01"
+)
+
+// startReportScheduler generates every configured report for the previous month once a month
+func (m *Monitor) startReportScheduler() {
+	if len(m.config.Reports) == 0 {
+		return
+	}
+	schedule := parseSummarySchedule("monthly reports", reportSchedule, "02:00", "", time.UTC)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.runSchedule(schedule, func() {
+			month := time.Now().UTC().AddDate(0, 0, -1).Format(reportMonthFormat)
+			for _, config := range m.config.Reports {
+				if _, err := m.GenerateReport(config.Name, month, config.Filter); err != nil {
+					logger.Errorf("Failed to generate report %s for %s: %v", config.Name, month, err)
+				}
+			}
+		})
+	}()
+}
+
+// ReportConfig returns the configured report with the given name
+func (m *Monitor) ReportConfig(name string) (structs.ReportConfig, bool) {
+	for _, config := range m.config.Reports {
+		if config.Name == name {
+			return config, true
+		}
+	}
+	return structs.ReportConfig{}, false
+}
+
+// GenerateReport builds and stores the availability report of a UTC month (YYYY-MM) for the
+// endpoints matching filter, replacing an earlier report with the same name and month
+func (m *Monitor) GenerateReport(name, month string, filter structs.Subscription) (*structs.Report, error) {
+	start, err := time.Parse(reportMonthFormat, month)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q: must be YYYY-MM", month)
+	}
+	end := start.AddDate(0, 1, 0)
+
+	endpoints, err := m.db.GetAllEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &structs.Report{
+		ID:           utils.GenerateIDWithURL(name, month),
+		Name:         name,
+		Month:        month,
+		Filter:       filter,
+		GeneratedAt:  time.Now(),
+		Availability: -1,
+		Endpoints:    []structs.ReportEndpoint{},
+	}
+
+	var healthy, counted int
+	for _, stored := range endpoints {
+		if !filter.Matches(stored.ToEndpoint()) {
+			continue
+		}
+
+		days, err := m.db.GetDailyStats(stored.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		// Endpoints no longer monitored appear only for months they have data in
+		if len(days) == 0 && (stored.Archived || !stored.Enabled) {
+			continue
+		}
+
+		entry := structs.ReportEndpoint{
+			ID:           stored.ID,
+			Name:         stored.Name,
+			URL:          stored.URL,
+			ProjectID:    stored.ProjectID,
+			Tags:         stored.Tags,
+			Availability: -1,
+			SLATarget:    stored.SLATarget,
+		}
+		var responseSum float64
+		var responseCount int
+		for _, day := range days {
+			entry.Checks += day.Checks
+			entry.Healthy += day.Healthy
+			entry.Unhealthy += day.Unhealthy
+			responseSum += day.ResponseTimeSum
+			responseCount += day.ResponseCount
+		}
+		if responseCount > 0 {
+			entry.AvgResponseMs = responseSum / float64(responseCount)
+		}
+		if total := entry.Healthy + entry.Unhealthy; total > 0 {
+			entry.Availability = float64(entry.Healthy) / float64(total) * 100
+			if entry.SLATarget > 0 {
+				met := entry.Availability >= entry.SLATarget
+				entry.SLAMet = &met
+				if !met {
+					report.SLABreaches++
+				}
+			}
+		}
+
+		report.Checks += entry.Checks
+		healthy += entry.Healthy
+		counted += entry.Healthy + entry.Unhealthy
+		report.Endpoints = append(report.Endpoints, entry)
+	}
+
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Name < report.Endpoints[j].Name
+	})
+	if counted > 0 {
+		report.Availability = float64(healthy) / float64(counted) * 100
+	}
+
+	if err := m.db.SaveReport(report); err != nil {
+		return nil, err
+	}
+	logger.Infof("Generated report %s for %s covering %d endpoints", name, month, len(report.Endpoints))
+	return report, nil
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// defaultRetryDelay is the wait before an endpoint's first retry when retry_delay is unset
+const defaultRetryDelay = time.Second
+
+// RetryDelay returns the wait before an endpoint's first retry
+func RetryDelay(endpoint structs.Endpoint) time.Duration {
+	if endpoint.RetryDelay.Duration > 0 {
+		return endpoint.RetryDelay.Duration
+	}
+	return defaultRetryDelay
+}
+
+// retryBackoff returns the wait before the given retry, starting at 1: retry_delay, doubling
+// with each further retry
+func retryBackoff(endpoint structs.Endpoint, retry int) time.Duration {
+	return RetryDelay(endpoint) << (retry - 1)
+}
+
+// checkBudget is how long a check may take with all its retries and the certificate check
+// that may follow a pass, so the watchdog leaves it be
+func checkBudget(endpoint structs.Endpoint, timeout time.Duration) time.Duration {
+	// Negative checks are never retried and have no certificate to check
+	if endpoint.CheckType == structs.CheckNegative {
+		return timeout
+	}
+	budget := timeout + sslProbeBudget
+	// Browser checks are never retried
+	if endpoint.CheckType == structs.CheckBrowser {
+		return budget
+	}
+	for retry := 1; retry <= endpoint.Retries; retry++ {
+		budget += retryBackoff(endpoint, retry) + timeout
+	}
+	return budget
+}
+
+// retryable reports whether a request failed in a way worth retrying within the same check:
+// a network error, or a gateway error the endpoint is not expected to answer with
+func retryable(endpoint structs.Endpoint, resp *http.Response, err error, expectedStatus int) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return endpoint.SuccessExpression == "" && resp.StatusCode != expectedStatus
+	}
+	return false
+}
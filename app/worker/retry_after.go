@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// maxRetryAfter caps how long a Retry-After header can pause checks
+const maxRetryAfter = 1 * time.Hour
+
+// parseRetryAfter returns the wait requested by a Retry-After header, given
+// either as delay-seconds or an HTTP date. Zero means no usable value.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = at.Sub(now)
+	}
+
+	if wait <= 0 {
+		return 0
+	}
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return wait
+}
+
+// handleRateLimited handles a 429 Too Many Requests response. In degraded mode the
+// check neither passes nor fails; in failure mode it counts towards the failure threshold.
+// Either way the next check waits for Retry-After.
+func (m *Monitor) handleRateLimited(state *MonitorState, retryAfter time.Duration, responseTime time.Duration) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	now := time.Now()
+	state.RateLimited = true
+	state.RateLimitCount++
+	state.LastRateLimited = now
+
+	errorMsg := "rate limited: 429 Too Many Requests"
+	if retryAfter > 0 {
+		errorMsg = fmt.Sprintf("%s, retry after %v", errorMsg, retryAfter)
+	}
+
+	state.RateLimitedUntil = now.Add(retryAfter)
+
+	if state.Endpoint.RateLimitMode == structs.RateLimitFailure {
+		m.recordFailure(state, structs.FailureApplication, errorMsg, responseTime)
+	} else {
+		// Keep the current status and counters, the endpoint answered but told us to slow down
+		state.LastCheck = now
+		state.NextCheck = m.nextCheckAfter(state, now)
+		state.ResponseTime = responseTime
+		state.LastError = errorMsg
+		state.LastFailureKind = ""
+
+		logger.Infof("[%s] ⏸ Health check rate limited (status: %s, error: %s)",
+			state.Endpoint.Name, state.Status, errorMsg)
+
+		m.saveHealthRecord(state, errorMsg)
+	}
+
+	// Don't check again before the server asked us to
+	if state.RateLimitedUntil.After(state.NextCheck) {
+		state.NextCheck = m.nextCheckNotBefore(state, state.RateLimitedUntil)
+		logger.Infof("[%s] Respecting Retry-After, next check at %s", state.Endpoint.Name, state.NextCheck.Format(time.RFC3339))
+	}
+}
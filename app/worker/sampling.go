@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// sampleHistory picks the records to write for a check result, oldest first. Endpoints with
+// history_sample_every above 1 write every failure and status change but only one in N
+// successes; the written success counts the checks it stands for. The latest held-back success
+// is written before the next failure or status change, so no check goes uncounted.
+// Caller must hold the state lock.
+func (state *MonitorState) sampleHistory(record *structs.HealthCheckRecord) []*structs.HealthCheckRecord {
+	every := state.Endpoint.HistorySample
+	success := record.Error == "" && record.Status == string(structs.StatusHealthy)
+	changed := record.Status != state.lastRecorded
+	state.lastRecorded = record.Status
+
+	if every > 1 && success && !changed {
+		state.held++
+		if state.held < every {
+			state.pending = record
+			return nil
+		}
+		record.Sampled = state.held
+		state.held, state.pending = 0, nil
+		return []*structs.HealthCheckRecord{record}
+	}
+
+	records := []*structs.HealthCheckRecord{record}
+	if state.pending != nil {
+		state.pending.Sampled = state.held
+		records = []*structs.HealthCheckRecord{state.pending, record}
+	}
+	state.held, state.pending = 0, nil
+	return records
+}
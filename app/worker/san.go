@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// updateSANCoverage records the hostnames an endpoint's certificate does not cover and alerts
+// when a misdeployed certificate appears or is fixed. Caller must hold the state lock.
+func (m *Monitor) updateSANCoverage(state *MonitorState, uncovered []string) {
+	previous := state.SSLUncoveredHosts
+	state.SSLUncoveredHosts = uncovered
+	if slices.Equal(previous, uncovered) {
+		return
+	}
+
+	alerter := m.alerterFor(state.Endpoint.ProjectID)
+	switch {
+	case len(uncovered) > 0:
+		logger.Errorf("[%s] SSL certificate does not cover %s", state.Endpoint.Name, strings.Join(uncovered, ", "))
+		if !state.AlertsSuppressed {
+			alerter.SendSANAlert(state.Endpoint, state.EndpointState, "ssl_san_mismatch", uncovered)
+		}
+	case len(previous) > 0:
+		logger.Infof("[%s] SSL certificate now covers all monitored hostnames", state.Endpoint.Name)
+		if !state.AlertsSuppressed {
+			alerter.SendSANAlert(state.Endpoint, state.EndpointState, "ssl_san_recovery", previous)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// summarySchedule is a daily, weekly or cron schedule evaluated in a configured timezone
+type summarySchedule struct {
+	name    string
+	expr    string
+	hour    int
+	minute  int
+	weekly  bool
+	weekday time.Weekday
+	cron    *utils.CronSchedule
+	loc     *time.Location
+}
+
+// parseSummarySchedule parses a schedule ("daily", "weekly" or cron), an HH:MM time and a weekday,
+// falling back to defaults with an error log for invalid values
+func parseSummarySchedule(name, schedule, at, weekday string, loc *time.Location) *summarySchedule {
+	var err error
+	s := &summarySchedule{name: name, expr: schedule, weekday: time.Monday, loc: loc}
+
+	// Parse configured time (format: HH:MM)
+	if _, err := fmt.Sscanf(at, "%d:%d", &s.hour, &s.minute); err != nil {
+		logger.Errorf("Invalid %s time format '%s', using default 09:30", name, at)
+		s.hour, s.minute = 9, 30
+	}
+
+	switch strings.ToLower(schedule) {
+	case "", "daily":
+	case "weekly":
+		s.weekly = true
+		if day, ok := parseWeekday(weekday); ok {
+			s.weekday = day
+		} else if weekday != "" {
+			logger.Errorf("Invalid %s weekday '%s', using Monday", name, weekday)
+		}
+	default:
+		s.cron, err = utils.ParseCron(schedule)
+		if err != nil {
+			logger.Errorf("Invalid %s schedule '%s' (%v), using daily", name, schedule, err)
+		}
+	}
+
+	return s
+}
+
+// next returns the next run after now, or the zero time if the schedule never fires
+func (s *summarySchedule) next(now time.Time) time.Time {
+	now = now.In(s.loc)
+	if s.cron != nil {
+		return s.cron.Next(now)
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), s.hour, s.minute, 0, 0, s.loc)
+	if now.After(next) {
+		// If it's already past the scheduled time today, schedule for tomorrow
+		next = next.AddDate(0, 0, 1)
+	}
+	if s.weekly {
+		next = next.AddDate(0, 0, (int(s.weekday)-int(next.Weekday())+7)%7)
+	}
+	return next
+}
+
+// runSchedule calls fn at every run of the schedule until the monitor stops. Runs follow the
+// wall clock: one missed while the host was suspended or the clock jumped forward runs once on
+// waking, and a clock stepped back does not repeat the last run.
+func (m *Monitor) runSchedule(s *summarySchedule, fn func()) {
+	var last time.Time
+	for {
+		now := time.Now().In(s.loc)
+		if !now.After(last) {
+			now = last.Add(time.Second)
+		}
+
+		next := s.next(now)
+		if next.IsZero() {
+			logger.Errorf("%s schedule '%s' never fires, scheduler stopped", s.name, s.expr)
+			return
+		}
+
+		duration := next.Sub(now)
+		logger.Infof("Next %s scheduled at: %s (in %v)", s.name, next.Format("02 Jan 2006 03:04 PM"), duration.Round(time.Minute))
+
+		if !m.sleepUntil(next) {
+			return
+		}
+		last = next
+		if late := time.Since(next); late > clockRecheck {
+			logger.Infof("%s running %v late, the host was suspended or its clock changed", s.name, late.Round(time.Minute))
+		}
+
+		// Only the HA leader sends scheduled summaries
+		if m.IsActive() {
+			fn()
+		}
+	}
+}
+
+// parseWeekday parses a weekday name such as "monday" or "mon"
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.HasPrefix(strings.ToLower(day.String()), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// overdueSlack is how far past its next check an endpoint may be before it counts as overdue;
+// the timer loop only looks for due endpoints every 5 seconds
+const overdueSlack = 10 * time.Second
+
+// SchedulerEntry describes how and when an endpoint is checked
+type SchedulerEntry struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	ProjectID      string     `json:"project_id,omitempty"`
+	Priority       string     `json:"priority"`
+	Scheduler      string     `json:"scheduler"` // interval, timer, cron, ssl_only or disabled
+	Interval       string     `json:"interval,omitempty"`
+	Schedule       string     `json:"schedule,omitempty"`
+	NextCheck      *time.Time `json:"next_check"`
+	DueInSeconds   float64    `json:"due_in_seconds"`
+	QueuePosition  int        `json:"queue_position,omitempty"`
+	LastCheck      time.Time  `json:"last_check"`
+	LastDurationMs float64    `json:"last_duration_ms"`
+	Running        bool       `json:"running"`
+	Overdue        bool       `json:"overdue"`
+	HeldBy         string     `json:"held_by,omitempty"` // paused, backoff or rate_limit when health checks are held back
+	MissedChecks   int        `json:"missed_checks"`
+}
+
+// Scheduler lists every endpoint with its next check, ordered the way checks will be
+// dispatched: by next check time, then by priority. Queue positions count only enabled endpoints.
+func (m *Monitor) Scheduler(now time.Time) []SchedulerEntry {
+	m.inflightMu.Lock()
+	running := make(map[string]bool, len(m.inflight))
+	for id := range m.inflight {
+		running[id] = true
+	}
+	m.inflightMu.Unlock()
+
+	entries := make([]SchedulerEntry, 0)
+	ranks := make(map[string]int)
+
+	m.mu.RLock()
+	for id, state := range m.states {
+		state.mu.RLock()
+		entry := SchedulerEntry{
+			ID:             id,
+			Name:           state.Endpoint.Name,
+			ProjectID:      state.Endpoint.ProjectID,
+			Priority:       string(state.Endpoint.Priority),
+			LastCheck:      state.LastCheck,
+			LastDurationMs: float64(state.lastDuration.Microseconds()) / 1000.0,
+			Running:        running[id],
+			MissedChecks:   state.MissedChecks,
+		}
+		ranks[id] = state.Endpoint.Priority.Rank()
+
+		// due is when the check should start at the latest, for overdue detection
+		var next, due time.Time
+		switch {
+		case !state.Enabled:
+			entry.Scheduler = "disabled"
+		case !state.ChecksHealth():
+			entry.Scheduler = "ssl_only"
+			next = state.NextCheck
+		case state.cron != nil:
+			entry.Scheduler = "cron"
+			entry.Schedule = state.Endpoint.Schedule
+			next = state.NextCheck
+		case isStandardHealthInterval(state.CheckInterval):
+			entry.Scheduler = "interval"
+			entry.Interval = state.CheckInterval.String()
+			next = nextGroupedRun(state.EndpointState, now)
+			due = nextGroupedRun(state.EndpointState, state.LastCheck)
+		default:
+			entry.Scheduler = "timer"
+			entry.Interval = state.CheckInterval.String()
+			next = state.NextCheck
+		}
+
+		if state.Enabled {
+			switch {
+			case state.HealthPaused:
+				entry.HeldBy = "paused"
+			case state.RateLimitedUntil.After(now):
+				entry.HeldBy = "rate_limit"
+			case state.BackoffInterval > 0:
+				entry.HeldBy = "backoff"
+			}
+			if due.IsZero() {
+				due = state.NextCheck
+			}
+			entry.Overdue = !entry.Running && now.Sub(due) > overdueSlack
+			entry.NextCheck = &next
+			entry.DueInSeconds = next.Sub(now).Round(time.Second).Seconds()
+		}
+		state.mu.RUnlock()
+
+		entries = append(entries, entry)
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].NextCheck, entries[j].NextCheck
+		if (a == nil) != (b == nil) {
+			return b == nil
+		}
+		if a != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		if ranks[entries[i].ID] != ranks[entries[j].ID] {
+			return ranks[entries[i].ID] < ranks[entries[j].ID]
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	position := 0
+	for i := range entries {
+		if entries[i].NextCheck != nil {
+			position++
+			entries[i].QueuePosition = position
+		}
+	}
+	return entries
+}
+
+// nextGroupedRun returns the tick of the endpoint's interval group that will next check it,
+// skipping ticks that a backoff or Retry-After holds it back from. Caller must hold the state lock.
+func nextGroupedRun(state *structs.EndpointState, now time.Time) time.Time {
+	interval := state.CheckInterval
+	next := now.Truncate(interval).Add(interval)
+	for {
+		slack := next.Add(interval / 2)
+		if state.BackoffInterval > 0 && slack.Before(state.NextCheck) {
+			next = next.Add(interval)
+			continue
+		}
+		if slack.Before(state.RateLimitedUntil) {
+			next = next.Add(interval)
+			continue
+		}
+		return next
+	}
+}
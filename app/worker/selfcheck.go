@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// Self checks, each alerting on its own
+const (
+	selfCheckDatabase      = "database"
+	selfCheckAlertDelivery = "alert_delivery"
+	selfCheckScheduler     = "scheduler"
+)
+
+// selfMonitor holds the counters seen at the last evaluation and the checks then failing
+type selfMonitor struct {
+	mu        sync.Mutex
+	dbErrors  uint64
+	delivered uint64
+	failed    uint64
+	failing   map[string]bool
+	checkedAt time.Time
+}
+
+// SelfStatus reports SiteWatch's own health as of the last self-monitoring evaluation
+type SelfStatus struct {
+	Enabled          bool      `json:"enabled"`
+	Healthy          bool      `json:"healthy"`
+	Failing          []string  `json:"failing"`
+	CheckedAt        time.Time `json:"checked_at"`
+	DBWriteErrors    uint64    `json:"db_write_errors_total"`
+	DeliveriesSent   uint64    `json:"deliveries_sent_total"`
+	DeliveriesFailed uint64    `json:"deliveries_failed_total"`
+
+	Heartbeats []HeartbeatStatus `json:"heartbeats,omitempty"`
+	Archive    *ArchiveStatus    `json:"archive,omitempty"`
+}
+
+// selfResult is the outcome of one self check; vars fill its detail message
+type selfResult struct {
+	failing bool
+	vars    []string
+}
+
+// startSelfMonitor periodically checks SiteWatch's own health
+func (m *Monitor) startSelfMonitor() {
+	ticker := time.NewTicker(m.config.SelfMonitoring.Interval.Duration)
+	defer ticker.Stop()
+
+	// Counters start from now, so failures before startup are not blamed on the first window
+	m.evaluateSelf(time.Now(), false)
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.evaluateSelf(now, true)
+		}
+	}
+}
+
+// dbWriteErrors counts failed database writes of check history, state and the outbox
+func (m *Monitor) dbWriteErrors() uint64 {
+	return atomic.LoadUint64(&m.stateWriteErrors) + atomic.LoadUint64(&m.history.failures) + atomic.LoadUint64(&m.outbox.dbErrors)
+}
+
+// evaluateSelf compares the counters with the last evaluation and alerts on self checks
+// that started or stopped failing
+func (m *Monitor) evaluateSelf(now time.Time, alert bool) {
+	cfg := m.config.SelfMonitoring
+	window := cfg.Interval.Duration.String()
+
+	dbErrors := m.dbWriteErrors()
+	delivered := atomic.LoadUint64(&m.outbox.delivered)
+	failed := atomic.LoadUint64(&m.outbox.failed)
+
+	m.self.mu.Lock()
+	newDBErrors := dbErrors - m.self.dbErrors
+	newDelivered := delivered - m.self.delivered
+	newFailed := failed - m.self.failed
+	m.self.dbErrors, m.self.delivered, m.self.failed = dbErrors, delivered, failed
+	wasFailing := m.self.failing
+	m.self.mu.Unlock()
+
+	// A standby writes nothing and schedules nothing, so only the active node judges itself
+	if !alert || !m.IsActive() {
+		return
+	}
+
+	results := make(map[string]selfResult)
+	results[selfCheckDatabase] = selfResult{
+		failing: newDBErrors >= uint64(cfg.DBWriteErrors),
+		vars:    []string{"count", strconv.FormatUint(newDBErrors, 10), "window", window},
+	}
+
+	attempts := newDelivered + newFailed
+	var rate float64
+	if attempts > 0 {
+		rate = float64(newFailed) / float64(attempts) * 100
+	}
+	delivery := selfResult{
+		failing: attempts >= uint64(cfg.MinDeliveries) && rate >= cfg.DeliveryFailureRate,
+		vars: []string{"failed", strconv.FormatUint(newFailed, 10), "total", strconv.FormatUint(attempts, 10),
+			"rate", strconv.FormatFloat(rate, 'f', 1, 64), "window", window},
+	}
+	// Too few attempts to judge the rate: stay failing while deliveries keep failing
+	if wasFailing[selfCheckAlertDelivery] && attempts < uint64(cfg.MinDeliveries) && newFailed > 0 {
+		delivery.failing = true
+	}
+	results[selfCheckAlertDelivery] = delivery
+
+	overdue := 0
+	for _, entry := range m.Scheduler(now) {
+		if entry.Overdue {
+			overdue++
+		}
+	}
+	results[selfCheckScheduler] = selfResult{
+		failing: overdue >= cfg.OverdueChecks,
+		vars:    []string{"count", strconv.Itoa(overdue)},
+	}
+
+	failing := make(map[string]bool)
+	for check, result := range results {
+		was := wasFailing[check]
+		switch {
+		case result.failing && !was:
+			logger.Errorf("Self-monitoring: %s is failing: %s", check, utils.Translate(utils.DefaultLanguage, "self."+check+".detail", result.vars...))
+			m.alerter.SendSelfAlert(check, result.vars, "self_failure")
+		case !result.failing && was:
+			logger.Infof("Self-monitoring: %s recovered", check)
+			m.alerter.SendSelfAlert(check, result.vars, "self_recovery")
+		}
+		if result.failing {
+			failing[check] = true
+		}
+	}
+
+	m.self.mu.Lock()
+	m.self.failing = failing
+	m.self.checkedAt = now
+	m.self.mu.Unlock()
+}
+
+// SelfStatus returns SiteWatch's own health as last evaluated
+func (m *Monitor) SelfStatus() SelfStatus {
+	m.self.mu.Lock()
+	defer m.self.mu.Unlock()
+
+	status := SelfStatus{
+		Enabled:   m.config.SelfMonitoring.Enabled,
+		Healthy:   len(m.self.failing) == 0,
+		Failing:   make([]string, 0, len(m.self.failing)),
+		CheckedAt: m.self.checkedAt,
+
+		DBWriteErrors:    m.dbWriteErrors(),
+		DeliveriesSent:   atomic.LoadUint64(&m.outbox.delivered),
+		DeliveriesFailed: atomic.LoadUint64(&m.outbox.failed),
+	}
+	if m.heartbeat != nil {
+		status.Heartbeats = m.heartbeat.Statuses()
+	}
+	if m.archiver != nil {
+		archive := m.archiver.Status()
+		status.Archive = &archive
+	}
+	for check := range m.self.failing {
+		status.Failing = append(status.Failing, check)
+	}
+	sort.Strings(status.Failing)
+	return status
+}
@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// serviceEvaluationInterval is how often service rollups are evaluated for alerting
+const serviceEvaluationInterval = 30 * time.Second
+
+// EvaluateService rolls up member endpoint states according to the service policy
+func EvaluateService(service *structs.Service, states map[string]*structs.EndpointState) structs.ServiceStatus {
+	result := structs.ServiceStatus{Service: service}
+
+	var totalWeight, healthyWeight, unknownWeight float64
+	for _, id := range service.EndpointIDs {
+		weight := 1.0
+		if w, ok := service.Weights[id]; ok {
+			weight = w
+		}
+		totalWeight += weight
+		result.Total++
+
+		state, ok := states[id]
+		switch {
+		case !ok:
+			result.Unknown++
+			unknownWeight += weight
+		case state.Status == structs.StatusHealthy:
+			result.Healthy++
+			healthyWeight += weight
+		case state.Status == structs.StatusUnhealthy:
+			result.Unhealthy++
+		default:
+			result.Unknown++
+			unknownWeight += weight
+		}
+	}
+
+	if totalWeight > 0 {
+		result.HealthyScore = healthyWeight / totalWeight * 100
+	}
+
+	if result.Total == 0 {
+		result.Status = structs.StatusUnknown
+		return result
+	}
+
+	switch service.Policy {
+	case structs.PolicyQuorum:
+		quorum := service.Quorum
+		if quorum <= 0 {
+			quorum = result.Total/2 + 1
+		}
+		switch {
+		case result.Healthy >= quorum:
+			result.Status = structs.StatusHealthy
+		case result.Healthy+result.Unknown >= quorum:
+			result.Status = structs.StatusUnknown
+		default:
+			result.Status = structs.StatusUnhealthy
+		}
+	case structs.PolicyWeighted:
+		threshold := service.WeightThreshold
+		if threshold <= 0 {
+			threshold = 100
+		}
+		switch {
+		case result.HealthyScore >= threshold:
+			result.Status = structs.StatusHealthy
+		case (healthyWeight+unknownWeight)/totalWeight*100 >= threshold:
+			result.Status = structs.StatusUnknown
+		default:
+			result.Status = structs.StatusUnhealthy
+		}
+	default:
+		switch {
+		case result.Unhealthy > 0:
+			result.Status = structs.StatusUnhealthy
+		case result.Unknown > 0:
+			result.Status = structs.StatusUnknown
+		default:
+			result.Status = structs.StatusHealthy
+		}
+	}
+
+	return result
+}
+
+// GetServiceStatuses returns the rolled-up status of every service
+func (m *Monitor) GetServiceStatuses() ([]structs.ServiceStatus, error) {
+	services, err := m.db.GetAllServices()
+	if err != nil {
+		return nil, err
+	}
+
+	states := m.GetStatus()
+	statuses := make([]structs.ServiceStatus, 0, len(services))
+	for _, service := range services {
+		statuses = append(statuses, EvaluateService(service, states))
+	}
+	return statuses, nil
+}
+
+// startServiceEvaluator periodically evaluates services and alerts on status changes
+func (m *Monitor) startServiceEvaluator() {
+	ticker := time.NewTicker(serviceEvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.evaluateServices()
+		}
+	}
+}
+
+// evaluateServices sends one service-level alert per status transition
+func (m *Monitor) evaluateServices() {
+	if !m.IsActive() {
+		return
+	}
+
+	statuses, err := m.GetServiceStatuses()
+	if err != nil {
+		logger.Errorf("Error evaluating services: %v", err)
+		return
+	}
+
+	m.serviceMu.Lock()
+	defer m.serviceMu.Unlock()
+
+	seen := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		service := status.Service
+		seen[service.ID] = true
+
+		previous, known := m.serviceStates[service.ID]
+		m.serviceStates[service.ID] = status.Status
+		if !known || previous == status.Status || service.AlertsSuppressed {
+			continue
+		}
+
+		switch {
+		case status.Status == structs.StatusUnhealthy:
+			logger.Infof("[service %s] ✗ Service is unhealthy (%d/%d endpoints healthy)", service.Name, status.Healthy, status.Total)
+			m.alerterFor(service.ProjectID).SendServiceAlert(status, "service_failure")
+		case previous == structs.StatusUnhealthy && status.Status == structs.StatusHealthy:
+			logger.Infof("[service %s] ✓ Service recovered", service.Name)
+			m.alerterFor(service.ProjectID).SendServiceAlert(status, "service_recovery")
+		}
+	}
+
+	// Forget deleted services
+	for id := range m.serviceStates {
+		if !seen[id] {
+			delete(m.serviceStates, id)
+		}
+	}
+}
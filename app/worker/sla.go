@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// slaEvaluationInterval is how often SLA and burn-rate alerts are evaluated
+const slaEvaluationInterval = 5 * time.Minute
+
+// recordSLACheck updates the monthly SLA counters after a check.
+// Caller must hold the state lock.
+func recordSLACheck(state *MonitorState) {
+	if state.Endpoint.SLATarget <= 0 {
+		return
+	}
+	// A check the server refused to answer says nothing about availability
+	if state.RateLimited && state.Endpoint.RateLimitMode != structs.RateLimitFailure {
+		return
+	}
+
+	month := state.LastCheck.Format("2006-01")
+	if state.SLAMonth != month {
+		state.SLAMonth = month
+		state.SLATotalChecks = 0
+		state.SLAHealthyChecks = 0
+		state.SLABreachAlerted = false
+	}
+
+	switch state.Status {
+	case structs.StatusHealthy:
+		state.SLATotalChecks++
+		state.SLAHealthyChecks++
+	case structs.StatusUnhealthy:
+		state.SLATotalChecks++
+	}
+}
+
+// monthlyAvailability returns the availability percentage for the current SLA month
+func monthlyAvailability(state *structs.EndpointState) (float64, bool) {
+	if state.SLATotalChecks == 0 {
+		return 0, false
+	}
+	return float64(state.SLAHealthyChecks) / float64(state.SLATotalChecks) * 100, true
+}
+
+// startSLAEvaluator periodically checks SLA targets and error-budget burn rates
+func (m *Monitor) startSLAEvaluator() {
+	ticker := time.NewTicker(slaEvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.evaluateSLAs()
+		}
+	}
+}
+
+// evaluateSLAs sends SLA breach and burn-rate alerts through the existing alert channels
+func (m *Monitor) evaluateSLAs() {
+	if !m.IsActive() {
+		return
+	}
+
+	m.mu.RLock()
+	var states []*MonitorState
+	for _, state := range m.states {
+		states = append(states, state)
+	}
+	m.mu.RUnlock()
+
+	window := m.config.SLABurnRateWindow.Duration
+
+	for _, state := range states {
+		state.mu.RLock()
+		target := state.Endpoint.SLATarget
+		enabled := state.Enabled && state.ChecksHealth()
+		state.mu.RUnlock()
+
+		if target <= 0 || !enabled {
+			continue
+		}
+
+		// Burn rate is measured over the recent window from history
+		burnRate := -1.0
+		if m.db != nil {
+			now := time.Now()
+			records, err := m.db.GetHealthHistoryRange(state.ID, now.Add(-window), now)
+			if err != nil {
+				logger.Errorf("Error loading history for SLA evaluation: %v", err)
+			} else if uptime := models.UptimePercent(records); uptime >= 0 {
+				burnRate = (100 - uptime) / (100 - target)
+			}
+		}
+
+		state.mu.Lock()
+		m.evaluateSLAState(state, target, burnRate, window)
+		state.mu.Unlock()
+	}
+}
+
+// evaluateSLAState applies breach and burn-rate rules to a single endpoint.
+// Caller must hold the state lock.
+func (m *Monitor) evaluateSLAState(state *MonitorState, target, burnRate float64, window time.Duration) {
+	suppressed := state.AlertsSuppressed
+
+	if availability, ok := monthlyAvailability(state.EndpointState); ok {
+		switch {
+		case availability < target && !state.SLABreachAlerted:
+			state.SLABreachAlerted = true
+			logger.Infof("[%s] SLA breached: %.3f%% < %.3f%%", state.Endpoint.Name, availability, target)
+			if !suppressed {
+				m.alerterFor(state.Endpoint.ProjectID).SendSLAAlert(state.Endpoint, state.EndpointState, "sla_breach",
+					fmt.Sprintf("Monthly availability %.3f%% is below the SLA target of %.3f%%", availability, target))
+			}
+		case availability >= target && state.SLABreachAlerted:
+			state.SLABreachAlerted = false
+		}
+	}
+
+	if burnRate < 0 {
+		return
+	}
+
+	threshold := m.config.SLABurnRateThreshold
+	switch {
+	case burnRate >= threshold && !state.BurnRateAlerted:
+		state.BurnRateAlerted = true
+		logger.Infof("[%s] Error budget burning at %.1fx", state.Endpoint.Name, burnRate)
+		if !suppressed {
+			m.alerterFor(state.Endpoint.ProjectID).SendSLAAlert(state.Endpoint, state.EndpointState, "sla_burn_rate",
+				fmt.Sprintf("Error budget is burning at %.1fx the sustainable rate over the last %v (threshold %.1fx)", burnRate, window, threshold))
+		}
+	case burnRate < threshold/2 && state.BurnRateAlerted:
+		// Hysteresis so a hovering burn rate doesn't flap
+		state.BurnRateAlerted = false
+	}
+}
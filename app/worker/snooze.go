@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// snoozeCheckInterval is how often expired snoozes are lifted
+const snoozeCheckInterval = 15 * time.Second
+
+// startSnoozeExpiry lifts alert snoozes once their time is up
+func (m *Monitor) startSnoozeExpiry() {
+	ticker := time.NewTicker(snoozeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			// Only the leader expires snoozes; the standby gets the change through the HA sync file
+			if m.IsActive() {
+				m.expireSnoozes(time.Now())
+			}
+		}
+	}
+}
+
+// expireSnoozes unsuppresses every endpoint whose snooze ended before now
+func (m *Monitor) expireSnoozes(now time.Time) {
+	var expired []string
+	m.mu.RLock()
+	for id, state := range m.states {
+		state.mu.RLock()
+		if state.AlertsSuppressed && !state.SuppressedUntil.IsZero() && !state.SuppressedUntil.After(now) {
+			expired = append(expired, id)
+		}
+		state.mu.RUnlock()
+	}
+	m.mu.RUnlock()
+
+	for _, id := range expired {
+		if err := m.UnsuppressAlerts(id); err != nil {
+			logger.Errorf("[%s] Failed to end alert snooze: %v", id, err)
+			continue
+		}
+		logger.Infof("[%s] Alert snooze ended", id)
+	}
+}
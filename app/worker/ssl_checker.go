@@ -1,11 +1,28 @@
 package worker
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
 )
 
+// sslDialTimeout bounds the TLS handshake so a hung server can't stall SSL checks
+const sslDialTimeout = 15 * time.Second
+
+// sslProbeBudget bounds a whole SSL check: the certificate handshake and the legacy TLS probe
+const sslProbeBudget = 2 * sslDialTimeout
+
+// legacyTLSInterval is how often the legacy TLS probe runs against an endpoint
+const legacyTLSInterval = 24 * time.Hour
+
 // SSLCertInfo holds SSL certificate information
 type SSLCertInfo struct {
 	Expiry          time.Time
@@ -13,10 +30,29 @@ type SSLCertInfo struct {
 	ExpiringSoon    bool
 	IsHTTPS         bool
 	Error           string
+	Uncovered       []string // Monitored or alternate hostnames missing from the certificate's SANs
+	TLSVersion      uint16   // Negotiated protocol version
+	CipherSuite     uint16   // Negotiated cipher suite
+	LegacyTLS       uint16   // Highest of TLS 1.0 and 1.1 the server still accepts, 0 if neither
+	LegacyProbed    bool     // Whether the legacy TLS probe ran; LegacyTLS is unset otherwise
 }
 
 // CheckSSLCertificate checks the SSL certificate expiry for a given URL
 func CheckSSLCertificate(urlStr string, warningDays int) SSLCertInfo {
+	return CheckSSLCertificateFor(structs.Endpoint{URL: urlStr}, warningDays)
+}
+
+// CheckSSLCertificateFor checks the SSL certificate expiry for an endpoint,
+// honouring its resolve_to and host_header overrides
+func CheckSSLCertificateFor(endpoint structs.Endpoint, warningDays int) SSLCertInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), sslProbeBudget)
+	defer cancel()
+	return checkSSL(ctx, endpoint, warningDays, true)
+}
+
+// checkSSL checks an endpoint's certificate within ctx, probing for legacy TLS if asked
+func checkSSL(ctx context.Context, endpoint structs.Endpoint, warningDays int, probeLegacy bool) SSLCertInfo {
+	urlStr := endpoint.URL
 	info := SSLCertInfo{
 		IsHTTPS: false,
 	}
@@ -49,10 +85,15 @@ func CheckSSLCertificate(urlStr string, warningDays int) SSLCertInfo {
 	}
 
 	// Connect with timeout and get certificate
-	conn, err := tls.Dial("tcp", address, &tls.Config{
-		InsecureSkipVerify: true,
-		ServerName:         hostname,
-	})
+	name := serverName(endpoint, parsedURL)
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: sslDialTimeout},
+		Config: &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         name,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", dialAddress(endpoint, address))
 	if err != nil {
 		info.Error = "Failed to connect: " + err.Error()
 		return info
@@ -60,7 +101,10 @@ func CheckSSLCertificate(urlStr string, warningDays int) SSLCertInfo {
 	defer conn.Close()
 
 	// Get certificate chain
-	certs := conn.ConnectionState().PeerCertificates
+	connState := conn.(*tls.Conn).ConnectionState()
+	info.TLSVersion = connState.Version
+	info.CipherSuite = connState.CipherSuite
+	certs := connState.PeerCertificates
 	if len(certs) == 0 {
 		info.Error = "No certificates found"
 		return info
@@ -78,5 +122,68 @@ func CheckSSLCertificate(urlStr string, warningDays int) SSLCertInfo {
 	// Check if expiring within configured warning days
 	info.ExpiringSoon = info.DaysToExpiry <= warningDays && info.DaysToExpiry >= 0
 
+	info.Uncovered = uncoveredHosts(cert, append([]string{name}, endpoint.AlternateHosts...))
+	if probeLegacy {
+		info.LegacyTLS = legacyTLSAccepted(ctx, endpoint, name, address)
+		// A probe cut short by the deadline says nothing about legacy support
+		info.LegacyProbed = ctx.Err() == nil
+	}
+
 	return info
 }
+
+// legacyTLSAccepted offers the server only TLS 1.0 and 1.1 with every cipher suite Go knows,
+// returning the version it accepts or 0 if it refuses both
+func legacyTLSAccepted(ctx context.Context, endpoint structs.Endpoint, name, address string) uint16 {
+	var suites []uint16
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites = append(suites, suite.ID)
+	}
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: sslDialTimeout},
+		Config: &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         name,
+			MinVersion:         tls.VersionTLS10,
+			MaxVersion:         tls.VersionTLS11,
+			CipherSuites:       suites,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", dialAddress(endpoint, address))
+	if err != nil {
+		return 0
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().Version
+}
+
+// uncoveredHosts returns the hostnames a certificate's SANs do not cover, as a browser
+// would judge them: wildcards match one label and the common name is ignored
+func uncoveredHosts(cert *x509.Certificate, hosts []string) []string {
+	var uncovered []string
+	seen := make(map[string]bool)
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSuffix(host, "."))
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		if cert.VerifyHostname(host) != nil {
+			uncovered = append(uncovered, host)
+		}
+	}
+	return uncovered
+}
+
+
+// CertFingerprint returns the hex-encoded SHA-256 fingerprint of a certificate
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeFingerprint lowercases a fingerprint and strips colon or space separators
+func NormalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ToLower(fingerprint)
+	return strings.NewReplacer(":", "", " ", "").Replace(fingerprint)
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// compiledExprs caches success expressions by source, so each is parsed once
+var compiledExprs sync.Map
+
+// evalSuccessExpression evaluates an endpoint's success expression against a response,
+// returning an error describing why the check failed
+func evalSuccessExpression(source string, resp *http.Response, body *responseBody, latency time.Duration) error {
+	var expr *utils.Expr
+	if cached, ok := compiledExprs.Load(source); ok {
+		expr = cached.(*utils.Expr)
+	} else {
+		compiled, err := utils.CompileExpr(source)
+		if err != nil {
+			return fmt.Errorf("invalid success expression: %v", err)
+		}
+		compiledExprs.Store(source, compiled)
+		expr = compiled
+	}
+
+	env := &utils.ExprEnv{Status: resp.StatusCode, Latency: latency, Headers: resp.Header}
+	if expr.NeedsBody() {
+		data, err := body.read()
+		if err != nil {
+			return err
+		}
+		env.Body = data
+	}
+
+	passed, err := expr.Eval(env)
+	if err != nil {
+		return fmt.Errorf("success expression failed: %v", err)
+	}
+	if !passed {
+		return fmt.Errorf("success expression not met (status %d, latency %v): %s", resp.StatusCode, latency.Round(time.Millisecond), source)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// syslogSDID is the structured data element carrying alert details
+const syslogSDID = "sitewatch@32473"
+
+// syslogParams orders the structured data parameters
+var syslogParams = []string{"endpoint", "endpoint_id", "url", "project", "alert_type", "status", "priority", "severity", "error", "response_time_ms", "owner", "runbook_url"}
+
+// syslogSeverity maps an alert severity to a syslog severity
+func syslogSeverity(severity structs.Severity) int {
+	switch severity {
+	case structs.SeverityCritical:
+		return utils.SyslogCritical
+	case structs.SeverityWarning:
+		return utils.SyslogWarning
+	default:
+		return utils.SyslogInfo
+	}
+}
+
+// sendSyslogAlert sends an RFC 5424 message for SIEM ingestion
+func (a *Alerter) sendSyslogAlert(subject, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) {
+	cfg := a.config.Syslog
+
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+	appName := cfg.AppName
+	if appName == "" {
+		appName = "sitewatch"
+	}
+	facility := 16 // local0
+	if cfg.Facility != "" {
+		code, err := utils.SyslogFacility(cfg.Facility)
+		if err != nil {
+			logger.Errorf("Invalid syslog facility, using local0: %v", err)
+		} else {
+			facility = code
+		}
+	}
+
+	severity := alertSeverity(alertType, endpoint, state)
+	params := map[string]string{
+		"endpoint":         endpoint.Name,
+		"endpoint_id":      state.ID,
+		"url":              endpoint.URL,
+		"alert_type":       alertType,
+		"status":           string(state.Status),
+		"priority":         string(endpoint.Priority),
+		"severity":         string(severity),
+		"response_time_ms": strconv.FormatInt(state.ResponseTime.Milliseconds(), 10),
+	}
+	if endpoint.ProjectID != "" {
+		params["project"] = endpoint.ProjectID
+	}
+	if state.LastError != "" {
+		params["error"] = state.LastError
+	}
+	if endpoint.Owner != "" {
+		params["owner"] = endpoint.Owner
+	}
+	if endpoint.RunbookURL != "" {
+		params["runbook_url"] = endpoint.RunbookURL
+	}
+
+	message := utils.SyslogMessage(facility, syslogSeverity(severity), appName, alertType,
+		syslogSDID, params, syslogParams, subject)
+
+	if err := utils.SendSyslog(network, cfg.Address, message, 10*time.Second); err != nil {
+		logger.Errorf("Failed to send syslog alert: %v", err)
+		return
+	}
+	logger.Infof("Syslog alert sent successfully for endpoint: %s", endpoint.Name)
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// failureThresholdReached reports whether the failures since the last success should
+// mark the endpoint unhealthy. Network and application failures are counted against
+// their own thresholds; a mix of both trips once it reaches the larger of the two.
+// With failure_duration set the endpoint must also have been failing for that long.
+// Caller must hold the state lock.
+func failureThresholdReached(state *structs.EndpointState) bool {
+	if !heldFor(state.FailingSince, state.LastCheck, state.Endpoint.FailureDuration.Duration) {
+		return false
+	}
+
+	network := state.Endpoint.FailureThresholdFor(structs.FailureNetwork)
+	application := state.Endpoint.FailureThresholdFor(structs.FailureApplication)
+
+	if state.ConsecutiveNetwork >= network || state.ConsecutiveApp >= application {
+		return true
+	}
+	return state.ConsecutiveFailures >= max(network, application)
+}
+
+// successThresholdReached reports whether the successes since the last failure should
+// mark the endpoint healthy: success_threshold of them, spanning success_duration when
+// set. Caller must hold the state lock.
+func successThresholdReached(state *structs.EndpointState) bool {
+	return state.ConsecutiveSuccesses >= state.Endpoint.SuccessThreshold &&
+		heldFor(state.HealthySince, state.LastCheck, state.Endpoint.SuccessDuration.Duration)
+}
+
+// heldFor reports whether a streak that began at since has lasted at least d by now.
+// A streak with no recorded start, e.g. from before an upgrade, is taken as long enough.
+func heldFor(since, now time.Time, d time.Duration) bool {
+	return d <= 0 || since.IsZero() || now.Sub(since) >= d
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"crypto/tls"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// TLSVersionName names a TLS protocol version, or "" for none
+func TLSVersionName(version uint16) string {
+	if version == 0 {
+		return ""
+	}
+	return tls.VersionName(version)
+}
+
+// TLSCipherName names a cipher suite, or "" for none
+func TLSCipherName(suite uint16) string {
+	if suite == 0 {
+		return ""
+	}
+	return tls.CipherSuiteName(suite)
+}
+
+// updateTLSProtocol records the negotiated protocol and cipher of an endpoint and alerts when
+// it starts or stops accepting TLS 1.0/1.1, or negotiates a lower version than last time.
+// Caller must hold the state lock.
+func (m *Monitor) updateTLSProtocol(state *MonitorState, info SSLCertInfo) {
+	// A failed handshake tells nothing about the protocol
+	if info.TLSVersion == 0 {
+		return
+	}
+
+	previousVersion, previousLegacy := state.TLSVersion, state.TLSLegacy
+	state.TLSVersion, state.TLSCipher, state.TLSLegacy = info.TLSVersion, info.CipherSuite, info.LegacyTLS
+
+	alerter := m.alerterFor(state.Endpoint.ProjectID)
+	suppressed := state.AlertsSuppressed
+	name := state.Endpoint.Name
+
+	if previousVersion != 0 && info.TLSVersion < previousVersion {
+		logger.Errorf("[%s] TLS downgraded from %s to %s", name, TLSVersionName(previousVersion), TLSVersionName(info.TLSVersion))
+		if !suppressed {
+			alerter.SendTLSAlert(state.Endpoint, state.EndpointState, "tls_downgrade",
+				"from", TLSVersionName(previousVersion), "to", TLSVersionName(info.TLSVersion), "cipher", TLSCipherName(info.CipherSuite))
+		}
+	}
+
+	switch {
+	case info.LegacyTLS != 0 && previousLegacy == 0:
+		logger.Errorf("[%s] Server still accepts %s", name, TLSVersionName(info.LegacyTLS))
+		if !suppressed {
+			alerter.SendTLSAlert(state.Endpoint, state.EndpointState, "tls_legacy", "version", TLSVersionName(info.LegacyTLS))
+		}
+	case info.LegacyTLS == 0 && previousLegacy != 0:
+		logger.Infof("[%s] Server no longer accepts %s", name, TLSVersionName(previousLegacy))
+		if !suppressed {
+			alerter.SendTLSAlert(state.Endpoint, state.EndpointState, "tls_legacy_recovery", "version", TLSVersionName(previousLegacy))
+		}
+	}
+}
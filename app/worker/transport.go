@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// serverName returns the TLS SNI name for an endpoint, preferring the host header override
+func serverName(endpoint structs.Endpoint, parsedURL *url.URL) string {
+	if endpoint.HostHeader != "" {
+		if host, _, err := net.SplitHostPort(endpoint.HostHeader); err == nil {
+			return host
+		}
+		return endpoint.HostHeader
+	}
+	return parsedURL.Hostname()
+}
+
+// dialAddress returns the address to connect to, replacing the URL host with resolve_to when
+// set. Other hosts, such as a redirect target, are dialed as they are.
+func dialAddress(endpoint structs.Endpoint, addr string) string {
+	if endpoint.ResolveTo == "" || !sameHost(endpoint, addr) {
+		return addr
+	}
+	if _, _, err := net.SplitHostPort(endpoint.ResolveTo); err == nil {
+		return endpoint.ResolveTo
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return endpoint.ResolveTo
+	}
+	return net.JoinHostPort(endpoint.ResolveTo, port)
+}
+
+// sameHost reports whether addr, a host or host:port, is the endpoint URL's host
+func sameHost(endpoint structs.Endpoint, addr string) bool {
+	parsedURL, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return false
+	}
+	host := addr
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		host = h
+	}
+	return strings.EqualFold(host, parsedURL.Hostname())
+}
+
+// maxRedirects matches the limit net/http applies by default
+const maxRedirects = 10
+
+// sameHostRedirects follows redirects on the endpoint's own host only. Requests to other
+// hosts would go out with the SNI name pinned for the endpoint, so the redirect response is
+// the result instead.
+func sameHostRedirects(endpoint structs.Endpoint) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if !sameHost(endpoint, req.URL.Host) {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+}
+
+// newTransport builds an HTTP transport honouring resolve_to and host_header overrides
+func newTransport(endpoint structs.Endpoint) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = endpoint.DisableKeepAlive
+	if endpoint.MaxIdleConns > 0 {
+		transport.MaxIdleConnsPerHost = endpoint.MaxIdleConns
+	}
+
+	// Self-signed certificates are accepted; cert_fingerprint pins them instead
+	if endpoint.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	if endpoint.ResolveTo == "" && endpoint.HostHeader == "" {
+		return transport
+	}
+
+	if parsedURL, err := url.Parse(endpoint.URL); err == nil {
+		transport.TLSClientConfig = &tls.Config{
+			ServerName:         serverName(endpoint, parsedURL),
+			InsecureSkipVerify: endpoint.InsecureSkipVerify,
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, dialAddress(endpoint, addr))
+	}
+
+	return transport
+}
+
+// TransportPool shares HTTP transports between checks so connections are kept alive
+type TransportPool struct {
+	transports map[string]*http.Transport
+	requests   uint64
+	reused     uint64
+	mu         sync.Mutex
+}
+
+// PoolStats reports how many check requests reused a pooled connection
+type PoolStats struct {
+	Transports int    `json:"transports"`
+	Requests   uint64 `json:"requests"`
+	Reused     uint64 `json:"reused"`
+}
+
+// NewTransportPool creates an empty transport pool
+func NewTransportPool() *TransportPool {
+	return &TransportPool{
+		transports: make(map[string]*http.Transport),
+	}
+}
+
+// transportKey groups endpoints that can safely share a transport. A transport with
+// resolve_to or host_header pins the SNI name and dial address of its endpoint's URL host,
+// so it is only shared by endpoints on the same host.
+func transportKey(endpoint structs.Endpoint) string {
+	var host string
+	if endpoint.ResolveTo != "" || endpoint.HostHeader != "" {
+		if parsedURL, err := url.Parse(endpoint.URL); err == nil {
+			host = parsedURL.Host
+		}
+	}
+	return fmt.Sprintf("%s|%s|%s|%t|%d|%t", host, endpoint.ResolveTo, endpoint.HostHeader, endpoint.DisableKeepAlive, endpoint.MaxIdleConns, endpoint.InsecureSkipVerify)
+}
+
+// Client returns an HTTP client backed by the pooled transport for an endpoint
+func (p *TransportPool) Client(endpoint structs.Endpoint, timeout time.Duration) *http.Client {
+	key := transportKey(endpoint)
+
+	p.mu.Lock()
+	transport, ok := p.transports[key]
+	if !ok {
+		transport = newTransport(endpoint)
+		p.transports[key] = transport
+	}
+	p.mu.Unlock()
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+	if endpoint.ResolveTo != "" || endpoint.HostHeader != "" {
+		client.CheckRedirect = sameHostRedirects(endpoint)
+	}
+	return client
+}
+
+// WithTrace attaches connection reuse tracking to a request context
+func (p *TransportPool) WithTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			atomic.AddUint64(&p.requests, 1)
+			if info.Reused {
+				atomic.AddUint64(&p.reused, 1)
+			}
+		},
+	})
+}
+
+// Stats returns connection reuse counters for the pool
+func (p *TransportPool) Stats() PoolStats {
+	p.mu.Lock()
+	transports := len(p.transports)
+	p.mu.Unlock()
+
+	return PoolStats{
+		Transports: transports,
+		Requests:   atomic.LoadUint64(&p.requests),
+		Reused:     atomic.LoadUint64(&p.reused),
+	}
+}
+
+// CloseIdleConnections closes idle connections on every pooled transport
+func (p *TransportPool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, transport := range p.transports {
+		transport.CloseIdleConnections()
+	}
+}
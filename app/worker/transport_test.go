@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// BenchmarkCheckEndpointTLS runs sequential checks against a local TLS server through the
+// transport pool and reports the share of requests that reused a pooled connection
+func BenchmarkCheckEndpointTLS(b *testing.B) {
+	for _, bench := range []struct {
+		name string
+		body string
+	}{
+		{"small-body", "ok"},
+		{"large-body", strings.Repeat("x", 4<<20)},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, bench.body)
+			}))
+			// The SSL check's legacy protocol probes fail the handshake on purpose
+			server.Config.ErrorLog = log.New(io.Discard, "", 0)
+			server.StartTLS()
+			defer server.Close()
+
+			m := newTestMonitor(b)
+			logger.InfoLogger.SetOutput(io.Discard)
+			logger.DebugLogger.SetOutput(io.Discard)
+			stored := &structs.StoredEndpoint{
+				ID:                 "bench",
+				Name:               "bench",
+				URL:                server.URL,
+				Method:             http.MethodGet,
+				Timeout:            10 * time.Second,
+				ExpectedStatus:     http.StatusOK,
+				InsecureSkipVerify: true,
+				Enabled:            true,
+				MonitorHealth:      true,
+			}
+			if err := m.AddEndpoint(stored); err != nil {
+				b.Fatal(err)
+			}
+			state := m.states[stored.ID]
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.checkEndpoint(state)
+			}
+			b.StopTimer()
+
+			stats := m.pool.Stats()
+			if stats.Requests > 0 {
+				b.ReportMetric(float64(stats.Reused)/float64(stats.Requests), "reused/req")
+			}
+			m.pool.CloseIdleConnections()
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+)
+
+// ReloadUsers reloads users from the database and updates every alerter's subscribers
+func (m *Monitor) ReloadUsers() {
+	users, err := m.db.GetAllUsers()
+	if err != nil {
+		logger.Errorf("Failed to load users: %v", err)
+		return
+	}
+
+	m.projectMu.Lock()
+	m.users = users
+	m.projectMu.Unlock()
+
+	for _, alerter := range m.allAlerters() {
+		alerter.SetUsers(users)
+	}
+
+	logger.Infof("Loaded %d users", len(users))
+}
+
+// currentUsers returns the loaded users
+func (m *Monitor) currentUsers() []*structs.User {
+	m.projectMu.RLock()
+	defer m.projectMu.RUnlock()
+	return m.users
+}
+
+// allAlerters returns the global alerter followed by every project alerter
+func (m *Monitor) allAlerters() []*Alerter {
+	m.projectMu.RLock()
+	defer m.projectMu.RUnlock()
+
+	alerters := []*Alerter{m.alerter}
+	for _, alerter := range m.projectAlerters {
+		alerters = append(alerters, alerter)
+	}
+	return alerters
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+)
+
+// inflightCheck tracks a running health check so the watchdog can cancel it
+type inflightCheck struct {
+	started  time.Time
+	deadline time.Time
+	cancel   context.CancelFunc
+	// finished is closed when the check completes
+	finished chan struct{}
+	// cancelled is set once the watchdog has cancelled the check
+	cancelled bool
+}
+
+// WatchdogStats reports in-flight and force-cancelled checks
+type WatchdogStats struct {
+	InFlight      int    `json:"in_flight"`
+	StuckChecks   uint64 `json:"stuck_checks"`
+	SkippedChecks uint64 `json:"skipped_checks"`
+	// CancelledRunning counts cancelled checks that have not returned yet
+	CancelledRunning int `json:"cancelled_running"`
+}
+
+// beginCheck registers a check for an endpoint and returns its context.
+// It returns false if a previous check for the same endpoint is still running.
+func (m *Monitor) beginCheck(id string, timeout time.Duration) (context.Context, func(), bool) {
+	m.inflightMu.Lock()
+	defer m.inflightMu.Unlock()
+
+	if _, running := m.inflight[id]; running {
+		return nil, nil, false
+	}
+
+	ctx, cancel := context.WithCancel(m.checkCtx)
+	now := time.Now()
+	check := &inflightCheck{
+		started:  now,
+		deadline: now.Add(timeout + m.config.WatchdogGrace.Duration),
+		cancel:   cancel,
+		finished: make(chan struct{}),
+	}
+	m.inflight[id] = check
+
+	done := func() {
+		cancel()
+		m.inflightMu.Lock()
+		if m.inflight[id] == check {
+			delete(m.inflight, id)
+		}
+		m.inflightMu.Unlock()
+		close(check.finished)
+	}
+
+	return ctx, done, true
+}
+
+// checkRunning reports whether a check for the endpoint is in flight
+func (m *Monitor) checkRunning(id string) bool {
+	m.inflightMu.Lock()
+	defer m.inflightMu.Unlock()
+	_, running := m.inflight[id]
+	return running
+}
+
+// waitForCheck blocks until the endpoint's running check, if any, has completed
+func (m *Monitor) waitForCheck(id string) {
+	m.inflightMu.Lock()
+	check, running := m.inflight[id]
+	m.inflightMu.Unlock()
+	if running {
+		<-check.finished
+	}
+}
+
+// startWatchdog periodically cancels checks that have exceeded their timeout plus grace
+func (m *Monitor) startWatchdog() {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.cancelStuckChecks()
+		}
+	}
+}
+
+// cancelStuckChecks force-cancels overdue checks. A cancelled check keeps its slot until it
+// returns, so no further check piles up behind work that ignores the cancellation.
+func (m *Monitor) cancelStuckChecks() {
+	now := time.Now()
+
+	m.inflightMu.Lock()
+	defer m.inflightMu.Unlock()
+
+	for id, check := range m.inflight {
+		if check.cancelled || now.Before(check.deadline) {
+			continue
+		}
+
+		check.cancel()
+		check.cancelled = true
+		atomic.AddUint64(&m.stuckChecks, 1)
+		logger.Errorf("[%s] Watchdog cancelled stuck check (running for %v)", id, now.Sub(check.started).Round(time.Second))
+	}
+}
+
+// GetWatchdogStats returns watchdog counters for the status API
+func (m *Monitor) GetWatchdogStats() WatchdogStats {
+	m.inflightMu.Lock()
+	inFlight, cancelled := len(m.inflight), 0
+	for _, check := range m.inflight {
+		if check.cancelled {
+			cancelled++
+		}
+	}
+	m.inflightMu.Unlock()
+
+	return WatchdogStats{
+		InFlight:         inFlight,
+		StuckChecks:      atomic.LoadUint64(&m.stuckChecks),
+		SkippedChecks:    atomic.LoadUint64(&m.overlapSkips),
+		CancelledRunning: cancelled,
+	}
+}
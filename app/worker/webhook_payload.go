@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// webhookVersion returns the schema to send to a receiver, falling back to the alerting config
+func (a *Alerter) webhookVersion(version int) int {
+	if version == 0 {
+		version = a.config.WebhookVersion
+	}
+	if version == 0 {
+		return structs.WebhookVersion1
+	}
+	return version
+}
+
+// webhookPayloadV1 builds the original alert payload. v1 is frozen: receivers depend on
+// its exact shape, so fields must not be renamed, removed or retyped. Change v2 instead.
+func (a *Alerter) webhookPayloadV1(alertID, subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) map[string]interface{} {
+	payload := map[string]interface{}{
+		"alert_id":   alertID,
+		"subject":    subject,
+		"message":    message,
+		"alert_type": alertType,
+		"severity":   string(alertSeverity(alertType, endpoint, state)),
+		"escalate":   a.shouldEscalate(endpoint.Priority),
+		"endpoint": map[string]interface{}{
+			"name":        endpoint.Name,
+			"url":         endpoint.URL,
+			"method":      endpoint.Method,
+			"priority":    string(endpoint.Priority),
+			"description": endpoint.Description,
+			"owner":       endpoint.Owner,
+			"runbook_url": endpoint.RunbookURL,
+			"labels":      endpoint.Labels,
+		},
+		"state": map[string]interface{}{
+			"status":               string(state.Status),
+			"consecutive_failures": state.ConsecutiveFailures,
+			"last_error":           state.LastError,
+			"response_time_ms":     state.ResponseTime.Milliseconds(),
+			"last_check":           state.LastCheck.In(a.location()).Format(time.RFC3339),
+		},
+		"timestamp": time.Now().In(a.location()).Format(time.RFC3339),
+	}
+
+	for key, value := range a.config.CustomFields {
+		payload[key] = value
+	}
+	return payload
+}
+
+// webhookV2 is the flat alert payload of schema version 2. Durations are ISO 8601, times
+// RFC 3339, and custom fields sit under custom_fields so they cannot shadow built-in ones.
+type webhookV2 struct {
+	SchemaVersion       int               `json:"schema_version"`
+	AlertID             string            `json:"alert_id"`
+	AlertType           string            `json:"alert_type"`
+	Severity            string            `json:"severity"`
+	Escalate            bool              `json:"escalate"`
+	Subject             string            `json:"subject"`
+	Message             string            `json:"message"`
+	EndpointID          string            `json:"endpoint_id"`
+	EndpointName        string            `json:"endpoint_name"`
+	EndpointURL         string            `json:"endpoint_url"`
+	EndpointMethod      string            `json:"endpoint_method"`
+	Priority            string            `json:"priority"`
+	ProjectID           string            `json:"project_id"`
+	Description         string            `json:"description"`
+	Owner               string            `json:"owner"`
+	RunbookURL          string            `json:"runbook_url"`
+	Tags                []string          `json:"tags"`
+	Labels              map[string]string `json:"labels"`
+	Status              string            `json:"status"`
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	FailureKind         string            `json:"failure_kind"`
+	LastError           string            `json:"last_error"`
+	ResponseTime        string            `json:"response_time"`
+	CheckInterval       string            `json:"check_interval"`
+	StatusDuration      string            `json:"status_duration"`
+	StatusSince         string            `json:"status_since"`
+	LastCheck           string            `json:"last_check"`
+	LastSuccess         string            `json:"last_success"`
+	Timestamp           string            `json:"timestamp"`
+	CustomFields        map[string]string `json:"custom_fields"`
+}
+
+// webhookPayloadV2 builds the version 2 alert payload
+func (a *Alerter) webhookPayloadV2(alertID, subject, message, alertType string, endpoint structs.Endpoint, state *structs.EndpointState) webhookV2 {
+	now := time.Now()
+	payload := webhookV2{
+		SchemaVersion:       structs.WebhookVersion2,
+		AlertID:             alertID,
+		AlertType:           alertType,
+		Severity:            string(alertSeverity(alertType, endpoint, state)),
+		Escalate:            a.shouldEscalate(endpoint.Priority),
+		Subject:             subject,
+		Message:             message,
+		EndpointID:          state.ID,
+		EndpointName:        endpoint.Name,
+		EndpointURL:         endpoint.URL,
+		EndpointMethod:      endpoint.Method,
+		Priority:            string(endpoint.Priority),
+		ProjectID:           endpoint.ProjectID,
+		Description:         endpoint.Description,
+		Owner:               endpoint.Owner,
+		RunbookURL:          endpoint.RunbookURL,
+		Tags:                endpoint.Tags,
+		Labels:              endpoint.Labels,
+		Status:              string(state.Status),
+		ConsecutiveFailures: state.ConsecutiveFailures,
+		FailureKind:         string(state.LastFailureKind),
+		LastError:           state.LastError,
+		ResponseTime:        utils.FormatISODuration(state.ResponseTime),
+		CheckInterval:       utils.FormatISODuration(state.CheckInterval),
+		LastCheck:           a.formatTime(state.LastCheck),
+		LastSuccess:         a.formatTime(state.LastSuccess),
+		StatusSince:         a.formatTime(state.LastStatusChange),
+		Timestamp:           a.formatTime(now),
+		CustomFields:        a.config.CustomFields,
+	}
+	if !state.LastStatusChange.IsZero() {
+		payload.StatusDuration = utils.FormatISODuration(now.Sub(state.LastStatusChange).Round(time.Second))
+	}
+
+	// Lists and maps are always present so receivers need no null checks
+	if payload.Tags == nil {
+		payload.Tags = []string{}
+	}
+	if payload.Labels == nil {
+		payload.Labels = map[string]string{}
+	}
+	if payload.CustomFields == nil {
+		payload.CustomFields = map[string]string{}
+	}
+	return payload
+}
+
+// formatTime formats t as RFC 3339 in the alert timezone, or "" when unset
+func (a *Alerter) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(a.location()).Format(time.RFC3339)
+}
+
+// sslSummaryPayload builds the SSL expiry summary webhook in the given schema version
+func (a *Alerter) sslSummaryPayload(version int, alertID string, summary SSLSummary) interface{} {
+	subject := utils.Translate(a.language(structs.ChannelWebhook), "ssl.subject", "count", strconv.Itoa(summary.Count()))
+
+	if version != structs.WebhookVersion2 {
+		// v1 is frozen like the alert payload, so it lists only expiring certificates
+		certs := make([]map[string]interface{}, 0, len(summary.Expiring))
+		for _, cert := range summary.Expiring {
+			certs = append(certs, map[string]interface{}{
+				"name":           cert.EndpointName,
+				"url":            cert.URL,
+				"expiry_date":    cert.ExpiryDate.Format(time.RFC3339),
+				"days_to_expiry": cert.DaysToExpiry,
+				"severity":       sslSeverity(cert.DaysToExpiry),
+			})
+		}
+
+		payload := map[string]interface{}{
+			"alert_id":     alertID,
+			"subject":      subject,
+			"alert_type":   "ssl_expiry_summary",
+			"certificates": certs,
+			"timestamp":    time.Now().Format(time.RFC3339),
+		}
+		for key, value := range a.config.CustomFields {
+			payload[key] = value
+		}
+		return payload
+	}
+
+	type certificate struct {
+		EndpointName string `json:"endpoint_name"`
+		EndpointURL  string `json:"endpoint_url"`
+		ExpiresAt    string `json:"expires_at"`
+		ExpiresIn    string `json:"expires_in"`
+		DaysToExpiry int    `json:"days_to_expiry"`
+		Severity     string `json:"severity"`
+	}
+	type expiredCertificate struct {
+		EndpointName    string `json:"endpoint_name"`
+		EndpointURL     string `json:"endpoint_url"`
+		ExpiredAt       string `json:"expired_at"`
+		ExpiredFor      string `json:"expired_for"`
+		DaysSinceExpiry int    `json:"days_since_expiry"`
+	}
+	type failedCertificate struct {
+		EndpointName string `json:"endpoint_name"`
+		EndpointURL  string `json:"endpoint_url"`
+		Error        string `json:"error"`
+	}
+	now := time.Now()
+	certs := make([]certificate, 0, len(summary.Expiring))
+	for _, cert := range summary.Expiring {
+		certs = append(certs, certificate{
+			EndpointName: cert.EndpointName,
+			EndpointURL:  cert.URL,
+			ExpiresAt:    a.formatTime(cert.ExpiryDate),
+			ExpiresIn:    utils.FormatISODuration(cert.ExpiryDate.Sub(now).Round(time.Second)),
+			DaysToExpiry: cert.DaysToExpiry,
+			Severity:     sslSeverity(cert.DaysToExpiry),
+		})
+	}
+	expired := make([]expiredCertificate, 0, len(summary.Expired))
+	for _, cert := range summary.Expired {
+		expired = append(expired, expiredCertificate{
+			EndpointName:    cert.EndpointName,
+			EndpointURL:     cert.URL,
+			ExpiredAt:       a.formatTime(cert.ExpiryDate),
+			ExpiredFor:      utils.FormatISODuration(now.Sub(cert.ExpiryDate).Round(time.Second)),
+			DaysSinceExpiry: -cert.DaysToExpiry,
+		})
+	}
+	failed := make([]failedCertificate, 0, len(summary.Failed))
+	for _, cert := range summary.Failed {
+		failed = append(failed, failedCertificate{
+			EndpointName: cert.EndpointName,
+			EndpointURL:  cert.URL,
+			Error:        cert.Error,
+		})
+	}
+
+	customFields := a.config.CustomFields
+	if customFields == nil {
+		customFields = map[string]string{}
+	}
+	return map[string]interface{}{
+		"schema_version": structs.WebhookVersion2,
+		"alert_id":       alertID,
+		"alert_type":     "ssl_expiry_summary",
+		"subject":        subject,
+		"certificates":   certs,
+		"expired":        expired,
+		"check_failed":   failed,
+		"timestamp":      a.formatTime(now),
+		"custom_fields":  customFields,
+	}
+}
@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ashanmugaraja/cronzee/app/logger"
+	"github.com/ashanmugaraja/cronzee/app/models"
+	"github.com/ashanmugaraja/cronzee/app/structs"
+	"github.com/ashanmugaraja/cronzee/app/utils"
+)
+
+// vapidKeySetting stores the VAPID private key in the settings bucket
+const vapidKeySetting = "vapid_private_key"
+
+// webPushTTL is how long push services keep an undelivered notification
+const webPushTTL = 24 * time.Hour
+
+// WebPusher sends Web Push notifications to browser subscriptions
+type WebPusher struct {
+	db      *models.Database
+	key     *ecdsa.PrivateKey
+	subject string
+	client  *http.Client
+}
+
+// NewWebPusher loads the VAPID key pair, generating and storing one on first use
+func NewWebPusher(db *models.Database, subject string, client *http.Client) (*WebPusher, error) {
+	var encoded string
+	found, err := db.GetSetting(vapidKeySetting, &encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var key *ecdsa.PrivateKey
+	if found {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored VAPID key: %w", err)
+		}
+		if key, err = x509.ParseECPrivateKey(der); err != nil {
+			return nil, fmt.Errorf("invalid stored VAPID key: %w", err)
+		}
+	} else {
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.SaveSetting(vapidKeySetting, base64.StdEncoding.EncodeToString(der)); err != nil {
+			return nil, err
+		}
+		logger.Infof("Generated VAPID key pair for Web Push")
+	}
+
+	return &WebPusher{
+		db:      db,
+		key:     key,
+		subject: subject,
+		client:  client,
+	}, nil
+}
+
+// PublicKey returns the application server key browsers subscribe with
+func (p *WebPusher) PublicKey() string {
+	key, err := utils.VAPIDPublicKey(p.key)
+	if err != nil {
+		logger.Errorf("Failed to encode VAPID public key: %v", err)
+	}
+	return key
+}
+
+// Notify pushes a notification to every subscription whose filter matches the endpoint
+func (p *WebPusher) Notify(endpoint structs.Endpoint, title, body string, urgent bool) {
+	subs, err := p.db.GetAllPushSubscriptions()
+	if err != nil {
+		logger.Errorf("Failed to load push subscriptions: %v", err)
+		return
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"title": title,
+		"body":  body,
+		"tag":   endpoint.Name,
+	})
+	if err != nil {
+		logger.Errorf("Failed to marshal push payload: %v", err)
+		return
+	}
+
+	urgency := "normal"
+	if urgent {
+		urgency = "high"
+	}
+
+	for _, sub := range subs {
+		if sub.Filter.Matches(endpoint) {
+			go p.send(sub, payload, urgency)
+		}
+	}
+}
+
+// send encrypts and delivers one notification, dropping subscriptions the push service reports gone
+func (p *WebPusher) send(sub *structs.PushSubscription, payload []byte, urgency string) {
+	body, err := utils.EncryptWebPush(sub.Keys.P256dh, sub.Keys.Auth, payload)
+	if err != nil {
+		logger.Errorf("Failed to encrypt push notification: %v", err)
+		return
+	}
+
+	target, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		logger.Errorf("Invalid push endpoint %s: %v", sub.Endpoint, err)
+		return
+	}
+
+	auth, err := utils.VAPIDAuthorization(p.key, target.Scheme+"://"+target.Host, p.subject, time.Now().Add(12*time.Hour))
+	if err != nil {
+		logger.Errorf("Failed to sign VAPID token: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		logger.Errorf("Failed to create push request: %v", err)
+		return
+	}
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(webPushTTL.Seconds())))
+	req.Header.Set("Urgency", urgency)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.Errorf("Failed to send push notification: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		logger.Debugf("Push notification delivered to %s", target.Host)
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		logger.Infof("Push subscription expired, removing: %s", sub.ID)
+		if err := p.db.DeletePushSubscription(sub.ID); err != nil {
+			logger.Errorf("Failed to remove push subscription: %v", err)
+		}
+	default:
+		logger.Errorf("Push service returned status %d", resp.StatusCode)
+	}
+}
+
+// WebPushPublicKey returns the VAPID public key, or "" if Web Push is unavailable
+func (m *Monitor) WebPushPublicKey() string {
+	if m.push == nil {
+		return ""
+	}
+	return m.push.PublicKey()
+}
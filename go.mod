@@ -2,6 +2,19 @@ module github.com/ashanmugaraja/cronzee
 
 go 1.21
 
-require go.etcd.io/bbolt v1.3.8
+require (
+	github.com/chromedp/cdproto v0.0.0-20240202021202-6d0b6a386732
+	github.com/chromedp/chromedp v0.9.5
+	go.etcd.io/bbolt v1.3.8
+	gopkg.in/yaml.v3 v3.0.1
+)
 
-require golang.org/x/sys v0.4.0 // indirect
+require (
+	github.com/chromedp/sysutil v1.0.0 // indirect
+	github.com/gobwas/httphead v0.1.0 // indirect
+	github.com/gobwas/pool v0.2.1 // indirect
+	github.com/gobwas/ws v1.3.2 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+)
@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/ashanmugaraja/cronzee/app/config"
 	"github.com/ashanmugaraja/cronzee/app/logger"
@@ -23,8 +23,25 @@ func main() {
 	// Parse command-line flags
 	configFile := flag.String("config", "config.json", "Path to configuration file")
 	dbPath := flag.String("db", "sitewatch.db", "Path to database file")
+	readOnly := flag.Bool("read-only", false, "Serve the dashboard and APIs from the database without running checks or alerts")
+	migrateStorage := flag.Bool("migrate-storage", false, "Copy all data between storage backends and exit, e.g. --migrate-storage from=bolt to=bolt:/path/new.db")
 	flag.Parse()
 
+	// Migrate storage instead of monitoring
+	if *migrateStorage {
+		from, to, err := models.ParseMigrationArgs(flag.Args(), *dbPath)
+		if err != nil {
+			logger.Errorf("Invalid --migrate-storage arguments: %v", err)
+			os.Exit(2)
+		}
+		if _, err := models.MigrateStorage(from, to); err != nil {
+			logger.Errorf("Storage migration failed: %v", err)
+			os.Exit(1)
+		}
+		logger.Infof("Storage migrated to %s", to)
+		return
+	}
+
 	logger.Infof("Starting Site Watch...")
 
 	// Load configuration
@@ -34,13 +51,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize database
-	db, err := models.NewDatabase(*dbPath)
+	if *readOnly {
+		cfg.ReadOnly = true
+	}
+
+	// Initialize database; a read-only instance never writes to the file
+	openDatabase := models.NewDatabase
+	if cfg.ReadOnly {
+		openDatabase = models.NewReadOnlyDatabase
+	}
+	db, err := openDatabase(*dbPath)
 	if err != nil {
 		logger.Errorf("Failed to initialize database: %v", err)
 		os.Exit(1)
 	}
 	defer db.Close()
+	db.SetEndpointDefaults(cfg.EndpointDefaults())
+
+	// Endpoints listed in the config file are added on start; ones already stored keep their settings
+	if !cfg.ReadOnly {
+		if err := db.MigrateFromConfig(cfg.Endpoints); err != nil {
+			logger.Errorf("Failed to load endpoints from config: %v", err)
+			os.Exit(1)
+		}
+	}
 
 	// Initialize monitor
 	monitor := worker.NewMonitor(cfg, db)
@@ -53,11 +87,12 @@ func main() {
 	monitor.Start()
 
 	// Start web server if enabled
+	var server *http.Server
 	if cfg.Server.Enabled {
 		r := router.NewRouter(monitor, db, cfg)
 		addr := fmt.Sprintf(":%d", cfg.Server.Port)
 		
-		server := &http.Server{
+		server = &http.Server{
 			Addr:    addr,
 			Handler: r,
 		}
@@ -76,7 +111,15 @@ func main() {
 	<-sigChan
 
 	logger.Infof("Shutting down Site Watch...")
+
+	// Stop taking requests first, so no check or change starts while the monitor drains
+	if server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout.Duration)
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Errorf("Web server shutdown: %v", err)
+		}
+		cancel()
+	}
 	monitor.Stop()
-	time.Sleep(1 * time.Second)
 	logger.Infof("Shutdown complete")
 }